@@ -0,0 +1,123 @@
+package common
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+)
+
+// BlockCache is a least recently used cache of data derived from finalized blocks, keyed by block ID.
+// Finalized blocks never change, so their entries never need to be invalidated.
+// The cache is bounded by the estimated size in bytes of its entries rather than by their number,
+// and can also be queried by block number. A nil cache stores nothing.
+type BlockCache[V any] struct {
+	mu       sync.Mutex
+	maxBytes int64
+	bytes    int64
+	entries  map[string]*list.Element
+	numbers  map[uint32]*list.Element
+	order    *list.List
+}
+
+// blockCacheEntry is a cached value together with the block it was derived from
+type blockCacheEntry[V any] struct {
+	id     string
+	number uint32
+	value  V
+	size   int64
+}
+
+// NewBlockCache creates a cache holding up to maxBytes of entries, nil when maxBytes is not positive
+func NewBlockCache[V any](maxBytes int64) *BlockCache[V] {
+	if maxBytes <= 0 {
+		return nil
+	}
+	return &BlockCache[V]{
+		maxBytes: maxBytes,
+		entries:  map[string]*list.Element{},
+		numbers:  map[uint32]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Get returns the value cached for a block ID, marking it as recently used
+func (c *BlockCache[V]) Get(id string) (V, bool) {
+	if c == nil {
+		var zero V
+		return zero, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.use(c.entries[strings.ToLower(id)])
+}
+
+// GetByNumber returns the value cached for a block number, marking it as recently used
+func (c *BlockCache[V]) GetByNumber(number uint32) (V, bool) {
+	if c == nil {
+		var zero V
+		return zero, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.use(c.numbers[number])
+}
+
+// use returns the value of an element and moves it to the front, false when the element is nil
+func (c *BlockCache[V]) use(element *list.Element) (V, bool) {
+	if element == nil {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*blockCacheEntry[V]).value, true
+}
+
+// Put stores the value derived from a finalized block with its estimated size in bytes,
+// evicting the least recently used entries until the cache fits its budget.
+// Values larger than the whole budget are not stored.
+func (c *BlockCache[V]) Put(id string, number uint32, value V, size int64) {
+	if c == nil || size > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id = strings.ToLower(id)
+	if element, ok := c.entries[id]; ok {
+		c.remove(element)
+	}
+
+	entry := &blockCacheEntry[V]{id: id, number: number, value: value, size: size}
+	element := c.order.PushFront(entry)
+	c.entries[id] = element
+	c.numbers[number] = element
+	c.bytes += size
+
+	for c.bytes > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove deletes an element from the cache
+func (c *BlockCache[V]) remove(element *list.Element) {
+	entry := element.Value.(*blockCacheEntry[V])
+	c.order.Remove(element)
+	delete(c.entries, entry.id)
+	if c.numbers[entry.number] == element {
+		delete(c.numbers, entry.number)
+	}
+	c.bytes -= entry.size
+}
+
+// Len returns the number of cached entries
+func (c *BlockCache[V]) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
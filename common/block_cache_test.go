@@ -0,0 +1,52 @@
+package common
+
+import "testing"
+
+func TestBlockCache(t *testing.T) {
+	cache := NewBlockCache[string](100)
+
+	cache.Put("0xAA", 1, "first", 40)
+	cache.Put("0xbb", 2, "second", 40)
+	if value, ok := cache.Get("0xaa"); !ok || value != "first" {
+		t.Errorf("Get() = %q, %v, want first", value, ok)
+	}
+	if value, ok := cache.GetByNumber(2); !ok || value != "second" {
+		t.Errorf("GetByNumber() = %q, %v, want second", value, ok)
+	}
+
+	// The least recently used entry is evicted to fit the new one
+	cache.GetByNumber(1)
+	cache.Put("0xcc", 3, "third", 40)
+	if _, ok := cache.Get("0xbb"); ok {
+		t.Error("Get() expected the least recently used entry to be evicted")
+	}
+	if _, ok := cache.GetByNumber(2); ok {
+		t.Error("GetByNumber() expected the evicted entry to be forgotten")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+
+	// Entries larger than the budget are not stored
+	cache.Put("0xdd", 4, "fourth", 101)
+	if _, ok := cache.GetByNumber(4); ok || cache.Len() != 2 {
+		t.Error("Put() expected an oversized entry to be ignored")
+	}
+
+	// Replacing an entry keeps the size accounting right
+	cache.Put("0xcc", 3, "third again", 70)
+	if value, _ := cache.Get("0xcc"); value != "third again" || cache.Len() != 1 {
+		t.Errorf("Put() replaced value = %q with %d entries", value, cache.Len())
+	}
+}
+
+func TestBlockCache_Disabled(t *testing.T) {
+	cache := NewBlockCache[string](0)
+	if cache != nil {
+		t.Fatal("NewBlockCache() expected nil without memory")
+	}
+	cache.Put("0xaa", 1, "first", 1)
+	if _, ok := cache.Get("0xaa"); ok || cache.Len() != 0 {
+		t.Error("nil cache expected to store nothing")
+	}
+}
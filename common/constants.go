@@ -34,6 +34,9 @@ const (
 	NetworkOptionsEndpoint         = "/network/options"
 	NetworkStatusEndpoint          = "/network/status"
 	HealthEndpoint                 = "/health"
+	LivenessEndpoint               = "/health/live"
+	ReadinessEndpoint              = "/health/ready"
+	MetricsEndpoint                = "/metrics"
 	EventsBlocksEndpoint           = "/events/blocks"
 	SearchTransactionsEndpoint     = "/search/transactions"
 	CallEndpoint                   = "/call"
@@ -41,10 +44,11 @@ const (
 
 // Operation types for VeChain
 const (
-	OperationTypeTransfer      = "Transfer"
-	OperationTypeFee           = "Fee"
-	OperationTypeFeeDelegation = "FeeDelegation"
-	OperationTypeContractCall  = "ContractCall"
+	OperationTypeTransfer       = "Transfer"
+	OperationTypeFee            = "Fee"
+	OperationTypeFeeDelegation  = "FeeDelegation"
+	OperationTypeContractCall   = "ContractCall"
+	OperationTypeContractDeploy = "ContractDeploy"
 )
 
 // Operation statuses for VeChain
@@ -54,6 +58,20 @@ const (
 	OperationStatusReverted  = "Reverted"
 )
 
+// Block revisions of the finality checkpoints, accepted wherever a block hash is
+const (
+	RevisionJustified = "justified"
+	RevisionFinalized = "finalized"
+)
+
+// Sync stages reported by /network/status
+const (
+	SyncStageConnecting = "connecting"
+	SyncStageBlockSync  = "block sync"
+	SyncStageSynced     = "synced"
+	SyncStageStalled    = "stalled"
+)
+
 // Blockchain identifier
 const (
 	BlockchainName = "vechainthor"
@@ -72,7 +90,9 @@ const (
 
 // Call methods for VeChain
 const (
-	CallMethodInspectClauses = "inspect_clauses"
+	CallMethodInspectClauses     = "inspect_clauses"
+	CallMethodSponsorTransaction = "sponsor_transaction"
+	CallMethodTransactionStatus  = "transaction_status"
 )
 
 // Delegator account metadata key
@@ -80,6 +100,18 @@ const (
 	DelegatorAccountMetadataKey = "fee_delegator_account"
 )
 
+// Batch metadata key, set in preprocess metadata to build one transaction per origin
+const (
+	BatchMetadataKey = "batch"
+)
+
+// Operation source metadata, telling clause transfers apart from transfers made during contract execution
+const (
+	OperationSourceMetadataKey = "source"
+	OperationSourceClause      = "clause"
+	OperationSourceInternal    = "internal"
+)
+
 const (
 	VTHOContractAddress = "0x0000000000000000000000000000456e65726779"
 )
@@ -0,0 +1,88 @@
+package delegation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// requestTimeout bounds the time spent waiting for the delegation service
+const requestTimeout = 10 * time.Second
+
+// SignatureRequest is the body sent to a VIP-201 delegation service
+type SignatureRequest struct {
+	Origin string `json:"origin"`
+	Raw    string `json:"raw"`
+}
+
+// SignatureResponse is the body returned by a VIP-201 delegation service
+type SignatureResponse struct {
+	Signature string `json:"signature"`
+}
+
+// Client requests gas payer signatures from a remote VIP-201 delegation service
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the delegation service at url
+func NewClient(url string) *Client {
+	return &Client{
+		url:        url,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// RequestSignature sends the transaction to the delegation service and returns the gas payer signature
+// over the delegator signing hash of the transaction for the given origin
+func (c *Client) RequestSignature(origin thor.Address, trx *tx.Transaction) ([]byte, error) {
+	if !trx.Features().IsDelegated() {
+		return nil, fmt.Errorf("transaction is not delegated")
+	}
+
+	raw, err := trx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	body, err := json.Marshal(&SignatureRequest{
+		Origin: origin.String(),
+		Raw:    hexutil.Encode(raw),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	resp, err := c.httpClient.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("delegation service request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("delegation service returned status %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+
+	var signatureResponse SignatureResponse
+	if err := json.NewDecoder(resp.Body).Decode(&signatureResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode delegation service response: %w", err)
+	}
+	signature, err := hexutil.Decode(signatureResponse.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delegation signature: %w", err)
+	}
+	if len(signature) != 65 {
+		return nil, fmt.Errorf("invalid delegation signature length %d", len(signature))
+	}
+	return signature, nil
+}
@@ -0,0 +1,62 @@
+package delegation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	meshtests "github.com/vechain/mesh/tests"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+func createTestTransaction(features tx.Features) *tx.Transaction {
+	to, _ := thor.ParseAddress(meshtests.FirstSoloAddress)
+	return tx.NewBuilder(tx.TypeLegacy).
+		ChainTag(0x27).
+		Expiration(720).
+		Gas(21000).
+		Features(features).
+		Clause(tx.NewClause(&to)).
+		Build()
+}
+
+func TestClient_RequestSignature(t *testing.T) {
+	stub := meshtests.NewDelegatorStub(meshtests.TestDelegatorPrivateKey)
+	defer stub.Close()
+
+	origin, _ := thor.ParseAddress(meshtests.TestAddress1)
+	trx := createTestTransaction(tx.DelegationFeature)
+
+	signature, err := NewClient(stub.URL).RequestSignature(origin, trx)
+	if err != nil {
+		t.Fatalf("RequestSignature() error = %v", err)
+	}
+	if len(signature) != 65 || stub.Requests() != 1 {
+		t.Fatalf("RequestSignature() = %x after %d requests", signature, stub.Requests())
+	}
+}
+
+func TestClient_RequestSignature_Errors(t *testing.T) {
+	stub := meshtests.NewDelegatorStub(meshtests.TestDelegatorPrivateKey)
+	defer stub.Close()
+	origin, _ := thor.ParseAddress(meshtests.TestAddress1)
+
+	// Transactions without the delegation feature are not sent
+	if _, err := NewClient(stub.URL).RequestSignature(origin, createTestTransaction(0)); err == nil || stub.Requests() != 0 {
+		t.Errorf("RequestSignature() error = %v after %d requests, want error without request", err, stub.Requests())
+	}
+
+	stub.Reject.Store(true)
+	if _, err := NewClient(stub.URL).RequestSignature(origin, createTestTransaction(tx.DelegationFeature)); err == nil {
+		t.Error("RequestSignature() expected error when the service rejects the transaction")
+	}
+
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"signature":"0x1234"}`))
+	}))
+	defer invalid.Close()
+	if _, err := NewClient(invalid.URL).RequestSignature(origin, createTestTransaction(tx.DelegationFeature)); err == nil {
+		t.Error("RequestSignature() expected error for a short signature")
+	}
+}
@@ -53,6 +53,28 @@ const (
 
 	// Mode errors
 	ErrAPIDoesNotSupportOfflineMode = 33
+
+	// Token errors
+	ErrUnsupportedToken = 34
+
+	// Events errors
+	ErrFailedToSyncBlockEvents = 35
+
+	// Search errors
+	ErrFailedToSearchTransactions = 36
+
+	// Simulation errors
+	ErrClauseSimulationReverted = 37
+
+	// Fee delegation errors
+	ErrFeeDelegationFailed = 38
+	ErrSponsorshipRejected = 39
+
+	// Finality errors
+	ErrFailedToGetFinalityCheckpoints = 40
+
+	// Events journal errors
+	ErrEventsJournalNetworkMismatch = 41
 )
 
 // Errors contains all the predefined Mesh errors for VeChain
@@ -106,6 +128,28 @@ var Errors = map[int]*types.Error{
 
 	// Mode errors
 	ErrAPIDoesNotSupportOfflineMode: {Code: ErrAPIDoesNotSupportOfflineMode, Message: "API does not support offline mode.", Retriable: false},
+
+	// Token errors
+	ErrUnsupportedToken: {Code: ErrUnsupportedToken, Message: "Unsupported token.", Retriable: false},
+
+	// Events errors
+	ErrFailedToSyncBlockEvents: {Code: ErrFailedToSyncBlockEvents, Message: "Failed to sync block events.", Retriable: true},
+
+	// Search errors
+	ErrFailedToSearchTransactions: {Code: ErrFailedToSearchTransactions, Message: "Failed to search transactions.", Retriable: true},
+
+	// Simulation errors
+	ErrClauseSimulationReverted: {Code: ErrClauseSimulationReverted, Message: "Clause simulation reverted.", Retriable: false},
+
+	// Fee delegation errors
+	ErrFeeDelegationFailed: {Code: ErrFeeDelegationFailed, Message: "Fee delegation failed.", Retriable: true},
+	ErrSponsorshipRejected: {Code: ErrSponsorshipRejected, Message: "Transaction rejected by the sponsorship policy.", Retriable: false},
+
+	// Finality errors
+	ErrFailedToGetFinalityCheckpoints: {Code: ErrFailedToGetFinalityCheckpoints, Message: "Failed to get finality checkpoints.", Retriable: true},
+
+	// Events journal errors
+	ErrEventsJournalNetworkMismatch: {Code: ErrEventsJournalNetworkMismatch, Message: "Block events journal belongs to another network.", Retriable: false},
 }
 
 // GetError returns an error by code, or nil if not found
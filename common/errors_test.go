@@ -231,6 +231,14 @@ func TestErrorConstants(t *testing.T) {
 		ErrTransactionNotFoundInMempool,
 		ErrFailedToSubmitTransaction,
 		ErrAPIDoesNotSupportOfflineMode,
+		ErrUnsupportedToken,
+		ErrFailedToSyncBlockEvents,
+		ErrFailedToSearchTransactions,
+		ErrClauseSimulationReverted,
+		ErrFeeDelegationFailed,
+		ErrSponsorshipRejected,
+		ErrFailedToGetFinalityCheckpoints,
+		ErrEventsJournalNetworkMismatch,
 	}
 
 	for _, code := range allCodes {
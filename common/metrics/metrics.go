@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	meshcommon "github.com/vechain/mesh/common"
+)
+
+// errorBodyLimit bounds the part of an error response kept to read its Mesh error code
+const errorBodyLimit = 4096
+
+// otherEndpoint labels the requests to paths that are not served, to keep the label set bounded
+const otherEndpoint = "other"
+
+// Registry holds every metric exported on the metrics endpoint
+var Registry = prometheus.NewRegistry()
+
+var (
+	// RequestsTotal counts the HTTP requests by endpoint and status code
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "mesh",
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by endpoint and status code.",
+	}, []string{"endpoint", "status"})
+
+	// RequestDuration observes the time taken to serve HTTP requests by endpoint
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "mesh",
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to serve HTTP requests by endpoint.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	// ErrorsTotal counts the Mesh errors returned by endpoint and error code
+	ErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "mesh",
+		Name:      "errors_total",
+		Help:      "Mesh errors returned by endpoint and error code.",
+	}, []string{"endpoint", "code"})
+
+	// ThorCallDuration observes the latency of the Thor node calls by client method and result
+	ThorCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "mesh",
+		Name:      "thor_client_call_duration_seconds",
+		Help:      "Latency of the Thor node calls by client method and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "result"})
+
+	// BlockHeight is the height of the best and finalized blocks of the Thor node
+	BlockHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "mesh",
+		Name:      "block_height",
+		Help:      "Height of the best and finalized blocks of the Thor node.",
+	}, []string{"revision"})
+
+	// ThorProcessExits counts the exits of the Thor child process that were not requested
+	ThorProcessExits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "mesh",
+		Name:      "thor_process_exits_total",
+		Help:      "Unexpected exits of the Thor child process.",
+	})
+)
+
+// endpoints are the paths labelled by their own name
+var endpoints = map[string]bool{
+	meshcommon.AccountBalanceEndpoint:         true,
+	meshcommon.BlockEndpoint:                  true,
+	meshcommon.BlockTransactionEndpoint:       true,
+	meshcommon.ConstructionCombineEndpoint:    true,
+	meshcommon.ConstructionDeriveEndpoint:     true,
+	meshcommon.ConstructionHashEndpoint:       true,
+	meshcommon.ConstructionPreprocessEndpoint: true,
+	meshcommon.ConstructionMetadataEndpoint:   true,
+	meshcommon.ConstructionParseEndpoint:      true,
+	meshcommon.ConstructionPayloadsEndpoint:   true,
+	meshcommon.ConstructionSubmitEndpoint:     true,
+	meshcommon.MempoolEndpoint:                true,
+	meshcommon.MempoolTransactionEndpoint:     true,
+	meshcommon.NetworkListEndpoint:            true,
+	meshcommon.NetworkOptionsEndpoint:         true,
+	meshcommon.NetworkStatusEndpoint:          true,
+	meshcommon.HealthEndpoint:                 true,
+	meshcommon.LivenessEndpoint:               true,
+	meshcommon.ReadinessEndpoint:              true,
+	meshcommon.EventsBlocksEndpoint:           true,
+	meshcommon.SearchTransactionsEndpoint:     true,
+	meshcommon.CallEndpoint:                   true,
+	meshcommon.MetricsEndpoint:                true,
+}
+
+func init() {
+	Registry.MustRegister(
+		RequestsTotal,
+		RequestDuration,
+		ErrorsTotal,
+		ThorCallDuration,
+		BlockHeight,
+		ThorProcessExits,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler returns the handler exporting the metrics in the Prometheus format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveThorCall records the latency of a Thor node call started at start
+func ObserveThorCall(method string, start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	ThorCallDuration.WithLabelValues(method, result).Observe(time.Since(start).Seconds())
+}
+
+// Middleware records the count and duration of the requests and the Mesh error codes of the responses
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		endpoint := r.URL.Path
+		if !endpoints[endpoint] {
+			endpoint = otherEndpoint
+		}
+		RequestsTotal.WithLabelValues(endpoint, strconv.Itoa(recorder.status)).Inc()
+		RequestDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+
+		if recorder.status != http.StatusOK {
+			var meshError struct {
+				Code *int32 `json:"code"`
+			}
+			if err := json.Unmarshal(recorder.body.Bytes(), &meshError); err == nil && meshError.Code != nil {
+				ErrorsTotal.WithLabelValues(endpoint, strconv.Itoa(int(*meshError.Code))).Inc()
+			}
+		}
+	})
+}
+
+// responseRecorder keeps the status code of a response and the beginning of its body when it is an error
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	if r.status != http.StatusOK && r.body.Len() < errorBodyLimit {
+		r.body.Write(data[:min(len(data), errorBodyLimit-r.body.Len())])
+	}
+	return r.ResponseWriter.Write(data)
+}
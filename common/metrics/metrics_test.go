@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	meshcommon "github.com/vechain/mesh/common"
+)
+
+func TestMiddleware(t *testing.T) {
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == meshcommon.BlockEndpoint {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":17,"message":"Block not found.","retriable":false}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+
+	ok := RequestsTotal.WithLabelValues(meshcommon.NetworkListEndpoint, "200")
+	failed := RequestsTotal.WithLabelValues(meshcommon.BlockEndpoint, "500")
+	other := RequestsTotal.WithLabelValues(otherEndpoint, "200")
+	blockErrors := ErrorsTotal.WithLabelValues(meshcommon.BlockEndpoint, "17")
+	before := []float64{testutil.ToFloat64(ok), testutil.ToFloat64(failed), testutil.ToFloat64(other), testutil.ToFloat64(blockErrors)}
+
+	for _, path := range []string{meshcommon.NetworkListEndpoint, meshcommon.BlockEndpoint, "/unknown/path"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, path, nil))
+	}
+
+	after := []float64{testutil.ToFloat64(ok), testutil.ToFloat64(failed), testutil.ToFloat64(other), testutil.ToFloat64(blockErrors)}
+	for i, name := range []string{"successful requests", "failed requests", "requests to unknown paths", "block errors"} {
+		if after[i]-before[i] != 1 {
+			t.Errorf("Middleware() counted %v %s, want 1", after[i]-before[i], name)
+		}
+	}
+}
+
+func TestObserveThorCall(t *testing.T) {
+	before := testutil.CollectAndCount(ThorCallDuration)
+	ObserveThorCall("TestObserveThorCall", time.Now(), nil)
+	ObserveThorCall("TestObserveThorCall", time.Now(), errors.New("node unavailable"))
+
+	if count := testutil.CollectAndCount(ThorCallDuration); count != before+2 {
+		t.Errorf("ObserveThorCall() recorded %d series, want one per result", count-before)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ThorProcessExits.Add(0)
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, meshcommon.MetricsEndpoint, nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Handler() status code = %d, want %d", w.Code, http.StatusOK)
+	}
+	for _, name := range []string{"mesh_thor_process_exits_total", "go_goroutines"} {
+		if !strings.Contains(w.Body.String(), name) {
+			t.Errorf("Handler() body is missing %s", name)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -50,6 +51,81 @@ func (c ClauseAdapter) GetValue() ClauseValue { return c.Clause.Value }
 func (c ClauseAdapter) GetTo() *thor.Address  { return c.Clause.To }
 func (c ClauseAdapter) GetData() string       { return c.Clause.Data }
 
+// TransactionFee holds the fee values reported by the receipt of an executed transaction
+type TransactionFee struct {
+	GasUsed  uint64
+	GasPayer string
+	Paid     *big.Int
+	Reward   *big.Int
+}
+
+// NewTransactionFee creates a TransactionFee from receipt values
+func NewTransactionFee(gasUsed uint64, gasPayer thor.Address, paid, reward *math.HexOrDecimal256) *TransactionFee {
+	fee := &TransactionFee{
+		GasUsed: gasUsed,
+		Paid:    big.NewInt(0),
+		Reward:  big.NewInt(0),
+	}
+	if !gasPayer.IsZero() {
+		fee.GasPayer = gasPayer.String()
+	}
+	if paid != nil {
+		fee.Paid = new(big.Int).Set((*big.Int)(paid))
+	}
+	if reward != nil {
+		fee.Reward = new(big.Int).Set((*big.Int)(reward))
+	}
+	return fee
+}
+
+// ClauseOutput holds the execution output of a single clause
+type ClauseOutput struct {
+	ContractAddress *thor.Address
+	Events          []*api.Event
+	Transfers       []*api.Transfer
+}
+
+// JSONOutputsToClauseOutputs converts the outputs of an expanded block transaction into ClauseOutput
+func JSONOutputsToClauseOutputs(outputs []*api.JSONOutput) []*ClauseOutput {
+	clauseOutputs := make([]*ClauseOutput, len(outputs))
+	for i, output := range outputs {
+		clauseOutput := &ClauseOutput{ContractAddress: output.ContractAddress}
+		for _, event := range output.Events {
+			clauseOutput.Events = append(clauseOutput.Events, &api.Event{Address: event.Address, Topics: event.Topics, Data: event.Data})
+		}
+		for _, transfer := range output.Transfers {
+			clauseOutput.Transfers = append(clauseOutput.Transfers, &api.Transfer{Sender: transfer.Sender, Recipient: transfer.Recipient, Amount: transfer.Amount})
+		}
+		clauseOutputs[i] = clauseOutput
+	}
+	return clauseOutputs
+}
+
+// APIOutputsToClauseOutputs converts the outputs of a transaction receipt into ClauseOutput
+func APIOutputsToClauseOutputs(outputs []*api.Output) []*ClauseOutput {
+	clauseOutputs := make([]*ClauseOutput, len(outputs))
+	for i, output := range outputs {
+		clauseOutputs[i] = &ClauseOutput{
+			ContractAddress: output.ContractAddress,
+			Events:          output.Events,
+			Transfers:       output.Transfers,
+		}
+	}
+	return clauseOutputs
+}
+
+// CallResultsToClauseOutputs converts the results of a clause simulation into ClauseOutput
+func CallResultsToClauseOutputs(results []*api.CallResult) []*ClauseOutput {
+	clauseOutputs := make([]*ClauseOutput, len(results))
+	for i, result := range results {
+		clauseOutputs[i] = &ClauseOutput{
+			Events:    result.Events,
+			Transfers: result.Transfers,
+		}
+	}
+	return clauseOutputs
+}
+
 type ClauseParser struct {
 	vechainClient       meshthor.VeChainClientInterface
 	operationsExtractor *OperationsExtractor
@@ -61,18 +137,54 @@ func NewClauseParser(vechainClient meshthor.VeChainClientInterface, operationsEx
 	return &ClauseParser{vechainClient: vechainClient, operationsExtractor: operationsExtractor, vip180Encoder: vip180.NewVIP180Encoder(), bytesHandler: meshcrypto.NewBytesHandler()}
 }
 
-// ParseTransactionOperationsFromClauseData parses operations from clause data with client for contract calls
+// ParseTransactionOperationsFromClauseData parses operations from the clause data of a transaction that was not executed.
+// The VET sent along with a contract call or deployment is reported as transfer operations next to it,
+// as it is once the transaction is executed.
 func (e *ClauseParser) ParseTransactionOperationsFromClauseData(clauseData []ClauseData, originAddr string, delegatorAddr string, gas uint64, status *string) ([]*types.Operation, error) {
+	return e.parseOperations(clauseData, nil, originAddr, delegatorAddr, gas, nil, status, false)
+}
+
+// ParseConstructionOperations parses the operations a transaction was built from.
+// The VET sent along with a contract call or deployment is only reported in the "value" metadata of
+// its operation, so that the operations build the same transaction again.
+func (e *ClauseParser) ParseConstructionOperations(clauseData []ClauseData, originAddr string, delegatorAddr string, gas uint64) ([]*types.Operation, error) {
+	return e.parseOperations(clauseData, nil, originAddr, delegatorAddr, gas, nil, nil, true)
+}
+
+// ParseExecutedTransactionOperations parses operations from the clause data of a transaction included in a block.
+// The fee operation reports the VTHO paid according to the receipt and debits it from the gas payer.
+// When clause outputs are available, VET transfers are taken from their transfer logs and token
+// transfers from their Transfer events, so that movements made by contracts are reported as well.
+func (e *ClauseParser) ParseExecutedTransactionOperations(clauseData []ClauseData, outputs []*ClauseOutput, originAddr string, delegatorAddr string, fee *TransactionFee, status *string) ([]*types.Operation, error) {
+	return e.parseOperations(clauseData, outputs, originAddr, delegatorAddr, fee.GasUsed, fee, status, false)
+}
+
+// ParseSimulatedTransactionOperations parses operations from the clause data of a pending transaction and the
+// outputs of its simulation, so that movements made by contracts are previewed. As the transaction is not
+// executed yet, the fee operation reports the gas limit.
+func (e *ClauseParser) ParseSimulatedTransactionOperations(clauseData []ClauseData, outputs []*ClauseOutput, originAddr string, delegatorAddr string, gas uint64, status *string) ([]*types.Operation, error) {
+	return e.parseOperations(clauseData, outputs, originAddr, delegatorAddr, gas, nil, status, false)
+}
+
+// parseOperations parses operations from clause data, using the receipt fee and clause outputs when available.
+// For construction, the VET value of contract clauses stays in their operation instead of transfer operations.
+func (e *ClauseParser) parseOperations(clauseData []ClauseData, outputs []*ClauseOutput, originAddr string, delegatorAddr string, gas uint64, fee *TransactionFee, status *string, construction bool) ([]*types.Operation, error) {
 	var operations []*types.Operation
 	hasValueTransfer, hasContractInteraction, hasEnergyTransfer, err := e.analyzeClauses(clauseData, gas)
 	if err != nil {
 		return nil, err
 	}
+	if fee != nil {
+		hasEnergyTransfer = fee.Paid.Sign() > 0
+	}
 
 	if !hasValueTransfer && !hasContractInteraction && !hasEnergyTransfer {
 		return operations, nil
 	}
 
+	// Outputs are only reported for transactions that were not reverted, one per clause
+	hasOutputs := len(outputs) > 0 && len(outputs) == len(clauseData)
+
 	operationIndex := 0
 	for clauseIndex, clause := range clauseData {
 		value, err := e.getClauseValue(clause)
@@ -80,33 +192,64 @@ func (e *ClauseParser) ParseTransactionOperationsFromClauseData(clauseData []Cla
 			return nil, err
 		}
 
-		// Try VIP180 token transfer first
-		if e.isVIP180Transfer(clause, value) {
-			ops, nextIndex := e.parseVIP180Transfer(clause, clauseIndex, operationIndex, originAddr, status)
+		// Direct transfer of a supported token, nil for any other clause
+		transferCall, err := e.decodeVIP180TransferCall(clause, value)
+		if err != nil {
+			return nil, err
+		}
+
+		// Executed clauses: VET and token movements are taken from the clause output
+		if hasOutputs {
+			ops, nextIndex, err := e.parseClauseOutput(clause, outputs[clauseIndex], transferCall, clauseIndex, operationIndex, originAddr, value, status)
+			if err != nil {
+				return nil, err
+			}
 			operations = append(operations, ops...)
 			operationIndex = nextIndex
+			if transferCall == nil && e.hasContractInteraction(clause) {
+				op := e.createContractInteractionOperation(clause, clauseIndex, operationIndex, originAddr, value, outputs[clauseIndex].ContractAddress, status)
+				operations = append(operations, op)
+				operationIndex++
+			}
 			continue
 		}
 
-		// Regular VET transfer
-		if value.Cmp(big.NewInt(0)) > 0 {
-			ops, nextIndex := e.parseVETTransfer(clause, clauseIndex, operationIndex, originAddr, value, status)
+		// Try VIP180 token transfer first
+		if transferCall != nil {
+			ops, nextIndex := e.parseVIP180Transfer(transferCall, clauseIndex, operationIndex, originAddr, status)
 			operations = append(operations, ops...)
 			operationIndex = nextIndex
+			continue
 		}
 
-		// Contract interaction
+		// Contract interaction, the VET sent along with the call is part of the operation
 		if e.hasContractInteraction(clause) {
-			op := e.createContractInteractionOperation(clause, clauseIndex, operationIndex, originAddr, status)
+			if !construction && value.Sign() > 0 {
+				ops, nextIndex := e.parseVETTransfer(clause, clauseIndex, operationIndex, originAddr, value, status)
+				operations = append(operations, ops...)
+				operationIndex = nextIndex
+			}
+			op := e.createContractInteractionOperation(clause, clauseIndex, operationIndex, originAddr, value, nil, status)
 			operations = append(operations, op)
 			operationIndex++
+			continue
+		}
+
+		// Regular VET transfer
+		if value.Cmp(big.NewInt(0)) > 0 {
+			ops, nextIndex := e.parseVETTransfer(clause, clauseIndex, operationIndex, originAddr, value, status)
+			operations = append(operations, ops...)
+			operationIndex = nextIndex
 		}
 	}
 
 	// Add energy transfer operation if needed
 	if hasEnergyTransfer {
-		op := e.createEnergyTransferOperation(operationIndex, originAddr, delegatorAddr, gas, status)
-		operations = append(operations, op)
+		if fee != nil {
+			operations = append(operations, e.createPaidFeeOperation(operationIndex, originAddr, delegatorAddr, fee, status))
+		} else {
+			operations = append(operations, e.createEnergyTransferOperation(operationIndex, originAddr, delegatorAddr, gas, status))
+		}
 	}
 
 	return operations, nil
@@ -151,32 +294,57 @@ func (e *ClauseParser) isVIP180Transfer(clause ClauseData, value *big.Int) bool
 		e.vip180Encoder.IsVIP180TransferCallData(clause.GetData())
 }
 
-// hasContractInteraction checks if a clause has contract interaction
+// hasContractInteraction checks if a clause has contract interaction, "0x" being empty call data
 func (e *ClauseParser) hasContractInteraction(clause ClauseData) bool {
-	return len(clause.GetData()) > 0
+	data := clause.GetData()
+	return len(data) > 0 && data != "0x"
 }
 
-// parseVIP180Transfer parses VIP180 token transfer operations
-func (e *ClauseParser) parseVIP180Transfer(clause ClauseData, clauseIndex, operationIndex int, originAddr string, status *string) ([]*types.Operation, int) {
+// vip180TransferCall holds the decoded direct transfer call of a clause to a supported token
+type vip180TransferCall struct {
+	token    thor.Address
+	currency *types.Currency
+	to       thor.Address
+	value    *big.Int
+}
+
+// decodeVIP180TransferCall decodes the token transfer call of a clause.
+// It returns nil when the clause is not a transfer call or the token is not supported,
+// in which case the clause is reported as a plain contract call.
+// Token lookups that fail for any other reason are returned as errors.
+func (e *ClauseParser) decodeVIP180TransferCall(clause ClauseData, value *big.Int) (*vip180TransferCall, error) {
+	if !e.isVIP180Transfer(clause, value) {
+		return nil, nil
+	}
+
 	transferData, err := e.vip180Encoder.DecodeVIP180TransferCallData(clause.GetData())
 	if err != nil {
-		return []*types.Operation{}, operationIndex
+		return nil, nil
 	}
 
-	// Get token currency
 	tokenCurrency, err := e.operationsExtractor.GetTokenCurrencyFromContractAddress(clause.GetTo().String(), e.vechainClient)
 	if err != nil {
-		log.Println("error getting token currency, assigning UNKNOWN symbol", err)
-		tokenCurrency = &types.Currency{
-			Symbol:   "UNKNOWN",
-			Decimals: 18,
+		if !meshcommon.IsUnsupportedTokenError(err) {
+			return nil, fmt.Errorf("failed to resolve token %s: %w", clause.GetTo().String(), err)
 		}
+		log.Println("unsupported token, parsing transfer as contract call", clause.GetTo().String(), err)
+		return nil, nil
 	}
 
+	return &vip180TransferCall{
+		token:    *clause.GetTo(),
+		currency: tokenCurrency,
+		to:       thor.Address(transferData.To),
+		value:    transferData.Value,
+	}, nil
+}
+
+// parseVIP180Transfer parses VIP180 token transfer operations
+func (e *ClauseParser) parseVIP180Transfer(transferCall *vip180TransferCall, clauseIndex, operationIndex int, originAddr string, status *string) ([]*types.Operation, int) {
 	networkIndex := int64(clauseIndex)
 	operations := []*types.Operation{
-		e.createTransferOperation(operationIndex, &networkIndex, originAddr, "-"+transferData.Value.String(), tokenCurrency, clauseIndex, status),
-		e.createTransferOperation(operationIndex+1, &networkIndex, transferData.To.String(), transferData.Value.String(), tokenCurrency, clauseIndex, status),
+		e.createTransferOperation(operationIndex, &networkIndex, originAddr, "-"+transferCall.value.String(), transferCall.currency, clauseIndex, status),
+		e.createTransferOperation(operationIndex+1, &networkIndex, transferCall.to.String(), transferCall.value.String(), transferCall.currency, clauseIndex, status),
 	}
 
 	return operations, operationIndex + 2
@@ -199,6 +367,107 @@ func (e *ClauseParser) parseVETTransfer(clause ClauseData, clauseIndex, operatio
 	return operations, operationIndex + 1
 }
 
+// parseOutputTransfers parses VET transfer operations from the transfer log of a clause output.
+// The transfer carrying the clause value is marked as coming from the clause, every other
+// transfer was made by a contract during execution and is marked as internal.
+func (e *ClauseParser) parseOutputTransfers(clause ClauseData, output *ClauseOutput, clauseIndex, operationIndex int, originAddr string, value *big.Int, status *string) ([]*types.Operation, int) {
+	var operations []*types.Operation
+
+	clauseRecipient := clause.GetTo()
+	if clauseRecipient == nil {
+		clauseRecipient = output.ContractAddress
+	}
+
+	clauseTransferFound := value.Sign() == 0
+	for _, transfer := range output.Transfers {
+		amount := big.NewInt(0)
+		if transfer.Amount != nil {
+			amount = (*big.Int)(transfer.Amount)
+		}
+
+		source := meshcommon.OperationSourceInternal
+		if !clauseTransferFound &&
+			transfer.Sender.String() == originAddr &&
+			amount.Cmp(value) == 0 &&
+			clauseRecipient != nil && transfer.Recipient == *clauseRecipient {
+			source = meshcommon.OperationSourceClause
+			clauseTransferFound = true
+		}
+
+		amountStr := amount.String()
+		sender := e.createTransferOperation(operationIndex, nil, transfer.Sender.String(), "-"+amountStr, meshcommon.VETCurrency, clauseIndex, status)
+		recipient := e.createTransferOperation(operationIndex+1, nil, transfer.Recipient.String(), amountStr, meshcommon.VETCurrency, clauseIndex, status)
+		sender.Metadata[meshcommon.OperationSourceMetadataKey] = source
+		recipient.Metadata[meshcommon.OperationSourceMetadataKey] = source
+		operations = append(operations, sender, recipient)
+		operationIndex += 2
+	}
+
+	return operations, operationIndex
+}
+
+// parseClauseOutput parses the VET transfers and VIP180 token transfers recorded in a clause output
+func (e *ClauseParser) parseClauseOutput(clause ClauseData, output *ClauseOutput, transferCall *vip180TransferCall, clauseIndex, operationIndex int, originAddr string, value *big.Int, status *string) ([]*types.Operation, int, error) {
+	operations, operationIndex := e.parseOutputTransfers(clause, output, clauseIndex, operationIndex, originAddr, value, status)
+	tokenOps, operationIndex, err := e.parseOutputTokenTransfers(output, transferCall, clauseIndex, operationIndex, originAddr, status)
+	if err != nil {
+		return nil, operationIndex, err
+	}
+	return append(operations, tokenOps...), operationIndex, nil
+}
+
+// parseOutputTokenTransfers parses VIP180 token transfer operations from the Transfer events of a clause output.
+// This covers direct transfers as well as transferFrom, mints, burns and tokens moved by contracts.
+// The event matching a direct transfer call of the clause is marked as coming from the clause.
+// Events of unsupported tokens are skipped, other token lookup failures are returned as errors.
+func (e *ClauseParser) parseOutputTokenTransfers(output *ClauseOutput, clauseTransfer *vip180TransferCall, clauseIndex, operationIndex int, originAddr string, status *string) ([]*types.Operation, int, error) {
+	var operations []*types.Operation
+
+	networkIndex := int64(clauseIndex)
+	for _, event := range output.Events {
+		transfer, err := e.vip180Encoder.DecodeVIP180TransferEvent(event.Topics, event.Data)
+		if err != nil {
+			continue
+		}
+
+		tokenCurrency, err := e.operationsExtractor.GetTokenCurrencyFromContractAddress(event.Address.String(), e.vechainClient)
+		if err != nil {
+			if !meshcommon.IsUnsupportedTokenError(err) {
+				return nil, operationIndex, fmt.Errorf("failed to resolve token %s: %w", event.Address.String(), err)
+			}
+			log.Println("unsupported token, skipping transfer event", event.Address.String(), err)
+			continue
+		}
+
+		source := meshcommon.OperationSourceInternal
+		if clauseTransfer != nil &&
+			event.Address == clauseTransfer.token &&
+			transfer.From.String() == originAddr &&
+			transfer.To == clauseTransfer.to &&
+			transfer.Value.Cmp(clauseTransfer.value) == 0 {
+			source = meshcommon.OperationSourceClause
+			clauseTransfer = nil
+		}
+
+		amountStr := transfer.Value.String()
+		// Mints and burns only move the balance of the non-zero side
+		if !transfer.From.IsZero() {
+			op := e.createTransferOperation(operationIndex, &networkIndex, transfer.From.String(), "-"+amountStr, tokenCurrency, clauseIndex, status)
+			op.Metadata[meshcommon.OperationSourceMetadataKey] = source
+			operations = append(operations, op)
+			operationIndex++
+		}
+		if !transfer.To.IsZero() {
+			op := e.createTransferOperation(operationIndex, &networkIndex, transfer.To.String(), amountStr, tokenCurrency, clauseIndex, status)
+			op.Metadata[meshcommon.OperationSourceMetadataKey] = source
+			operations = append(operations, op)
+			operationIndex++
+		}
+	}
+
+	return operations, operationIndex, nil
+}
+
 // createTransferOperation creates a transfer operation with common fields
 func (e *ClauseParser) createTransferOperation(index int, networkIndex *int64, address, amount string, currency *types.Currency, clauseIndex int, status *string) *types.Operation {
 	return &types.Operation{
@@ -214,24 +483,34 @@ func (e *ClauseParser) createTransferOperation(index int, networkIndex *int64, a
 	}
 }
 
-// createContractInteractionOperation creates a contract interaction operation
-func (e *ClauseParser) createContractInteractionOperation(clause ClauseData, clauseIndex, operationIndex int, originAddr string, status *string) *types.Operation {
-	toAddr := ""
+// createContractInteractionOperation creates a contract interaction operation.
+// Clauses without recipient deploy a contract and are reported as ContractDeploy operations,
+// with the address of the created contract when the clause output is known.
+// The VET value of the clause, if any, is reported in the "value" metadata key.
+func (e *ClauseParser) createContractInteractionOperation(clause ClauseData, clauseIndex, operationIndex int, originAddr string, value *big.Int, contractAddress *thor.Address, status *string) *types.Operation {
+	opType := meshcommon.OperationTypeContractCall
+	metadata := map[string]any{"clauseIndex": clauseIndex}
 	if clause.GetTo() != nil {
-		toAddr = clause.GetTo().String()
+		metadata["to"] = clause.GetTo().String()
+		metadata["data"] = strings.ToLower(clause.GetData())
+	} else {
+		opType = meshcommon.OperationTypeContractDeploy
+		metadata["bytecode"] = strings.ToLower(clause.GetData())
+		if contractAddress != nil {
+			metadata["contractAddress"] = contractAddress.String()
+		}
+	}
+	if value != nil && value.Sign() > 0 {
+		metadata["value"] = value.String()
 	}
 
 	return &types.Operation{
 		OperationIdentifier: &types.OperationIdentifier{Index: int64(operationIndex)},
-		Type:                meshcommon.OperationTypeContractCall,
+		Type:                opType,
 		Status:              status,
 		Account:             &types.AccountIdentifier{Address: originAddr},
 		Amount:              &types.Amount{Value: "0", Currency: meshcommon.VETCurrency},
-		Metadata: map[string]any{
-			"clauseIndex": clauseIndex,
-			"to":          toAddr,
-			"data":        "0x" + fmt.Sprintf("%x", clause.GetData()),
-		},
+		Metadata:            metadata,
 	}
 }
 
@@ -255,22 +534,60 @@ func (e *ClauseParser) createEnergyTransferOperation(operationIndex int, originA
 	}
 }
 
-// ParseTransactionOperationsFromJSONClauses is a helper function that parses operations from clauses
-func (e *ClauseParser) ParseTransactionOperationsFromJSONClauses(clauses []*api.JSONClause, originAddr string, delegatorAddr string, gas uint64, status *string) ([]*types.Operation, error) {
+// createPaidFeeOperation creates the fee operation of an executed transaction, debiting the paid VTHO from the gas payer
+func (e *ClauseParser) createPaidFeeOperation(operationIndex int, originAddr string, delegatorAddr string, fee *TransactionFee, status *string) *types.Operation {
+	feeType := meshcommon.OperationTypeFee
+	metadata := map[string]any{
+		"gasUsed": strconv.FormatUint(fee.GasUsed, 10),
+		"reward":  fee.Reward.String(),
+	}
+
+	if delegatorAddr != "" {
+		feeType = meshcommon.OperationTypeFeeDelegation
+		metadata[meshcommon.DelegatorAccountMetadataKey] = delegatorAddr
+	}
+
+	payer := fee.GasPayer
+	if payer == "" {
+		payer = originAddr
+	}
+
+	return &types.Operation{
+		OperationIdentifier: &types.OperationIdentifier{Index: int64(operationIndex)},
+		Type:                feeType,
+		Status:              status,
+		Account:             &types.AccountIdentifier{Address: payer},
+		Amount:              &types.Amount{Value: new(big.Int).Neg(fee.Paid).String(), Currency: meshcommon.VTHOCurrency},
+		Metadata:            metadata,
+	}
+}
+
+// JSONClausesToClauseData wraps api.JSONClause values into ClauseData
+func JSONClausesToClauseData(clauses []*api.JSONClause) []ClauseData {
 	clauseData := make([]ClauseData, len(clauses))
 	for i, clause := range clauses {
 		clauseData[i] = JSONClauseAdapter{Clause: clause}
 	}
-	return e.ParseTransactionOperationsFromClauseData(clauseData, originAddr, delegatorAddr, gas, status)
+	return clauseData
 }
 
-// ParseOperationsFromAPIClauses is a helper function that parses operations from transactions.Clauses
-func (e *ClauseParser) ParseOperationsFromAPIClauses(clauses api.Clauses, originAddr string, delegatorAddr string, gas uint64, status *string) ([]*types.Operation, error) {
+// APIClausesToClauseData wraps api.Clauses values into ClauseData
+func APIClausesToClauseData(clauses api.Clauses) []ClauseData {
 	clauseData := make([]ClauseData, len(clauses))
 	for i, clause := range clauses {
 		clauseData[i] = ClauseAdapter{Clause: clause}
 	}
-	return e.ParseTransactionOperationsFromClauseData(clauseData, originAddr, delegatorAddr, gas, status)
+	return clauseData
+}
+
+// ParseTransactionOperationsFromJSONClauses is a helper function that parses operations from clauses
+func (e *ClauseParser) ParseTransactionOperationsFromJSONClauses(clauses []*api.JSONClause, originAddr string, delegatorAddr string, gas uint64, status *string) ([]*types.Operation, error) {
+	return e.ParseTransactionOperationsFromClauseData(JSONClausesToClauseData(clauses), originAddr, delegatorAddr, gas, status)
+}
+
+// ParseOperationsFromAPIClauses is a helper function that parses operations from transactions.Clauses
+func (e *ClauseParser) ParseOperationsFromAPIClauses(clauses api.Clauses, originAddr string, delegatorAddr string, gas uint64, status *string) ([]*types.Operation, error) {
+	return e.ParseTransactionOperationsFromClauseData(APIClausesToClauseData(clauses), originAddr, delegatorAddr, gas, status)
 }
 
 // ParseClausesFromOptions parses clauses from map format to Thor tx.Clause objects
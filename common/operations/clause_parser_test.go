@@ -1,9 +1,11 @@
 package operations
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -70,7 +72,7 @@ func (e errorClauseData) GetData() string {
 
 func TestMeshTransactionEncoder_analyzeClauses(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name                        string
@@ -99,7 +101,7 @@ func TestMeshTransactionEncoder_analyzeClauses(t *testing.T) {
 			},
 			gas:                         0,
 			expectedValueTransfer:       true,
-			expectedContractInteraction: true,
+			expectedContractInteraction: false,
 			expectedEnergyTransfer:      false,
 		},
 		{
@@ -162,7 +164,7 @@ func TestMeshTransactionEncoder_analyzeClauses(t *testing.T) {
 
 func TestMeshTransactionEncoder_isVIP180Transfer(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name     string
@@ -224,7 +226,7 @@ func TestMeshTransactionEncoder_isVIP180Transfer(t *testing.T) {
 
 func TestMeshTransactionEncoder_hasContractInteraction(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name     string
@@ -241,13 +243,13 @@ func TestMeshTransactionEncoder_hasContractInteraction(t *testing.T) {
 			expected: true,
 		},
 		{
-			name: "has to address",
+			name: "empty data",
 			clause: JSONClauseAdapter{Clause: createTestJSONClause(
 				createTestAddress(meshtests.TestAddress1),
 				big.NewInt(0),
 				"0x",
 			)},
-			expected: true,
+			expected: false,
 		},
 		{
 			name: "no data and nil to",
@@ -281,7 +283,7 @@ func TestMeshTransactionEncoder_hasContractInteraction(t *testing.T) {
 
 func TestMeshTransactionEncoder_createTransferOperation(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name         string
@@ -349,7 +351,7 @@ func TestMeshTransactionEncoder_createTransferOperation(t *testing.T) {
 
 func TestMeshTransactionEncoder_createContractInteractionOperation(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name           string
@@ -357,6 +359,10 @@ func TestMeshTransactionEncoder_createContractInteractionOperation(t *testing.T)
 		clauseIndex    int
 		operationIndex int
 		originAddr     string
+		value          *big.Int
+		contract       *thor.Address
+		expectedType   string
+		dataKey        string
 		status         *string
 	}{
 		{
@@ -369,6 +375,8 @@ func TestMeshTransactionEncoder_createContractInteractionOperation(t *testing.T)
 			clauseIndex:    0,
 			operationIndex: 0,
 			originAddr:     meshtests.FirstSoloAddress,
+			expectedType:   meshcommon.OperationTypeContractCall,
+			dataKey:        "data",
 			status:         &testStatus,
 		},
 		{
@@ -381,19 +389,50 @@ func TestMeshTransactionEncoder_createContractInteractionOperation(t *testing.T)
 			clauseIndex:    1,
 			operationIndex: 1,
 			originAddr:     meshtests.FirstSoloAddress,
+			expectedType:   meshcommon.OperationTypeContractDeploy,
+			dataKey:        "bytecode",
+		},
+		{
+			name: "deployment with created contract",
+			clause: JSONClauseAdapter{Clause: createTestJSONClause(
+				nil,
+				big.NewInt(0),
+				"0x5678",
+			)},
+			clauseIndex:    0,
+			operationIndex: 0,
+			originAddr:     meshtests.FirstSoloAddress,
+			contract:       createTestAddress(meshtests.SimulatedContractAddress),
+			expectedType:   meshcommon.OperationTypeContractDeploy,
+			dataKey:        "bytecode",
+			status:         &testStatus,
+		},
+		{
+			name: "with value",
+			clause: JSONClauseAdapter{Clause: createTestJSONClause(
+				createTestAddress(meshtests.TestAddress1),
+				big.NewInt(1000),
+				"0xABCD",
+			)},
+			clauseIndex:    2,
+			operationIndex: 3,
+			originAddr:     meshtests.FirstSoloAddress,
+			value:          big.NewInt(1000),
+			expectedType:   meshcommon.OperationTypeContractCall,
+			dataKey:        "data",
 			status:         &testStatus,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			operation := parser.createContractInteractionOperation(tt.clause, tt.clauseIndex, tt.operationIndex, tt.originAddr, tt.status)
+			operation := parser.createContractInteractionOperation(tt.clause, tt.clauseIndex, tt.operationIndex, tt.originAddr, tt.value, tt.contract, tt.status)
 
 			if operation.OperationIdentifier.Index != int64(tt.operationIndex) {
 				t.Errorf("createContractInteractionOperation() index = %v, want %v", operation.OperationIdentifier.Index, tt.operationIndex)
 			}
-			if operation.Type != meshcommon.OperationTypeContractCall {
-				t.Errorf("createContractInteractionOperation() type = %v, want %v", operation.Type, meshcommon.OperationTypeContractCall)
+			if operation.Type != tt.expectedType {
+				t.Errorf("createContractInteractionOperation() type = %v, want %v", operation.Type, tt.expectedType)
 			}
 			if operation.Status != tt.status {
 				t.Errorf("createContractInteractionOperation() status = %v, want %v", operation.Status, tt.status)
@@ -410,13 +449,30 @@ func TestMeshTransactionEncoder_createContractInteractionOperation(t *testing.T)
 			if operation.Metadata["clauseIndex"] != tt.clauseIndex {
 				t.Errorf("createContractInteractionOperation() clauseIndex = %v, want %v", operation.Metadata["clauseIndex"], tt.clauseIndex)
 			}
+			if data := strings.ToLower(tt.clause.GetData()); operation.Metadata[tt.dataKey] != data {
+				t.Errorf("createContractInteractionOperation() %s = %v, want %v", tt.dataKey, operation.Metadata[tt.dataKey], data)
+			}
+			contractAddress, hasContractAddress := operation.Metadata["contractAddress"]
+			if tt.contract == nil && hasContractAddress {
+				t.Errorf("createContractInteractionOperation() contractAddress = %v, want none", contractAddress)
+			}
+			if tt.contract != nil && contractAddress != tt.contract.String() {
+				t.Errorf("createContractInteractionOperation() contractAddress = %v, want %v", contractAddress, tt.contract)
+			}
+			value, hasValue := operation.Metadata["value"]
+			if tt.value == nil && hasValue {
+				t.Errorf("createContractInteractionOperation() value = %v, want none", value)
+			}
+			if tt.value != nil && value != tt.value.String() {
+				t.Errorf("createContractInteractionOperation() value = %v, want %v", value, tt.value)
+			}
 		})
 	}
 }
 
 func TestMeshTransactionEncoder_createEnergyTransferOperation(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name           string
@@ -503,7 +559,7 @@ func TestMeshTransactionEncoder_createEnergyTransferOperation(t *testing.T) {
 
 func TestMeshTransactionEncoder_parseVETTransfer(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name              string
@@ -586,7 +642,7 @@ func TestMeshTransactionEncoder_parseVETTransfer(t *testing.T) {
 
 func TestMeshTransactionEncoder_parseTransactionOperationsFromClauses(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name        string
@@ -616,7 +672,7 @@ func TestMeshTransactionEncoder_parseTransactionOperationsFromClauses(t *testing
 			originAddr:  meshtests.FirstSoloAddress,
 			gas:         0,
 			status:      &testStatus,
-			expectedOps: 3,
+			expectedOps: 2,
 		},
 		{
 			name: "VET transfer with gas",
@@ -630,7 +686,7 @@ func TestMeshTransactionEncoder_parseTransactionOperationsFromClauses(t *testing
 			originAddr:  meshtests.FirstSoloAddress,
 			gas:         21000,
 			status:      &testStatus,
-			expectedOps: 4,
+			expectedOps: 3,
 		},
 	}
 
@@ -650,7 +706,7 @@ func TestMeshTransactionEncoder_parseTransactionOperationsFromClauses(t *testing
 
 func TestMeshTransactionEncoder_ParseTransactionOperationsFromTransactionClauses(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name        string
@@ -680,7 +736,7 @@ func TestMeshTransactionEncoder_ParseTransactionOperationsFromTransactionClauses
 			originAddr:  meshtests.FirstSoloAddress,
 			gas:         0,
 			status:      &testStatus,
-			expectedOps: 3,
+			expectedOps: 2,
 		},
 	}
 
@@ -700,7 +756,7 @@ func TestMeshTransactionEncoder_ParseTransactionOperationsFromTransactionClauses
 
 func TestClauseParser_ParseOperationsWithFeeDelegation(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name          string
@@ -802,9 +858,414 @@ func TestClauseParser_ParseOperationsWithFeeDelegation(t *testing.T) {
 	}
 }
 
+func TestClauseParser_ParseExecutedTransactionOperations(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
+
+	clauses := []*api.JSONClause{
+		createTestJSONClause(
+			createTestAddress(meshtests.TestAddress1),
+			big.NewInt(1000000000000000000),
+			"",
+		),
+	}
+	paid := math.HexOrDecimal256(*big.NewInt(210000000000000000))
+	reward := math.HexOrDecimal256(*big.NewInt(63000000000000000))
+	delegator := "0x" + strings.Repeat("ab", 20)
+
+	tests := []struct {
+		name          string
+		delegatorAddr string
+		gasPayer      string
+		paid          *math.HexOrDecimal256
+		expectedType  string
+		expectedOps   int
+	}{
+		{
+			name:         "fee paid by origin",
+			gasPayer:     meshtests.FirstSoloAddress,
+			paid:         &paid,
+			expectedType: meshcommon.OperationTypeFee,
+			expectedOps:  3,
+		},
+		{
+			name:          "fee paid by delegator",
+			delegatorAddr: delegator,
+			gasPayer:      delegator,
+			paid:          &paid,
+			expectedType:  meshcommon.OperationTypeFeeDelegation,
+			expectedOps:   3,
+		},
+		{
+			name:        "nothing paid",
+			gasPayer:    meshtests.FirstSoloAddress,
+			paid:        nil,
+			expectedOps: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee := NewTransactionFee(21000, *createTestAddress(tt.gasPayer), tt.paid, &reward)
+			operations, err := parser.ParseExecutedTransactionOperations(JSONClausesToClauseData(clauses), nil, meshtests.FirstSoloAddress, tt.delegatorAddr, fee, &testStatus)
+			if err != nil {
+				t.Fatalf("ParseExecutedTransactionOperations() error = %v", err)
+			}
+			if len(operations) != tt.expectedOps {
+				t.Fatalf("ParseExecutedTransactionOperations() operations length = %v, want %v", len(operations), tt.expectedOps)
+			}
+			if tt.paid == nil {
+				return
+			}
+
+			feeOp := operations[len(operations)-1]
+			if feeOp.Type != tt.expectedType {
+				t.Errorf("fee operation type = %v, want %v", feeOp.Type, tt.expectedType)
+			}
+			if feeOp.Account.Address != tt.gasPayer {
+				t.Errorf("fee operation account = %v, want %v", feeOp.Account.Address, tt.gasPayer)
+			}
+			if feeOp.Amount.Value != "-210000000000000000" {
+				t.Errorf("fee operation amount = %v, want -210000000000000000", feeOp.Amount.Value)
+			}
+			if feeOp.Metadata["gasUsed"] != "21000" {
+				t.Errorf("fee operation gasUsed = %v, want 21000", feeOp.Metadata["gasUsed"])
+			}
+			if feeOp.Metadata["reward"] != "63000000000000000" {
+				t.Errorf("fee operation reward = %v, want 63000000000000000", feeOp.Metadata["reward"])
+			}
+			if tt.delegatorAddr != "" && feeOp.Metadata[meshcommon.DelegatorAccountMetadataKey] != tt.delegatorAddr {
+				t.Errorf("fee operation delegator = %v, want %v", feeOp.Metadata[meshcommon.DelegatorAccountMetadataKey], tt.delegatorAddr)
+			}
+		})
+	}
+}
+
+func TestClauseParser_ParseExecutedTransactionOperations_InternalTransfers(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
+
+	origin := createTestAddress(meshtests.FirstSoloAddress)
+	contract := createTestAddress("0x" + strings.Repeat("cd", 20))
+	recipient := createTestAddress(meshtests.TestAddress1)
+	value := math.HexOrDecimal256(*big.NewInt(1000))
+	internalValue := math.HexOrDecimal256(*big.NewInt(400))
+
+	clauses := []*api.JSONClause{
+		createTestJSONClause(contract, big.NewInt(1000), "0x12345678"),
+	}
+	outputs := JSONOutputsToClauseOutputs([]*api.JSONOutput{
+		{
+			Transfers: []*api.JSONTransfer{
+				{Sender: *origin, Recipient: *contract, Amount: &value},
+				{Sender: *contract, Recipient: *recipient, Amount: &internalValue},
+			},
+		},
+	})
+	fee := NewTransactionFee(21000, *origin, nil, nil)
+
+	operations, err := parser.ParseExecutedTransactionOperations(JSONClausesToClauseData(clauses), outputs, origin.String(), "", fee, &testStatus)
+	if err != nil {
+		t.Fatalf("ParseExecutedTransactionOperations() error = %v", err)
+	}
+	// 2 clause transfer ops, 2 internal transfer ops and the contract call
+	if len(operations) != 5 {
+		t.Fatalf("ParseExecutedTransactionOperations() operations length = %v, want 5", len(operations))
+	}
+
+	expected := []struct {
+		address string
+		amount  string
+		source  string
+	}{
+		{origin.String(), "-1000", meshcommon.OperationSourceClause},
+		{contract.String(), "1000", meshcommon.OperationSourceClause},
+		{contract.String(), "-400", meshcommon.OperationSourceInternal},
+		{recipient.String(), "400", meshcommon.OperationSourceInternal},
+	}
+	for i, exp := range expected {
+		op := operations[i]
+		if op.Type != meshcommon.OperationTypeTransfer {
+			t.Errorf("operation %d type = %v, want %v", i, op.Type, meshcommon.OperationTypeTransfer)
+		}
+		if op.OperationIdentifier.Index != int64(i) {
+			t.Errorf("operation %d index = %v", i, op.OperationIdentifier.Index)
+		}
+		if op.Account.Address != exp.address {
+			t.Errorf("operation %d account = %v, want %v", i, op.Account.Address, exp.address)
+		}
+		if op.Amount.Value != exp.amount {
+			t.Errorf("operation %d amount = %v, want %v", i, op.Amount.Value, exp.amount)
+		}
+		if op.Metadata[meshcommon.OperationSourceMetadataKey] != exp.source {
+			t.Errorf("operation %d source = %v, want %v", i, op.Metadata[meshcommon.OperationSourceMetadataKey], exp.source)
+		}
+	}
+	if operations[4].Type != meshcommon.OperationTypeContractCall {
+		t.Errorf("operation 4 type = %v, want %v", operations[4].Type, meshcommon.OperationTypeContractCall)
+	}
+}
+
+func TestClauseParser_ParseExecutedTransactionOperations_TokenTransferEvents(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
+
+	origin := createTestAddress(meshtests.FirstSoloAddress)
+	recipient := createTestAddress(meshtests.TestAddress1)
+	dex := createTestAddress("0x" + strings.Repeat("cd", 20))
+	vtho := createTestAddress(meshcommon.VTHOContractAddress)
+
+	transferEventID := thor.MustParseBytes32("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	transferEvent := func(from, to *thor.Address, amount int64) *api.JSONEvent {
+		return &api.JSONEvent{
+			Address: *vtho,
+			Topics:  []thor.Bytes32{transferEventID, thor.BytesToBytes32(from.Bytes()), thor.BytesToBytes32(to.Bytes())},
+			Data:    fmt.Sprintf("0x%064x", amount),
+		}
+	}
+
+	transferData, err := parser.vip180Encoder.EncodeVIP180TransferCallData(recipient.String(), "500")
+	if err != nil {
+		t.Fatalf("EncodeVIP180TransferCallData() error = %v", err)
+	}
+
+	clauses := []*api.JSONClause{
+		// Direct token transfer
+		createTestJSONClause(vtho, big.NewInt(0), transferData),
+		// Contract call moving tokens internally, including a mint
+		createTestJSONClause(dex, big.NewInt(0), "0x12345678"),
+	}
+	outputs := JSONOutputsToClauseOutputs([]*api.JSONOutput{
+		{Events: []*api.JSONEvent{transferEvent(origin, recipient, 500)}},
+		{Events: []*api.JSONEvent{
+			transferEvent(dex, origin, 300),
+			transferEvent(&thor.Address{}, recipient, 200),
+			// Not a transfer event
+			{Address: *dex, Topics: []thor.Bytes32{{}}, Data: "0x"},
+		}},
+	})
+	fee := NewTransactionFee(21000, *origin, nil, nil)
+
+	operations, err := parser.ParseExecutedTransactionOperations(JSONClausesToClauseData(clauses), outputs, origin.String(), "", fee, &testStatus)
+	if err != nil {
+		t.Fatalf("ParseExecutedTransactionOperations() error = %v", err)
+	}
+
+	expected := []struct {
+		opType  string
+		address string
+		amount  string
+		source  string
+	}{
+		{meshcommon.OperationTypeTransfer, origin.String(), "-500", meshcommon.OperationSourceClause},
+		{meshcommon.OperationTypeTransfer, recipient.String(), "500", meshcommon.OperationSourceClause},
+		{meshcommon.OperationTypeTransfer, dex.String(), "-300", meshcommon.OperationSourceInternal},
+		{meshcommon.OperationTypeTransfer, origin.String(), "300", meshcommon.OperationSourceInternal},
+		{meshcommon.OperationTypeTransfer, recipient.String(), "200", meshcommon.OperationSourceInternal},
+		{meshcommon.OperationTypeContractCall, origin.String(), "0", ""},
+	}
+	if len(operations) != len(expected) {
+		t.Fatalf("ParseExecutedTransactionOperations() operations length = %v, want %v", len(operations), len(expected))
+	}
+	for i, exp := range expected {
+		op := operations[i]
+		if op.Type != exp.opType {
+			t.Errorf("operation %d type = %v, want %v", i, op.Type, exp.opType)
+		}
+		if op.Account.Address != exp.address {
+			t.Errorf("operation %d account = %v, want %v", i, op.Account.Address, exp.address)
+		}
+		if op.Amount.Value != exp.amount {
+			t.Errorf("operation %d amount = %v, want %v", i, op.Amount.Value, exp.amount)
+		}
+		if exp.source == "" {
+			continue
+		}
+		if op.Amount.Currency.Symbol != meshcommon.VTHOCurrency.Symbol {
+			t.Errorf("operation %d currency = %v, want VTHO", i, op.Amount.Currency.Symbol)
+		}
+		if op.Metadata[meshcommon.OperationSourceMetadataKey] != exp.source {
+			t.Errorf("operation %d source = %v, want %v", i, op.Metadata[meshcommon.OperationSourceMetadataKey], exp.source)
+		}
+	}
+}
+
+func TestClauseParser_ParseExecutedTransactionOperations_ContractDeploy(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
+
+	origin := createTestAddress(meshtests.FirstSoloAddress)
+	contract := createTestAddress(meshtests.SimulatedContractAddress)
+	value := math.HexOrDecimal256(*big.NewInt(1000))
+
+	clauses := []*api.JSONClause{
+		createTestJSONClause(nil, big.NewInt(1000), "0x6080604052"),
+	}
+	outputs := JSONOutputsToClauseOutputs([]*api.JSONOutput{
+		{
+			ContractAddress: contract,
+			Transfers:       []*api.JSONTransfer{{Sender: *origin, Recipient: *contract, Amount: &value}},
+		},
+	})
+	fee := NewTransactionFee(21000, *origin, nil, nil)
+
+	operations, err := parser.ParseExecutedTransactionOperations(JSONClausesToClauseData(clauses), outputs, origin.String(), "", fee, &testStatus)
+	if err != nil {
+		t.Fatalf("ParseExecutedTransactionOperations() error = %v", err)
+	}
+	// The value sent to the created contract and the deployment
+	if len(operations) != 3 {
+		t.Fatalf("ParseExecutedTransactionOperations() operations length = %v, want 3", len(operations))
+	}
+	if operations[1].Account.Address != contract.String() || operations[1].Amount.Value != "1000" {
+		t.Errorf("operation 1 = %v, want 1000 credited to %v", operations[1], contract)
+	}
+	deploy := operations[2]
+	if deploy.Type != meshcommon.OperationTypeContractDeploy {
+		t.Errorf("operation 2 type = %v, want %v", deploy.Type, meshcommon.OperationTypeContractDeploy)
+	}
+	if deploy.Metadata["contractAddress"] != contract.String() {
+		t.Errorf("operation 2 contractAddress = %v, want %v", deploy.Metadata["contractAddress"], contract)
+	}
+	if deploy.Metadata["bytecode"] != "0x6080604052" || deploy.Metadata["value"] != "1000" {
+		t.Errorf("operation 2 metadata = %v", deploy.Metadata)
+	}
+}
+
+func TestClauseParser_ParseTransactionOperations_ContractCallWithValue(t *testing.T) {
+	parser := NewClauseParser(meshthor.NewMockVeChainClient(), NewOperationsExtractor(nil))
+	contract := createTestAddress(meshtests.SimulatedContractAddress)
+	clauseData := JSONClausesToClauseData([]*api.JSONClause{
+		createTestJSONClause(contract, big.NewInt(1000), "0xd0e30db0"),
+	})
+
+	// A pending call reports the VET it sends as it is reported once executed
+	operations, err := parser.ParseTransactionOperationsFromClauseData(clauseData, meshtests.FirstSoloAddress, "", 0, &testStatus)
+	if err != nil {
+		t.Fatalf("ParseTransactionOperationsFromClauseData() error = %v", err)
+	}
+	if len(operations) != 3 {
+		t.Fatalf("ParseTransactionOperationsFromClauseData() operations length = %v, want 3", len(operations))
+	}
+	if operations[0].Type != meshcommon.OperationTypeTransfer || operations[0].Account.Address != meshtests.FirstSoloAddress || operations[0].Amount.Value != "-1000" {
+		t.Errorf("operation 0 = %v, want 1000 debited from the origin", operations[0])
+	}
+	if operations[1].Type != meshcommon.OperationTypeTransfer || operations[1].Account.Address != contract.String() || operations[1].Amount.Value != "1000" {
+		t.Errorf("operation 1 = %v, want 1000 credited to %v", operations[1], contract)
+	}
+	if operations[2].Type != meshcommon.OperationTypeContractCall || operations[2].OperationIdentifier.Index != 2 {
+		t.Errorf("operation 2 = %v, want the contract call", operations[2])
+	}
+
+	// Construction keeps the value in the contract call, to build the same clause again
+	operations, err = parser.ParseConstructionOperations(clauseData, meshtests.FirstSoloAddress, "", 0)
+	if err != nil {
+		t.Fatalf("ParseConstructionOperations() error = %v", err)
+	}
+	if len(operations) != 1 || operations[0].Type != meshcommon.OperationTypeContractCall || operations[0].Metadata["value"] != "1000" {
+		t.Errorf("ParseConstructionOperations() = %v, want a single contract call carrying the value", operations)
+	}
+}
+
+func TestClauseParser_ParseTransactionOperations_UnsupportedToken(t *testing.T) {
+	registry, err := meshcommon.NewTokenRegistry(nil, true, 0)
+	if err != nil {
+		t.Fatalf("NewTokenRegistry() error = %v", err)
+	}
+	parser := NewClauseParser(meshthor.NewMockVeChainClient(), NewOperationsExtractor(registry))
+
+	transferData, err := parser.vip180Encoder.EncodeVIP180TransferCallData(meshtests.TestAddress1, "500")
+	if err != nil {
+		t.Fatalf("EncodeVIP180TransferCallData() error = %v", err)
+	}
+	clauses := []*api.JSONClause{
+		createTestJSONClause(createTestAddress("0x"+strings.Repeat("cd", 20)), big.NewInt(0), transferData),
+	}
+
+	operations, err := parser.ParseTransactionOperationsFromJSONClauses(clauses, meshtests.FirstSoloAddress, "", 0, &testStatus)
+	if err != nil {
+		t.Fatalf("ParseTransactionOperationsFromJSONClauses() error = %v", err)
+	}
+	if len(operations) != 1 || operations[0].Type != meshcommon.OperationTypeContractCall {
+		t.Errorf("ParseTransactionOperationsFromJSONClauses() = %v, want a single contract call", operations)
+	}
+}
+
+func TestClauseParser_ParseTransactionOperations_TokenLookupError(t *testing.T) {
+	token := createTestAddress("0x" + strings.Repeat("cd", 20))
+	origin := createTestAddress(meshtests.FirstSoloAddress)
+	recipient := createTestAddress(meshtests.TestAddress1)
+
+	newParser := func() (*ClauseParser, *meshthor.MockVeChainClient) {
+		registry, err := meshcommon.NewTokenRegistry(nil, false, 0)
+		if err != nil {
+			t.Fatalf("NewTokenRegistry() error = %v", err)
+		}
+		mockClient := meshthor.NewMockVeChainClient()
+		mockClient.SetMockError(errors.New("connection refused"))
+		return NewClauseParser(mockClient, NewOperationsExtractor(registry)), mockClient
+	}
+
+	t.Run("transfer call", func(t *testing.T) {
+		parser, mockClient := newParser()
+		transferData, err := parser.vip180Encoder.EncodeVIP180TransferCallData(recipient.String(), "500")
+		if err != nil {
+			t.Fatalf("EncodeVIP180TransferCallData() error = %v", err)
+		}
+		clauses := []*api.JSONClause{createTestJSONClause(token, big.NewInt(0), transferData)}
+
+		// A failed lookup must not report the transfer as a plain contract call
+		if _, err := parser.ParseTransactionOperationsFromJSONClauses(clauses, origin.String(), "", 0, &testStatus); err == nil {
+			t.Fatal("ParseTransactionOperationsFromJSONClauses() error = nil, want token lookup error")
+		}
+
+		mockClient.SetMockError(nil)
+		mockClient.SetMockCallResults([]string{"0x"})
+		operations, err := parser.ParseTransactionOperationsFromJSONClauses(clauses, origin.String(), "", 0, &testStatus)
+		if err != nil {
+			t.Fatalf("ParseTransactionOperationsFromJSONClauses() error = %v", err)
+		}
+		if len(operations) != 1 || operations[0].Type != meshcommon.OperationTypeContractCall {
+			t.Errorf("ParseTransactionOperationsFromJSONClauses() = %v, want a single contract call", operations)
+		}
+	})
+
+	t.Run("transfer event", func(t *testing.T) {
+		parser, _ := newParser()
+		clauses := []*api.JSONClause{createTestJSONClause(createTestAddress("0x"+strings.Repeat("ef", 20)), big.NewInt(0), "0x12345678")}
+		outputs := JSONOutputsToClauseOutputs([]*api.JSONOutput{
+			{Events: []*api.JSONEvent{{
+				Address: *token,
+				Topics: []thor.Bytes32{
+					thor.MustParseBytes32("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+					thor.BytesToBytes32(origin.Bytes()),
+					thor.BytesToBytes32(recipient.Bytes()),
+				},
+				Data: fmt.Sprintf("0x%064x", 500),
+			}}},
+		})
+		fee := NewTransactionFee(21000, *origin, nil, nil)
+
+		// A failed lookup must not drop the token movement
+		if _, err := parser.ParseExecutedTransactionOperations(JSONClausesToClauseData(clauses), outputs, origin.String(), "", fee, &testStatus); err == nil {
+			t.Fatal("ParseExecutedTransactionOperations() error = nil, want token lookup error")
+		}
+	})
+}
+
+func TestAPIOutputsToClauseOutputs(t *testing.T) {
+	contract := createTestAddress("0x" + strings.Repeat("cd", 20))
+	outputs := APIOutputsToClauseOutputs([]*api.Output{
+		{ContractAddress: contract, Transfers: []*api.Transfer{{Sender: *contract}}},
+	})
+	if len(outputs) != 1 || outputs[0].ContractAddress != contract || len(outputs[0].Transfers) != 1 {
+		t.Errorf("APIOutputsToClauseOutputs() = %+v", outputs)
+	}
+}
+
 func TestClauseParser_ParseClausesFromOptions(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name          string
@@ -1033,7 +1494,7 @@ func TestClauseParser_ParseClausesFromOptions(t *testing.T) {
 }
 func TestParseHexOrDecimal256(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name        string
@@ -1117,7 +1578,7 @@ func TestParseHexOrDecimal256(t *testing.T) {
 
 func TestParseAPIClause(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name        string
@@ -1270,7 +1731,7 @@ func TestParseAPIClause(t *testing.T) {
 
 func TestClauseParser_ErrorHandlingInGetClauseValue(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	// Create a clause with a value that will fail to marshal
 	errorClause := errorClauseData{
@@ -1316,7 +1777,7 @@ func TestClauseParser_ErrorHandlingInGetClauseValue(t *testing.T) {
 
 func TestClauseParser_ErrorHandlingInAnalyzeClauses(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	errorClause := errorClauseData{
 		to:    createTestAddress(meshtests.TestAddress1),
@@ -1349,7 +1810,7 @@ func TestClauseParser_ErrorHandlingInAnalyzeClauses(t *testing.T) {
 
 func TestClauseParser_ParseTransactionOperationsFromJSONClauses_ErrorHandling(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	t.Run("empty clauses returns empty operations without error", func(t *testing.T) {
 		operations, err := parser.ParseTransactionOperationsFromJSONClauses([]*api.JSONClause{}, meshtests.FirstSoloAddress, "", 0, &testStatus)
@@ -1366,7 +1827,7 @@ func TestClauseParser_ParseTransactionOperationsFromJSONClauses_ErrorHandling(t
 
 func TestClauseParser_ParseOperationsFromAPIClauses_ErrorHandling(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	t.Run("empty API clauses returns empty operations without error", func(t *testing.T) {
 		operations, err := parser.ParseOperationsFromAPIClauses(api.Clauses{}, meshtests.FirstSoloAddress, "", 0, &testStatus)
@@ -1383,7 +1844,7 @@ func TestClauseParser_ParseOperationsFromAPIClauses_ErrorHandling(t *testing.T)
 
 func TestClauseParser_GetClauseValue_DirectTest(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	parser := NewClauseParser(mockClient, NewOperationsExtractor())
+	parser := NewClauseParser(mockClient, NewOperationsExtractor(nil))
 
 	tests := []struct {
 		name        string
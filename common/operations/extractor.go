@@ -1,6 +1,10 @@
 package operations
 
 import (
+	"cmp"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"math/big"
 	"slices"
 	"strings"
@@ -8,13 +12,16 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 	meshcommon "github.com/vechain/mesh/common"
 	"github.com/vechain/mesh/common/vip180"
-	"github.com/vechain/mesh/thor"
+	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/thor"
 )
 
-type OperationsExtractor struct{}
+type OperationsExtractor struct {
+	tokenRegistry *meshcommon.TokenRegistry
+}
 
-func NewOperationsExtractor() *OperationsExtractor {
-	return &OperationsExtractor{}
+func NewOperationsExtractor(tokenRegistry *meshcommon.TokenRegistry) *OperationsExtractor {
+	return &OperationsExtractor{tokenRegistry: tokenRegistry}
 }
 
 // GetStringFromOptions gets a string value from options map
@@ -25,6 +32,15 @@ func (e *OperationsExtractor) GetStringFromOptions(options map[string]any, key s
 	return meshcommon.TransactionTypeDynamic
 }
 
+// SortOperations returns a copy of the operations sorted by operation index, which is the order of their clauses
+func SortOperations(operations []*types.Operation) []*types.Operation {
+	sorted := slices.Clone(operations)
+	slices.SortStableFunc(sorted, func(a, b *types.Operation) int {
+		return cmp.Compare(a.OperationIdentifier.Index, b.OperationIdentifier.Index)
+	})
+	return sorted
+}
+
 // GetTxOrigins extracts origin addresses from operations
 func (e *OperationsExtractor) GetTxOrigins(operations []*types.Operation) []string {
 	var origins []string
@@ -41,8 +57,8 @@ func (e *OperationsExtractor) GetTxOrigins(operations []*types.Operation) []stri
 			continue
 		}
 
-		// Consider Fee operations
-		if op.Type == meshcommon.OperationTypeFee {
+		// Consider Fee operations, contract calls and deployments, which are always made by the origin
+		if op.Type == meshcommon.OperationTypeFee || op.Type == meshcommon.OperationTypeContractCall || op.Type == meshcommon.OperationTypeContractDeploy {
 			origins = append(origins, address)
 			continue
 		}
@@ -60,13 +76,83 @@ func (e *OperationsExtractor) GetTxOrigins(operations []*types.Operation) []stri
 	return origins
 }
 
-// GetTokenCurrencyFromContractAddress returns the currency definition for a token contract
-func (e *OperationsExtractor) GetTokenCurrencyFromContractAddress(contractAddress string, client thor.VeChainClientInterface) (*types.Currency, error) {
-	if strings.EqualFold(contractAddress, meshcommon.VTHOCurrency.Metadata["contractAddress"].(string)) {
-		return meshcommon.VTHOCurrency, nil
+// OriginOperations are the operations of a single transaction and the origin signing it
+type OriginOperations struct {
+	Origin     string
+	Operations []*types.Operation
+}
+
+// GroupOperationsByOrigin splits operations into one group per origin, in the order of their first operation.
+// Operations made by an origin belong to its group; the other operations, such as the credit of a transfer,
+// follow the origin operation they are related to, or the only origin when there is a single one.
+func (e *OperationsExtractor) GroupOperationsByOrigin(operations []*types.Operation) ([]OriginOperations, error) {
+	sorted := SortOperations(operations)
+
+	var groups []OriginOperations
+	groupIndex := make(map[string]int)
+	opOrigin := make(map[int64]string)
+	for _, op := range sorted {
+		origins := e.GetTxOrigins([]*types.Operation{op})
+		if len(origins) == 0 {
+			continue
+		}
+		opOrigin[op.OperationIdentifier.Index] = origins[0]
+		if _, ok := groupIndex[origins[0]]; !ok {
+			groupIndex[origins[0]] = len(groups)
+			groups = append(groups, OriginOperations{Origin: origins[0]})
+		}
 	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("operations have no origin")
+	}
+
+	// Relations may be declared on either side, an operation follows the origins of the operations linked to it
+	related := make(map[int64][]int64)
+	for _, op := range sorted {
+		for _, relatedOp := range op.RelatedOperations {
+			related[op.OperationIdentifier.Index] = append(related[op.OperationIdentifier.Index], relatedOp.Index)
+			related[relatedOp.Index] = append(related[relatedOp.Index], op.OperationIdentifier.Index)
+		}
+	}
+
+	for _, op := range sorted {
+		index := op.OperationIdentifier.Index
+		origin, ok := opOrigin[index]
+		if !ok {
+			var candidates []string
+			for _, relatedIndex := range related[index] {
+				if relatedOrigin, ok := opOrigin[relatedIndex]; ok && !slices.Contains(candidates, relatedOrigin) {
+					candidates = append(candidates, relatedOrigin)
+				}
+			}
+			switch {
+			case len(candidates) == 1:
+				origin = candidates[0]
+			case len(candidates) == 0 && len(groups) == 1:
+				origin = groups[0].Origin
+			default:
+				return nil, fmt.Errorf("operation %d must be related to the operation of exactly one origin", index)
+			}
+		}
+		group := &groups[groupIndex[origin]]
+		group.Operations = append(group.Operations, op)
+	}
+
+	return groups, nil
+}
+
+// GetTokenCurrencyFromContractAddress returns the currency definition for a token contract.
+// Registered tokens are resolved from the token registry, other tokens are only resolved on chain
+// when the registry is not an allow-list, and the result is cached by the registry.
+func (e *OperationsExtractor) GetTokenCurrencyFromContractAddress(contractAddress string, client meshthor.VeChainClientInterface) (*types.Currency, error) {
+	return e.tokenRegistry.ResolveCurrency(contractAddress, func(contractAddress string) (*types.Currency, error) {
+		return e.fetchTokenCurrency(contractAddress, client)
+	})
+}
 
-	// For other tokens, fetch metadata from the contract using the wrapper
+// fetchTokenCurrency fetches the symbol and decimals of a token contract.
+// Failures other than node call errors mean the contract does not implement VIP180.
+func (e *OperationsExtractor) fetchTokenCurrency(contractAddress string, client meshthor.VeChainClientInterface) (*types.Currency, error) {
 	contract, err := vip180.NewVIP180Contract(contractAddress, client)
 	if err != nil {
 		return nil, err
@@ -75,13 +161,13 @@ func (e *OperationsExtractor) GetTokenCurrencyFromContractAddress(contractAddres
 	// Get symbol
 	symbol, err := contract.Symbol()
 	if err != nil {
-		return nil, err
+		return nil, classifyTokenCallError(err)
 	}
 
 	// Get decimals
 	decimals, err := contract.Decimals()
 	if err != nil {
-		return nil, err
+		return nil, classifyTokenCallError(err)
 	}
 
 	return &types.Currency{
@@ -93,6 +179,15 @@ func (e *OperationsExtractor) GetTokenCurrencyFromContractAddress(contractAddres
 	}, nil
 }
 
+// classifyTokenCallError marks errors caused by the contract itself as ErrNotVIP180Token
+func classifyTokenCallError(err error) error {
+	var callErr *vip180.ContractCallError
+	if errors.As(err, &callErr) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", meshcommon.ErrNotVIP180Token, err)
+}
+
 // GetVETOperations extracts VET transfer operations from a list of operations
 func (e *OperationsExtractor) GetVETOperations(operations []*types.Operation) []map[string]string {
 	var result []map[string]string
@@ -139,6 +234,154 @@ func (e *OperationsExtractor) GetTokensOperations(operations []*types.Operation)
 	return registered
 }
 
+// ContractCall holds the clause described by a ContractCall or ContractDeploy operation,
+// To is nil for deployments
+type ContractCall struct {
+	To    *thor.Address
+	Value *big.Int
+	Data  []byte
+}
+
+// ParseContractCallOperation parses the clause of a ContractCall operation from its metadata.
+// The operation carries the contract address in "to", the hex encoded call data in "data" and
+// an optional VET amount in "value". Its own amount, when given, must be zero VET.
+func ParseContractCallOperation(op *types.Operation) (*ContractCall, error) {
+	value, err := parseContractOperationValue(op, "contract call")
+	if err != nil {
+		return nil, err
+	}
+
+	toStr, ok := op.Metadata["to"].(string)
+	if !ok || toStr == "" {
+		return nil, fmt.Errorf("contract call 'to' is required and must be a string")
+	}
+	to, err := thor.ParseAddress(toStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract call 'to' address: %w", err)
+	}
+
+	data, err := parseMetadataHex(op.Metadata, "data", "contract call")
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("contract call 'data' must not be empty")
+	}
+
+	return &ContractCall{To: &to, Value: value, Data: data}, nil
+}
+
+// ParseContractDeployOperation parses the clause of a ContractDeploy operation from its metadata.
+// The operation carries the hex encoded contract bytecode in "bytecode", the optional hex encoded
+// ABI constructor arguments in "args" and an optional VET amount in "value". Its own amount, when given, must be zero VET.
+// The deployment data is the bytecode followed by the arguments. A transaction does not keep them apart,
+// so parsing it back reports the whole deployment data in "bytecode".
+func ParseContractDeployOperation(op *types.Operation) (*ContractCall, error) {
+	value, err := parseContractOperationValue(op, "contract deployment")
+	if err != nil {
+		return nil, err
+	}
+
+	bytecode, err := parseMetadataHex(op.Metadata, "bytecode", "contract deployment")
+	if err != nil {
+		return nil, err
+	}
+	if len(bytecode) == 0 {
+		return nil, fmt.Errorf("contract deployment 'bytecode' must not be empty")
+	}
+
+	if _, exists := op.Metadata["args"]; exists {
+		args, err := parseMetadataHex(op.Metadata, "args", "contract deployment")
+		if err != nil {
+			return nil, err
+		}
+		bytecode = append(bytecode, args...)
+	}
+
+	return &ContractCall{Value: value, Data: bytecode}, nil
+}
+
+// parseContractOperationValue checks the amount of a contract operation and parses its "value" metadata
+func parseContractOperationValue(op *types.Operation, kind string) (*big.Int, error) {
+	if op.Amount != nil {
+		amount, ok := new(big.Int).SetString(op.Amount.Value, 10)
+		if !ok || amount.Sign() != 0 || op.Amount.Currency == nil || op.Amount.Currency.Symbol != meshcommon.VETCurrency.Symbol {
+			return nil, fmt.Errorf("%s amount must be 0 %s, the value is given in metadata", kind, meshcommon.VETCurrency.Symbol)
+		}
+	}
+
+	value := new(big.Int)
+	valueRaw, exists := op.Metadata["value"]
+	if !exists {
+		return value, nil
+	}
+	valueStr, ok := valueRaw.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s 'value' must be a string", kind)
+	}
+	if _, ok := value.SetString(valueStr, 10); !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s 'value': %s", kind, valueStr)
+	}
+	return value, nil
+}
+
+// parseMetadataHex parses a 0x prefixed hex string from operation metadata
+func parseMetadataHex(metadata map[string]any, key, kind string) ([]byte, error) {
+	str, ok := metadata[key].(string)
+	if !ok || !strings.HasPrefix(str, "0x") {
+		return nil, fmt.Errorf("%s '%s' is required and must be a 0x prefixed hex string", kind, key)
+	}
+	data, err := hex.DecodeString(str[2:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s '%s': %w", kind, key, err)
+	}
+	return data, nil
+}
+
+// GetContractCallOperations extracts the clauses of ContractCall operations from a list of operations
+func (e *OperationsExtractor) GetContractCallOperations(operations []*types.Operation) ([]map[string]string, error) {
+	var result []map[string]string
+
+	for _, op := range operations {
+		if op.Type != meshcommon.OperationTypeContractCall {
+			continue
+		}
+		call, err := ParseContractCallOperation(op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", op.OperationIdentifier.Index, err)
+		}
+		result = append(result, map[string]string{
+			"to":    call.To.String(),
+			"value": call.Value.String(),
+			"data":  "0x" + hex.EncodeToString(call.Data),
+		})
+	}
+
+	return result, nil
+}
+
+// GetContractDeployOperations extracts the clauses of ContractDeploy operations from a list of operations
+func (e *OperationsExtractor) GetContractDeployOperations(operations []*types.Operation) ([]map[string]string, error) {
+	var result []map[string]string
+
+	for _, op := range operations {
+		if op.Type != meshcommon.OperationTypeContractDeploy {
+			continue
+		}
+		deploy, err := ParseContractDeployOperation(op)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", op.OperationIdentifier.Index, err)
+		}
+		result = append(result, map[string]string{
+			"to":    "",
+			"value": deploy.Value.String(),
+			"data":  "0x" + hex.EncodeToString(deploy.Data),
+		})
+	}
+
+	return result, nil
+}
+
 // GetFeeDelegatorAccount extracts fee delegator account from metadata
 func (e *OperationsExtractor) GetFeeDelegatorAccount(metadata map[string]any) string {
 	if metadata == nil {
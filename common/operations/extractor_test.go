@@ -1,6 +1,7 @@
 package operations
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -11,7 +12,7 @@ import (
 )
 
 func TestGetStringFromOptions(t *testing.T) {
-	extractor := NewOperationsExtractor()
+	extractor := NewOperationsExtractor(nil)
 	options := map[string]any{
 		"key1": "value1",
 		"key2": 123,
@@ -62,7 +63,7 @@ func TestGetTxOrigins(t *testing.T) {
 		},
 	}
 
-	extractor := NewOperationsExtractor()
+	extractor := NewOperationsExtractor(nil)
 	origins := extractor.GetTxOrigins(operations)
 
 	if len(origins) != 2 {
@@ -83,6 +84,66 @@ func TestGetTxOrigins(t *testing.T) {
 	}
 }
 
+func TestGroupOperationsByOrigin(t *testing.T) {
+	transfer := func(index int64, address, value string, related ...int64) *types.Operation {
+		op := &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: index},
+			Type:                meshcommon.OperationTypeTransfer,
+			Account:             &types.AccountIdentifier{Address: address},
+			Amount:              &types.Amount{Value: value, Currency: meshcommon.VETCurrency},
+		}
+		for _, relatedIndex := range related {
+			op.RelatedOperations = append(op.RelatedOperations, &types.OperationIdentifier{Index: relatedIndex})
+		}
+		return op
+	}
+	const deposit1 = "0x1111111111111111111111111111111111111111"
+	const deposit2 = "0x2222222222222222222222222222222222222222"
+	const hotWallet = "0x3333333333333333333333333333333333333333"
+	extractor := NewOperationsExtractor(nil)
+
+	// The credit of the second transfer relates to its debit, the first debit relates to its credit
+	groups, err := extractor.GroupOperationsByOrigin([]*types.Operation{
+		transfer(0, deposit1, "-1", 1),
+		transfer(1, hotWallet, "1"),
+		transfer(2, deposit2, "-2"),
+		transfer(3, hotWallet, "2", 2),
+	})
+	if err != nil {
+		t.Fatalf("GroupOperationsByOrigin() error = %v", err)
+	}
+	if len(groups) != 2 || groups[0].Origin != deposit1 || groups[1].Origin != deposit2 {
+		t.Fatalf("GroupOperationsByOrigin() = %+v, want groups of %s and %s", groups, deposit1, deposit2)
+	}
+	for i, group := range groups {
+		if len(group.Operations) != 2 || group.Operations[1].Account.Address != hotWallet {
+			t.Errorf("GroupOperationsByOrigin() group %d operations = %+v", i, group.Operations)
+		}
+	}
+
+	// Unrelated credits follow the only origin
+	groups, err = extractor.GroupOperationsByOrigin([]*types.Operation{
+		transfer(0, deposit1, "-1"),
+		transfer(1, hotWallet, "1"),
+	})
+	if err != nil || len(groups) != 1 || len(groups[0].Operations) != 2 {
+		t.Errorf("GroupOperationsByOrigin() = %+v, %v, want a single group", groups, err)
+	}
+
+	// Unrelated credits are ambiguous with several origins
+	if _, err := extractor.GroupOperationsByOrigin([]*types.Operation{
+		transfer(0, deposit1, "-1"),
+		transfer(1, deposit2, "-1"),
+		transfer(2, hotWallet, "2"),
+	}); err == nil {
+		t.Error("GroupOperationsByOrigin() expected error for an unrelated credit")
+	}
+
+	if _, err := extractor.GroupOperationsByOrigin([]*types.Operation{transfer(0, hotWallet, "1")}); err == nil {
+		t.Error("GroupOperationsByOrigin() expected error without origin")
+	}
+}
+
 func TestGetTokenCurrencyFromContractAddressWithClient(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
 
@@ -120,7 +181,7 @@ func TestGetTokenCurrencyFromContractAddressWithClient(t *testing.T) {
 				})
 			}
 
-			extractor := NewOperationsExtractor()
+			extractor := NewOperationsExtractor(nil)
 			result, err := extractor.GetTokenCurrencyFromContractAddress(tt.contractAddr, mockClient)
 
 			if tt.expectError {
@@ -146,6 +207,111 @@ func TestGetTokenCurrencyFromContractAddressWithClient(t *testing.T) {
 	}
 }
 
+func TestGetTokenCurrencyFromContractAddress_TokenRegistry(t *testing.T) {
+	usdcAddress := "0x1234567890123456789012345678901234567890"
+	spoofAddress := "0x0000000000000000000000000000000000000001"
+	tokens := []*types.Currency{{
+		Symbol:   "USDC",
+		Decimals: 6,
+		Metadata: map[string]any{"contractAddress": usdcAddress},
+	}}
+	vthoSymbolResult := "0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000045654484f00000000000000000000000000000000000000000000000000000000"
+
+	t.Run("registered token is resolved from the registry", func(t *testing.T) {
+		registry, _ := meshcommon.NewTokenRegistry(tokens, true, 0)
+		mockClient := meshthor.NewMockVeChainClient()
+		mockClient.SetMockError(errors.New("no node calls expected"))
+
+		currency, err := NewOperationsExtractor(registry).GetTokenCurrencyFromContractAddress(usdcAddress, mockClient)
+		if err != nil {
+			t.Fatalf("GetTokenCurrencyFromContractAddress() error = %v", err)
+		}
+		if currency.Symbol != "USDC" || currency.Decimals != 6 {
+			t.Errorf("GetTokenCurrencyFromContractAddress() = %v", currency)
+		}
+	})
+
+	t.Run("unregistered token is rejected in allow-list mode", func(t *testing.T) {
+		registry, _ := meshcommon.NewTokenRegistry(tokens, true, 0)
+		if _, err := NewOperationsExtractor(registry).GetTokenCurrencyFromContractAddress(spoofAddress, meshthor.NewMockVeChainClient()); err == nil {
+			t.Errorf("GetTokenCurrencyFromContractAddress() error = nil, want error")
+		}
+	})
+
+	t.Run("spoofed symbol is rejected in discovery mode", func(t *testing.T) {
+		registry, _ := meshcommon.NewTokenRegistry(tokens, false, 0)
+		mockClient := meshthor.NewMockVeChainClient()
+		mockClient.SetMockCallResults([]string{
+			vthoSymbolResult,
+			"0x0000000000000000000000000000000000000000000000000000000000000012",
+		})
+		if _, err := NewOperationsExtractor(registry).GetTokenCurrencyFromContractAddress(spoofAddress, mockClient); err == nil {
+			t.Errorf("GetTokenCurrencyFromContractAddress() error = nil, want error")
+		}
+	})
+}
+
+func TestGetTokenCurrencyFromContractAddress_Cache(t *testing.T) {
+	tokenAddress := "0x0000000000000000000000000000000000000abc"
+	symbolResult := "0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000034142430000000000000000000000000000000000000000000000000000000000"
+	decimalsResult := "0x0000000000000000000000000000000000000000000000000000000000000012"
+
+	t.Run("discovered token is fetched once", func(t *testing.T) {
+		registry, _ := meshcommon.NewTokenRegistry(nil, false, 0)
+		extractor := NewOperationsExtractor(registry)
+		mockClient := meshthor.NewMockVeChainClient()
+		mockClient.SetMockCallResults([]string{symbolResult, decimalsResult})
+
+		for range 3 {
+			currency, err := extractor.GetTokenCurrencyFromContractAddress(tokenAddress, mockClient)
+			if err != nil {
+				t.Fatalf("GetTokenCurrencyFromContractAddress() error = %v", err)
+			}
+			if currency.Symbol != "ABC" || currency.Decimals != 18 {
+				t.Errorf("GetTokenCurrencyFromContractAddress() = %v", currency)
+			}
+		}
+		if mockClient.MockCallIndex != 2 {
+			t.Errorf("made %d contract calls, want 2", mockClient.MockCallIndex)
+		}
+	})
+
+	t.Run("contract that is not a token is cached", func(t *testing.T) {
+		registry, _ := meshcommon.NewTokenRegistry(nil, false, 0)
+		extractor := NewOperationsExtractor(registry)
+		mockClient := meshthor.NewMockVeChainClient()
+		mockClient.SetMockCallResults([]string{"0x"})
+
+		for range 2 {
+			_, err := extractor.GetTokenCurrencyFromContractAddress(tokenAddress, mockClient)
+			if !errors.Is(err, meshcommon.ErrNotVIP180Token) {
+				t.Errorf("GetTokenCurrencyFromContractAddress() error = %v, want ErrNotVIP180Token", err)
+			}
+		}
+		if mockClient.MockCallIndex != 1 {
+			t.Errorf("made %d contract calls, want 1", mockClient.MockCallIndex)
+		}
+	})
+
+	t.Run("node errors are not cached", func(t *testing.T) {
+		registry, _ := meshcommon.NewTokenRegistry(nil, false, 0)
+		extractor := NewOperationsExtractor(registry)
+		mockClient := meshthor.NewMockVeChainClient()
+		mockClient.SetMockError(errors.New("connection refused"))
+
+		_, err := extractor.GetTokenCurrencyFromContractAddress(tokenAddress, mockClient)
+		if err == nil || errors.Is(err, meshcommon.ErrNotVIP180Token) {
+			t.Fatalf("GetTokenCurrencyFromContractAddress() error = %v, want node error", err)
+		}
+
+		mockClient.SetMockError(nil)
+		mockClient.SetMockCallResults([]string{symbolResult, decimalsResult})
+		if _, err := extractor.GetTokenCurrencyFromContractAddress(tokenAddress, mockClient); err != nil {
+			t.Errorf("GetTokenCurrencyFromContractAddress() error = %v", err)
+		}
+	})
+}
+
 func TestGetVETOperations(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -263,7 +429,7 @@ func TestGetVETOperations(t *testing.T) {
 		},
 	}
 
-	extractor := NewOperationsExtractor()
+	extractor := NewOperationsExtractor(nil)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := extractor.GetVETOperations(tt.operations)
@@ -397,7 +563,7 @@ func TestGetTokensOperations(t *testing.T) {
 		},
 	}
 
-	extractor := NewOperationsExtractor()
+	extractor := NewOperationsExtractor(nil)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			registered := extractor.GetTokensOperations(tt.operations)
@@ -424,8 +590,165 @@ func TestGetTokensOperations(t *testing.T) {
 	}
 }
 
+func TestGetContractCallOperations(t *testing.T) {
+	extractor := NewOperationsExtractor(nil)
+
+	contractCall := func(amount *types.Amount, metadata map[string]any) *types.Operation {
+		return &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                meshcommon.OperationTypeContractCall,
+			Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+			Amount:              amount,
+			Metadata:            metadata,
+		}
+	}
+	zero := &types.Amount{Value: "0", Currency: meshcommon.VETCurrency}
+
+	tests := []struct {
+		name      string
+		operation *types.Operation
+		expected  map[string]string
+		wantErr   bool
+	}{
+		{
+			name:      "call with value",
+			operation: contractCall(zero, map[string]any{"to": meshtests.TestAddress1, "data": "0xA9059CBB", "value": "1000"}),
+			expected:  map[string]string{"to": meshtests.TestAddress1, "data": "0xa9059cbb", "value": "1000"},
+		},
+		{
+			name:      "call without amount or value",
+			operation: contractCall(nil, map[string]any{"to": meshtests.TestAddress1, "data": "0x1234"}),
+			expected:  map[string]string{"to": meshtests.TestAddress1, "data": "0x1234", "value": "0"},
+		},
+		{
+			name:      "non-zero amount",
+			operation: contractCall(&types.Amount{Value: "-1000", Currency: meshcommon.VETCurrency}, map[string]any{"to": meshtests.TestAddress1, "data": "0x1234"}),
+			wantErr:   true,
+		},
+		{
+			name:      "missing to",
+			operation: contractCall(zero, map[string]any{"data": "0x1234"}),
+			wantErr:   true,
+		},
+		{
+			name:      "empty data",
+			operation: contractCall(zero, map[string]any{"to": meshtests.TestAddress1, "data": "0x"}),
+			wantErr:   true,
+		},
+		{
+			name:      "data without prefix",
+			operation: contractCall(zero, map[string]any{"to": meshtests.TestAddress1, "data": "1234"}),
+			wantErr:   true,
+		},
+		{
+			name:      "negative value",
+			operation: contractCall(zero, map[string]any{"to": meshtests.TestAddress1, "data": "0x1234", "value": "-1"}),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls, err := extractor.GetContractCallOperations([]*types.Operation{tt.operation})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("GetContractCallOperations() expected error, got %v", calls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetContractCallOperations() error = %v", err)
+			}
+			if len(calls) != 1 {
+				t.Fatalf("GetContractCallOperations() returned %d calls, want 1", len(calls))
+			}
+			for key, value := range tt.expected {
+				if calls[0][key] != value {
+					t.Errorf("GetContractCallOperations() %s = %v, want %v", key, calls[0][key], value)
+				}
+			}
+		})
+	}
+
+	// Contract calls are made by the origin
+	origins := extractor.GetTxOrigins([]*types.Operation{contractCall(zero, nil)})
+	if len(origins) != 1 || origins[0] != meshtests.FirstSoloAddress {
+		t.Errorf("GetTxOrigins() = %v, want [%s]", origins, meshtests.FirstSoloAddress)
+	}
+}
+
+func TestGetContractDeployOperations(t *testing.T) {
+	extractor := NewOperationsExtractor(nil)
+
+	contractDeploy := func(metadata map[string]any) *types.Operation {
+		return &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                meshcommon.OperationTypeContractDeploy,
+			Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+			Amount:              &types.Amount{Value: "0", Currency: meshcommon.VETCurrency},
+			Metadata:            metadata,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		operation *types.Operation
+		expected  map[string]string
+		wantErr   bool
+	}{
+		{
+			name:      "bytecode only",
+			operation: contractDeploy(map[string]any{"bytecode": "0x6080604052"}),
+			expected:  map[string]string{"to": "", "data": "0x6080604052", "value": "0"},
+		},
+		{
+			name:      "bytecode with constructor args and value",
+			operation: contractDeploy(map[string]any{"bytecode": "0x60806040520000000000000000000000000000000000000000000000000000000000000001", "value": "5"}),
+			expected:  map[string]string{"to": "", "data": "0x60806040520000000000000000000000000000000000000000000000000000000000000001", "value": "5"},
+		},
+		{
+			name:      "missing bytecode",
+			operation: contractDeploy(map[string]any{"value": "5"}),
+			wantErr:   true,
+		},
+		{
+			name:      "separate args",
+			operation: contractDeploy(map[string]any{"bytecode": "0x6080604052", "args": "0x01"}),
+			expected:  map[string]string{"to": "", "data": "0x608060405201", "value": "0"},
+		},
+		{
+			name:      "invalid args",
+			operation: contractDeploy(map[string]any{"bytecode": "0x6080604052", "args": "01"}),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deploys, err := extractor.GetContractDeployOperations([]*types.Operation{tt.operation})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("GetContractDeployOperations() expected error, got %v", deploys)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetContractDeployOperations() error = %v", err)
+			}
+			if len(deploys) != 1 {
+				t.Fatalf("GetContractDeployOperations() returned %d deployments, want 1", len(deploys))
+			}
+			for key, value := range tt.expected {
+				if deploys[0][key] != value {
+					t.Errorf("GetContractDeployOperations() %s = %v, want %v", key, deploys[0][key], value)
+				}
+			}
+		})
+	}
+}
+
 func TestGetFeeDelegatorAccount(t *testing.T) {
-	extractor := NewOperationsExtractor()
+	extractor := NewOperationsExtractor(nil)
 
 	tests := []struct {
 		name     string
@@ -0,0 +1,271 @@
+package common
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// DefaultTokenCacheSize is the number of discovered token contracts cached by default
+const DefaultTokenCacheSize = 1024
+
+// ErrNotVIP180Token is returned by token lookups for contracts that do not implement VIP180
+var ErrNotVIP180Token = errors.New("contract is not a VIP180 token")
+
+// ErrTokenNotSupported is returned for tokens rejected by the registry, either not registered
+// in allow-list mode or reusing a reserved symbol
+var ErrTokenNotSupported = errors.New("unsupported token")
+
+// TokenLookup fetches the currency of a token contract from the chain
+type TokenLookup func(contractAddress string) (*types.Currency, error)
+
+// TokenRegistry holds the VIP180 tokens supported by the service.
+//
+// By default the registry acts as an allow-list and only registered tokens (and VTHO) are supported.
+// When token discovery is enabled, other tokens are discovered on chain, but a contract can never
+// claim the symbol of a built-in or registered currency.
+// A nil registry behaves as an empty registry in discovery mode, without caching.
+//
+// Discovered tokens are kept in a bounded cache shared by every user of the registry,
+// including contracts found not to be supported tokens.
+type TokenRegistry struct {
+	allowList  bool
+	currencies []*types.Currency
+	byAddress  map[string]*types.Currency
+	bySymbol   map[string]*types.Currency
+
+	cacheMu      sync.Mutex
+	cacheSize    int
+	cacheEntries map[string]*list.Element
+	cacheOrder   *list.List
+}
+
+// discoveredToken is a cached token lookup result, currency is nil for unsupported contracts
+type discoveredToken struct {
+	address  string
+	currency *types.Currency
+	err      error
+}
+
+// NewTokenRegistry creates a token registry from the given token currencies.
+// Each currency must carry its contract address in the "contractAddress" metadata key.
+// cacheSize bounds the number of discovered contracts kept in memory, DefaultTokenCacheSize is used when not positive.
+func NewTokenRegistry(tokens []*types.Currency, allowList bool, cacheSize int) (*TokenRegistry, error) {
+	if cacheSize <= 0 {
+		cacheSize = DefaultTokenCacheSize
+	}
+	registry := &TokenRegistry{
+		allowList:    allowList,
+		byAddress:    map[string]*types.Currency{},
+		bySymbol:     map[string]*types.Currency{},
+		cacheSize:    cacheSize,
+		cacheEntries: map[string]*list.Element{},
+		cacheOrder:   list.New(),
+	}
+
+	for _, token := range tokens {
+		address, ok := token.Metadata["contractAddress"].(string)
+		if !ok {
+			return nil, fmt.Errorf("token %s has no contract address", token.Symbol)
+		}
+		parsed, err := thor.ParseAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract address for token %s: %w", token.Symbol, err)
+		}
+		address = parsed.String()
+
+		if token.Symbol == "" {
+			return nil, fmt.Errorf("token %s has no symbol", address)
+		}
+		if token.Decimals < 0 {
+			return nil, fmt.Errorf("token %s has negative decimals", token.Symbol)
+		}
+		if isBuiltInSymbol(token.Symbol) {
+			return nil, fmt.Errorf("token %s reuses the symbol of a built-in currency", address)
+		}
+		if _, exists := registry.byAddress[address]; exists {
+			return nil, fmt.Errorf("token %s is registered more than once", address)
+		}
+		if _, exists := registry.bySymbol[strings.ToUpper(token.Symbol)]; exists {
+			return nil, fmt.Errorf("symbol %s is registered more than once", token.Symbol)
+		}
+
+		currency := &types.Currency{
+			Symbol:   token.Symbol,
+			Decimals: token.Decimals,
+			Metadata: map[string]any{
+				"contractAddress": address,
+			},
+		}
+		registry.currencies = append(registry.currencies, currency)
+		registry.byAddress[address] = currency
+		registry.bySymbol[strings.ToUpper(token.Symbol)] = currency
+	}
+
+	return registry, nil
+}
+
+// IsAllowList returns true if only registered tokens are supported
+func (r *TokenRegistry) IsAllowList() bool {
+	return r != nil && r.allowList
+}
+
+// Currencies returns the registered token currencies, in registration order
+func (r *TokenRegistry) Currencies() []*types.Currency {
+	if r == nil {
+		return nil
+	}
+	return r.currencies
+}
+
+// GetCurrency returns the currency of a registered token contract, VTHO included
+func (r *TokenRegistry) GetCurrency(contractAddress string) (*types.Currency, bool) {
+	address := strings.ToLower(contractAddress)
+	if address == VTHOContractAddress {
+		return VTHOCurrency, true
+	}
+	if r == nil {
+		return nil, false
+	}
+	currency, ok := r.byAddress[address]
+	return currency, ok
+}
+
+// ResolveCurrency returns the currency of a token contract.
+// Registered tokens are returned directly. In discovery mode other tokens are fetched with lookup
+// and validated, and the outcome is cached. Contracts that are not tokens (lookup errors wrapping
+// ErrNotVIP180Token) or that spoof a reserved symbol are cached as unsupported, other lookup errors
+// are considered transient and are not cached.
+func (r *TokenRegistry) ResolveCurrency(contractAddress string, lookup TokenLookup) (*types.Currency, error) {
+	if currency, ok := r.GetCurrency(contractAddress); ok {
+		return currency, nil
+	}
+	if r.IsAllowList() {
+		return nil, fmt.Errorf("%w: token %s is not registered", ErrTokenNotSupported, contractAddress)
+	}
+
+	address := strings.ToLower(contractAddress)
+	if cached, ok := r.getCached(address); ok {
+		return cached.currency, cached.err
+	}
+
+	currency, err := lookup(contractAddress)
+	if err != nil {
+		if errors.Is(err, ErrNotVIP180Token) {
+			r.putCached(&discoveredToken{address: address, err: err})
+		}
+		return nil, err
+	}
+
+	if err := r.ValidateDiscoveredToken(contractAddress, currency.Symbol); err != nil {
+		r.putCached(&discoveredToken{address: address, err: err})
+		return nil, err
+	}
+
+	r.putCached(&discoveredToken{address: address, currency: currency})
+	return currency, nil
+}
+
+// getCached returns a cached token lookup result, marking it as recently used
+func (r *TokenRegistry) getCached(address string) (*discoveredToken, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	element, ok := r.cacheEntries[address]
+	if !ok {
+		return nil, false
+	}
+	r.cacheOrder.MoveToFront(element)
+	return element.Value.(*discoveredToken), true
+}
+
+// putCached stores a token lookup result, evicting the least recently used entry when full
+func (r *TokenRegistry) putCached(token *discoveredToken) {
+	if r == nil {
+		return
+	}
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	if element, ok := r.cacheEntries[token.address]; ok {
+		element.Value = token
+		r.cacheOrder.MoveToFront(element)
+		return
+	}
+
+	r.cacheEntries[token.address] = r.cacheOrder.PushFront(token)
+	if r.cacheOrder.Len() > r.cacheSize {
+		oldest := r.cacheOrder.Back()
+		r.cacheOrder.Remove(oldest)
+		delete(r.cacheEntries, oldest.Value.(*discoveredToken).address)
+	}
+}
+
+// IsUnsupportedTokenError checks whether a token resolution error means the contract is definitively
+// not a supported token, as opposed to a transient failure of the lookup
+func IsUnsupportedTokenError(err error) bool {
+	return errors.Is(err, ErrNotVIP180Token) || errors.Is(err, ErrTokenNotSupported)
+}
+
+// ValidateDiscoveredToken checks that a token found on chain can be supported.
+// Unregistered tokens are rejected in allow-list mode, and tokens reusing the symbol
+// of a built-in or registered currency are rejected as spoofs.
+func (r *TokenRegistry) ValidateDiscoveredToken(contractAddress, symbol string) error {
+	if _, ok := r.GetCurrency(contractAddress); ok {
+		return nil
+	}
+	if r.IsAllowList() {
+		return fmt.Errorf("%w: token %s is not registered", ErrTokenNotSupported, contractAddress)
+	}
+	if r.isReservedSymbol(symbol) {
+		return fmt.Errorf("%w: token %s reuses the reserved symbol %s", ErrTokenNotSupported, contractAddress, symbol)
+	}
+	return nil
+}
+
+// ValidateCurrency checks that a currency given in a request matches a supported currency.
+// Currencies without a contract address must be VET or VTHO, token currencies must match the
+// registered definition when their contract or symbol is known.
+func (r *TokenRegistry) ValidateCurrency(currency *types.Currency) error {
+	contractAddress, hasContract := currency.Metadata["contractAddress"].(string)
+	if !hasContract {
+		if isBuiltInSymbol(currency.Symbol) {
+			return nil
+		}
+		return fmt.Errorf("currency %s has no contract address", currency.Symbol)
+	}
+
+	registered, ok := r.GetCurrency(contractAddress)
+	if !ok {
+		return r.ValidateDiscoveredToken(contractAddress, currency.Symbol)
+	}
+	if registered.Symbol != currency.Symbol || registered.Decimals != currency.Decimals {
+		return fmt.Errorf("currency %s does not match token %s registered as %s", currency.Symbol, contractAddress, registered.Symbol)
+	}
+	return nil
+}
+
+// isReservedSymbol checks whether a symbol belongs to a built-in or registered currency
+func (r *TokenRegistry) isReservedSymbol(symbol string) bool {
+	if isBuiltInSymbol(symbol) {
+		return true
+	}
+	if r == nil {
+		return false
+	}
+	_, exists := r.bySymbol[strings.ToUpper(symbol)]
+	return exists
+}
+
+// isBuiltInSymbol checks whether a symbol belongs to VET or VTHO
+func isBuiltInSymbol(symbol string) bool {
+	return strings.EqualFold(symbol, VETCurrency.Symbol) || strings.EqualFold(symbol, VTHOCurrency.Symbol)
+}
@@ -0,0 +1,357 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+const testUSDCAddress = "0x1234567890123456789012345678901234567890"
+
+func newTestTokenCurrency(address, symbol string, decimals int32) *types.Currency {
+	return &types.Currency{
+		Symbol:   symbol,
+		Decimals: decimals,
+		Metadata: map[string]any{"contractAddress": address},
+	}
+}
+
+func TestNewTokenRegistry(t *testing.T) {
+	tests := []struct {
+		name    string
+		tokens  []*types.Currency
+		wantErr bool
+	}{
+		{
+			name:   "valid tokens",
+			tokens: []*types.Currency{newTestTokenCurrency("0x1234567890ABCDEF1234567890abcdef12345678", "USDC", 6)},
+		},
+		{
+			name:    "missing contract address",
+			tokens:  []*types.Currency{{Symbol: "USDC", Decimals: 6}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid contract address",
+			tokens:  []*types.Currency{newTestTokenCurrency("0x1234", "USDC", 6)},
+			wantErr: true,
+		},
+		{
+			name:    "missing symbol",
+			tokens:  []*types.Currency{newTestTokenCurrency(testUSDCAddress, "", 6)},
+			wantErr: true,
+		},
+		{
+			name:    "negative decimals",
+			tokens:  []*types.Currency{newTestTokenCurrency(testUSDCAddress, "USDC", -1)},
+			wantErr: true,
+		},
+		{
+			name:    "built-in symbol",
+			tokens:  []*types.Currency{newTestTokenCurrency(testUSDCAddress, "vtho", 18)},
+			wantErr: true,
+		},
+		{
+			name: "duplicate address",
+			tokens: []*types.Currency{
+				newTestTokenCurrency(testUSDCAddress, "USDC", 6),
+				newTestTokenCurrency(testUSDCAddress, "USDT", 6),
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate symbol",
+			tokens: []*types.Currency{
+				newTestTokenCurrency(testUSDCAddress, "USDC", 6),
+				newTestTokenCurrency("0x0000000000000000000000000000000000000001", "usdc", 6),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := NewTokenRegistry(tt.tokens, true, 0)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTokenRegistry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(registry.Currencies()) != len(tt.tokens) {
+				t.Errorf("Currencies() length = %d, want %d", len(registry.Currencies()), len(tt.tokens))
+			}
+			// Addresses are normalized to lower case
+			if _, ok := registry.GetCurrency("0x1234567890abcdef1234567890abcdef12345678"); !ok {
+				t.Errorf("GetCurrency() did not find the registered token")
+			}
+		})
+	}
+}
+
+func TestTokenRegistry_GetCurrency(t *testing.T) {
+	registry, err := NewTokenRegistry([]*types.Currency{newTestTokenCurrency(testUSDCAddress, "USDC", 6)}, true, 0)
+	if err != nil {
+		t.Fatalf("NewTokenRegistry() error = %v", err)
+	}
+
+	if currency, ok := registry.GetCurrency(VTHOContractAddress); !ok || currency != VTHOCurrency {
+		t.Errorf("GetCurrency() VTHO = %v, %v", currency, ok)
+	}
+	if currency, ok := registry.GetCurrency(testUSDCAddress); !ok || currency.Symbol != "USDC" {
+		t.Errorf("GetCurrency() USDC = %v, %v", currency, ok)
+	}
+	if _, ok := registry.GetCurrency("0x0000000000000000000000000000000000000001"); ok {
+		t.Errorf("GetCurrency() found an unregistered token")
+	}
+
+	var nilRegistry *TokenRegistry
+	if _, ok := nilRegistry.GetCurrency(VTHOContractAddress); !ok {
+		t.Errorf("GetCurrency() on nil registry did not find VTHO")
+	}
+	if _, ok := nilRegistry.GetCurrency(testUSDCAddress); ok {
+		t.Errorf("GetCurrency() on nil registry found a token")
+	}
+	if nilRegistry.IsAllowList() || nilRegistry.Currencies() != nil {
+		t.Errorf("nil registry should be an empty discovery registry")
+	}
+}
+
+func TestTokenRegistry_ValidateDiscoveredToken(t *testing.T) {
+	tokens := []*types.Currency{newTestTokenCurrency(testUSDCAddress, "USDC", 6)}
+	allowList, _ := NewTokenRegistry(tokens, true, 0)
+	discovery, _ := NewTokenRegistry(tokens, false, 0)
+	spoofAddress := "0x0000000000000000000000000000000000000001"
+
+	tests := []struct {
+		name     string
+		registry *TokenRegistry
+		address  string
+		symbol   string
+		wantErr  bool
+	}{
+		{"registered token", allowList, testUSDCAddress, "USDC", false},
+		{"unregistered token in allow-list", allowList, spoofAddress, "ABC", true},
+		{"unregistered token in discovery", discovery, spoofAddress, "ABC", false},
+		{"spoofed VTHO", discovery, spoofAddress, "VTHO", true},
+		{"spoofed VET", discovery, spoofAddress, "vet", true},
+		{"spoofed registered symbol", discovery, spoofAddress, "USDC", true},
+		{"nil registry", nil, spoofAddress, "ABC", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.registry.ValidateDiscoveredToken(tt.address, tt.symbol)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDiscoveredToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenRegistry_ValidateCurrency(t *testing.T) {
+	registry, _ := NewTokenRegistry([]*types.Currency{newTestTokenCurrency(testUSDCAddress, "USDC", 6)}, true, 0)
+	spoofAddress := "0x0000000000000000000000000000000000000001"
+
+	tests := []struct {
+		name     string
+		currency *types.Currency
+		wantErr  bool
+	}{
+		{"VET", VETCurrency, false},
+		{"VTHO", VTHOCurrency, false},
+		{"VTHO without contract address", &types.Currency{Symbol: "VTHO", Decimals: 18}, false},
+		{"registered token", newTestTokenCurrency(testUSDCAddress, "USDC", 6), false},
+		{"wrong decimals", newTestTokenCurrency(testUSDCAddress, "USDC", 18), true},
+		{"wrong symbol", newTestTokenCurrency(testUSDCAddress, "USDT", 6), true},
+		{"spoofed VTHO", newTestTokenCurrency(spoofAddress, "VTHO", 18), true},
+		{"spoofed USDC", newTestTokenCurrency(spoofAddress, "USDC", 6), true},
+		{"unknown symbol without contract", &types.Currency{Symbol: "ABC", Decimals: 18}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.ValidateCurrency(tt.currency)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCurrency() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTokenRegistry_ResolveCurrency(t *testing.T) {
+	tokenAddress := "0x0000000000000000000000000000000000000abc"
+	token := newTestTokenCurrency(tokenAddress, "ABC", 18)
+
+	t.Run("caches discovered tokens", func(t *testing.T) {
+		registry, _ := NewTokenRegistry(nil, false, 0)
+		calls := 0
+		lookup := func(string) (*types.Currency, error) {
+			calls++
+			return token, nil
+		}
+
+		for range 3 {
+			currency, err := registry.ResolveCurrency(tokenAddress, lookup)
+			if err != nil {
+				t.Fatalf("ResolveCurrency() error = %v", err)
+			}
+			if currency.Symbol != "ABC" {
+				t.Errorf("ResolveCurrency() symbol = %s, want ABC", currency.Symbol)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("lookup called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("caches contracts that are not tokens", func(t *testing.T) {
+		registry, _ := NewTokenRegistry(nil, false, 0)
+		calls := 0
+		lookup := func(string) (*types.Currency, error) {
+			calls++
+			return nil, fmt.Errorf("%w: execution reverted", ErrNotVIP180Token)
+		}
+
+		for range 3 {
+			if _, err := registry.ResolveCurrency(tokenAddress, lookup); !errors.Is(err, ErrNotVIP180Token) {
+				t.Errorf("ResolveCurrency() error = %v, want ErrNotVIP180Token", err)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("lookup called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("caches spoofed tokens", func(t *testing.T) {
+		registry, _ := NewTokenRegistry(nil, false, 0)
+		calls := 0
+		lookup := func(string) (*types.Currency, error) {
+			calls++
+			return newTestTokenCurrency(tokenAddress, "VTHO", 18), nil
+		}
+
+		for range 2 {
+			if _, err := registry.ResolveCurrency(tokenAddress, lookup); !IsUnsupportedTokenError(err) {
+				t.Errorf("ResolveCurrency() error = %v, want unsupported token error for spoofed token", err)
+			}
+		}
+		if calls != 1 {
+			t.Errorf("lookup called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("does not cache transient errors", func(t *testing.T) {
+		registry, _ := NewTokenRegistry(nil, false, 0)
+		calls := 0
+		lookup := func(string) (*types.Currency, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("connection refused")
+			}
+			return token, nil
+		}
+
+		if _, err := registry.ResolveCurrency(tokenAddress, lookup); err == nil || IsUnsupportedTokenError(err) {
+			t.Errorf("ResolveCurrency() error = %v, want transient error on first lookup", err)
+		}
+		if _, err := registry.ResolveCurrency(tokenAddress, lookup); err != nil {
+			t.Errorf("ResolveCurrency() error = %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("lookup called %d times, want 2", calls)
+		}
+	})
+
+	t.Run("registered tokens skip lookup", func(t *testing.T) {
+		registry, _ := NewTokenRegistry([]*types.Currency{newTestTokenCurrency(testUSDCAddress, "USDC", 6)}, true, 0)
+		lookup := func(string) (*types.Currency, error) {
+			t.Fatal("lookup should not be called")
+			return nil, nil
+		}
+
+		currency, err := registry.ResolveCurrency(testUSDCAddress, lookup)
+		if err != nil || currency.Symbol != "USDC" {
+			t.Errorf("ResolveCurrency() = %v, %v, want USDC", currency, err)
+		}
+		if _, err := registry.ResolveCurrency(tokenAddress, lookup); !IsUnsupportedTokenError(err) {
+			t.Errorf("ResolveCurrency() error = %v, want unsupported token error in allow-list mode", err)
+		}
+	})
+
+	t.Run("evicts least recently used entries", func(t *testing.T) {
+		registry, _ := NewTokenRegistry(nil, false, 2)
+		calls := map[string]int{}
+		lookup := func(address string) (*types.Currency, error) {
+			calls[address]++
+			return newTestTokenCurrency(address, "T"+address[len(address)-1:], 18), nil
+		}
+
+		first := "0x0000000000000000000000000000000000000001"
+		second := "0x0000000000000000000000000000000000000002"
+		third := "0x0000000000000000000000000000000000000003"
+		for _, address := range []string{first, second, first, third, first, second} {
+			if _, err := registry.ResolveCurrency(address, lookup); err != nil {
+				t.Fatalf("ResolveCurrency() error = %v", err)
+			}
+		}
+
+		if calls[first] != 1 {
+			t.Errorf("lookup called %d times for recently used token, want 1", calls[first])
+		}
+		if calls[second] != 2 {
+			t.Errorf("lookup called %d times for evicted token, want 2", calls[second])
+		}
+	})
+
+	t.Run("nil registry does not cache", func(t *testing.T) {
+		var registry *TokenRegistry
+		calls := 0
+		lookup := func(string) (*types.Currency, error) {
+			calls++
+			return token, nil
+		}
+
+		for range 2 {
+			if _, err := registry.ResolveCurrency(tokenAddress, lookup); err != nil {
+				t.Fatalf("ResolveCurrency() error = %v", err)
+			}
+		}
+		if calls != 2 {
+			t.Errorf("lookup called %d times, want 2", calls)
+		}
+	})
+}
+
+func TestTokenRegistry_ResolveCurrency_Concurrent(t *testing.T) {
+	registry, _ := NewTokenRegistry(nil, false, 8)
+	var calls atomic.Int32
+	lookup := func(address string) (*types.Currency, error) {
+		calls.Add(1)
+		return newTestTokenCurrency(address, "TKN", 18), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := range 64 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			address := fmt.Sprintf("0x%040x", i%16+1)
+			if _, err := registry.ResolveCurrency(address, lookup); err != nil {
+				t.Errorf("ResolveCurrency() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if calls.Load() < 16 {
+		t.Errorf("lookup called %d times, want at least 16", calls.Load())
+	}
+	if len(registry.cacheEntries) > 8 || registry.cacheOrder.Len() > 8 {
+		t.Errorf("cache holds %d entries, want at most 8", registry.cacheOrder.Len())
+	}
+}
@@ -3,13 +3,13 @@ package tx
 import (
 	"fmt"
 	"math/big"
-	"sort"
 	"strings"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/ethereum/go-ethereum/common/math"
 	meshcommon "github.com/vechain/mesh/common"
 	meshcrypto "github.com/vechain/mesh/common/crypto"
+	meshoperations "github.com/vechain/mesh/common/operations"
 	"github.com/vechain/mesh/common/vip180"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/transactions"
@@ -17,6 +17,9 @@ import (
 	thorTx "github.com/vechain/thor/v2/tx"
 )
 
+// maxExpiration is the largest expiration a transaction can encode
+const maxExpiration = float64(^uint32(0))
+
 type TransactionBuilder struct {
 	vip180Encoder *vip180.VIP180Encoder
 	bytesHandler  *meshcrypto.BytesHandler
@@ -142,8 +145,19 @@ func (b *TransactionBuilder) BuildTransactionFromRequest(request types.Construct
 
 	builder.BlockRef(thorTx.BlockRef(blockRefBytes))
 
-	// Set expiration from configuration
-	builder.Expiration(expiration)
+	// Expiration and dependency requested by the caller, the configured expiration is the default
+	txExpiration, err := ParseExpiration(metadata, expiration)
+	if err != nil {
+		return nil, err
+	}
+	dependsOn, err := ParseDependsOn(metadata)
+	if err != nil {
+		return nil, err
+	}
+	builder.Expiration(txExpiration)
+	if dependsOn != nil {
+		builder.DependsOn(dependsOn)
+	}
 	builder.Gas(uint64(gasFloat))
 	builder.Nonce(nonceValue.Uint64())
 
@@ -159,6 +173,38 @@ func (b *TransactionBuilder) BuildTransactionFromRequest(request types.Construct
 	return builder.Build(), nil
 }
 
+// ParseExpiration returns the expiration, in blocks, requested in the "expiration" metadata key,
+// or defaultExpiration when it is not set
+func ParseExpiration(metadata map[string]any, defaultExpiration uint32) (uint32, error) {
+	raw, ok := metadata["expiration"]
+	if !ok {
+		return defaultExpiration, nil
+	}
+	// encoding/json deserializes numbers as float64
+	expiration, ok := raw.(float64)
+	if !ok || expiration < 1 || expiration > maxExpiration || expiration != float64(uint32(expiration)) {
+		return 0, fmt.Errorf("expiration must be an integer in [1,%d]", uint32(maxExpiration))
+	}
+	return uint32(expiration), nil
+}
+
+// ParseDependsOn returns the ID of the transaction requested in the "dependsOn" metadata key, nil when it is not set
+func ParseDependsOn(metadata map[string]any) (*thor.Bytes32, error) {
+	raw, ok := metadata["dependsOn"]
+	if !ok {
+		return nil, nil
+	}
+	dependsOnStr, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("dependsOn must be a transaction ID string")
+	}
+	dependsOn, err := thor.ParseBytes32(dependsOnStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dependsOn: %w", err)
+	}
+	return &dependsOn, nil
+}
+
 // createTransactionBuilder creates a transaction builder based on type
 func (b *TransactionBuilder) createTransactionBuilder(transactionType string, metadata map[string]any) (*thorTx.Builder, error) {
 	if transactionType == meshcommon.TransactionTypeLegacy {
@@ -204,15 +250,8 @@ func (b *TransactionBuilder) createTransactionBuilder(transactionType string, me
 
 // addClausesToBuilder adds clauses to the transaction builder
 func (b *TransactionBuilder) addClausesToBuilder(builder *thorTx.Builder, operations []*types.Operation) error {
-	// Sort operations by index to maintain order
-	sortedOps := make([]*types.Operation, len(operations))
-	copy(sortedOps, operations)
-
-	sort.Slice(sortedOps, func(i, j int) bool {
-		return sortedOps[i].OperationIdentifier.Index < sortedOps[j].OperationIdentifier.Index
-	})
-
-	for _, op := range sortedOps {
+	// Clauses follow the operation index order
+	for _, op := range meshoperations.SortOperations(operations) {
 		if op.Type == meshcommon.OperationTypeTransfer {
 			// Only process Transfer operations with positive values (recipients)
 			value := new(big.Int)
@@ -267,6 +306,19 @@ func (b *TransactionBuilder) addClausesToBuilder(builder *thorTx.Builder, operat
 			clause = clause.WithValue(value)
 			builder.Clause(clause)
 		}
+		if op.Type == meshcommon.OperationTypeContractCall || op.Type == meshcommon.OperationTypeContractDeploy {
+			parse := meshoperations.ParseContractCallOperation
+			if op.Type == meshcommon.OperationTypeContractDeploy {
+				parse = meshoperations.ParseContractDeployOperation
+			}
+			call, err := parse(op)
+			if err != nil {
+				return err
+			}
+			// Deployments have no recipient
+			clause := thorTx.NewClause(call.To).WithValue(call.Value).WithData(call.Data)
+			builder.Clause(clause)
+		}
 		// FeeDelegation operations are handled in the signing process
 	}
 	return nil
@@ -1,7 +1,9 @@
 package tx
 
 import (
+	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
@@ -173,6 +175,103 @@ func TestBuildTransactionFromRequest(t *testing.T) {
 	}
 }
 
+func TestBuildTransactionFromRequest_ExpirationAndDependsOn(t *testing.T) {
+	config := createTestConfig()
+	dependsOn := "0x" + strings.Repeat("ab", 32)
+
+	request := types.ConstructionPayloadsRequest{
+		Operations: []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                meshcommon.OperationTypeTransfer,
+				Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+				Amount:              &types.Amount{Value: "1000000000000000000", Currency: meshcommon.VETCurrency},
+			},
+		},
+		Metadata: map[string]any{
+			"transactionType": meshcommon.TransactionTypeLegacy,
+			"blockRef":        "0x0000000000000000",
+			"chainTag":        float64(1),
+			"gas":             float64(21000),
+			"nonce":           "0x1",
+			"gasPriceCoef":    uint8(0),
+			"expiration":      float64(32),
+			"dependsOn":       dependsOn,
+		},
+	}
+
+	builder := NewTransactionBuilder()
+	tx, err := builder.BuildTransactionFromRequest(request, config.Expiration)
+	if err != nil {
+		t.Fatalf("BuildTransactionFromRequest() error = %v", err)
+	}
+	if tx.Expiration() != 32 {
+		t.Errorf("BuildTransactionFromRequest() expiration = %d, want 32", tx.Expiration())
+	}
+	if tx.DependsOn() == nil || tx.DependsOn().String() != dependsOn {
+		t.Errorf("BuildTransactionFromRequest() dependsOn = %v, want %s", tx.DependsOn(), dependsOn)
+	}
+
+	// Without metadata the configured expiration is used and there is no dependency
+	delete(request.Metadata, "expiration")
+	delete(request.Metadata, "dependsOn")
+	tx, err = builder.BuildTransactionFromRequest(request, 720)
+	if err != nil {
+		t.Fatalf("BuildTransactionFromRequest() error = %v", err)
+	}
+	if tx.Expiration() != 720 || tx.DependsOn() != nil {
+		t.Errorf("BuildTransactionFromRequest() expiration = %d, dependsOn = %v, want 720 and none", tx.Expiration(), tx.DependsOn())
+	}
+}
+
+func TestParseExpiration(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		expected uint32
+		wantErr  bool
+	}{
+		{name: "default", metadata: map[string]any{}, expected: 720},
+		{name: "requested", metadata: map[string]any{"expiration": float64(18)}, expected: 18},
+		{name: "zero", metadata: map[string]any{"expiration": float64(0)}, wantErr: true},
+		{name: "fractional", metadata: map[string]any{"expiration": 1.5}, wantErr: true},
+		{name: "too large", metadata: map[string]any{"expiration": float64(1 << 32)}, wantErr: true},
+		{name: "string", metadata: map[string]any{"expiration": "18"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiration, err := ParseExpiration(tt.metadata, 720)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseExpiration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && expiration != tt.expected {
+				t.Errorf("ParseExpiration() = %d, want %d", expiration, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDependsOn(t *testing.T) {
+	txID := "0x" + strings.Repeat("01", 32)
+
+	dependsOn, err := ParseDependsOn(map[string]any{"dependsOn": txID})
+	if err != nil || dependsOn == nil || dependsOn.String() != txID {
+		t.Errorf("ParseDependsOn() = %v, %v, want %s", dependsOn, err, txID)
+	}
+
+	dependsOn, err = ParseDependsOn(map[string]any{})
+	if err != nil || dependsOn != nil {
+		t.Errorf("ParseDependsOn() = %v, %v, want nil", dependsOn, err)
+	}
+
+	for _, invalid := range []any{"0x1234", float64(1), "not hex"} {
+		if _, err := ParseDependsOn(map[string]any{"dependsOn": invalid}); err == nil {
+			t.Errorf("ParseDependsOn(%v) expected error", invalid)
+		}
+	}
+}
+
 func TestBuildTransactionFromRequest_WithFeeDelegation(t *testing.T) {
 	config := createTestConfig()
 
@@ -475,6 +574,80 @@ func TestAddClausesToBuilder_VIP180Transfer(t *testing.T) {
 	}
 }
 
+func TestAddClausesToBuilder_ContractCall(t *testing.T) {
+	builder := thorTx.NewBuilder(thorTx.TypeLegacy)
+
+	operations := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                meshcommon.OperationTypeContractCall,
+			Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+			Amount:              &types.Amount{Value: "0", Currency: meshcommon.VETCurrency},
+			Metadata: map[string]any{
+				"to":    meshtests.TestAddress1,
+				"data":  "0xd09de08a",
+				"value": "1000",
+			},
+		},
+	}
+
+	meshTxBuilder := NewTransactionBuilder()
+	if err := meshTxBuilder.addClausesToBuilder(builder, operations); err != nil {
+		t.Fatalf("addClausesToBuilder() ContractCall error = %v", err)
+	}
+
+	clauses := builder.Build().Clauses()
+	if len(clauses) != 1 {
+		t.Fatalf("Expected 1 clause for contract call, got %d", len(clauses))
+	}
+	if clauses[0].To() == nil || clauses[0].To().String() != meshtests.TestAddress1 {
+		t.Errorf("Clause 'to' = %v, want %v", clauses[0].To(), meshtests.TestAddress1)
+	}
+	if clauses[0].Value().Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("Clause value = %v, want 1000", clauses[0].Value())
+	}
+	if fmt.Sprintf("0x%x", clauses[0].Data()) != "0xd09de08a" {
+		t.Errorf("Clause data = 0x%x, want 0xd09de08a", clauses[0].Data())
+	}
+
+	// Call data is required
+	delete(operations[0].Metadata, "data")
+	if err := meshTxBuilder.addClausesToBuilder(thorTx.NewBuilder(thorTx.TypeLegacy), operations); err == nil {
+		t.Error("addClausesToBuilder() expected error for contract call without data")
+	}
+}
+
+func TestAddClausesToBuilder_ContractDeploy(t *testing.T) {
+	builder := thorTx.NewBuilder(thorTx.TypeLegacy)
+
+	operations := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                meshcommon.OperationTypeContractDeploy,
+			Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+			Metadata: map[string]any{
+				"bytecode": "0x608060405201",
+			},
+		},
+	}
+
+	meshTxBuilder := NewTransactionBuilder()
+	if err := meshTxBuilder.addClausesToBuilder(builder, operations); err != nil {
+		t.Fatalf("addClausesToBuilder() ContractDeploy error = %v", err)
+	}
+
+	clauses := builder.Build().Clauses()
+	if len(clauses) != 1 {
+		t.Fatalf("Expected 1 clause for contract deployment, got %d", len(clauses))
+	}
+	if clauses[0].To() != nil {
+		t.Errorf("Clause 'to' = %v, want nil", clauses[0].To())
+	}
+	if fmt.Sprintf("0x%x", clauses[0].Data()) != "0x608060405201" {
+		t.Errorf("Clause data = 0x%x, want 0x608060405201", clauses[0].Data())
+	}
+}
+
 func TestBuildTransactionMetadata_Legacy(t *testing.T) {
 	builder := NewTransactionBuilder()
 	gasPriceCoef := uint8(128)
@@ -678,7 +851,7 @@ func TestBuildMeshTransactionFromTransactions(t *testing.T) {
 		}(),
 	}
 
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 	status := meshcommon.OperationStatusSucceeded
 	operations, err := encoder.clauseParser.ParseOperationsFromAPIClauses(tx.Clauses, tx.Origin.String(), "", tx.Gas, &status)
 	if err != nil {
@@ -717,7 +890,7 @@ func TestBuildMeshTransactionFromAPI(t *testing.T) {
 		}(),
 	}
 
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 	operations, err := encoder.ParseTransactionOperationsFromAPI(tx)
 	if err != nil {
 		t.Errorf("ParseTransactionOperationsFromAPI() error = %v", err)
@@ -2,6 +2,7 @@ package tx
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -23,6 +24,15 @@ type MeshTransaction struct {
 	Delegator []byte
 }
 
+// MaxGasPrice returns the highest price per gas the transaction can pay, derived from its own fields:
+// the gas price set by gasPriceCoef over baseGasPrice for legacy transactions, maxFeePerGas otherwise
+func (t *MeshTransaction) MaxGasPrice(baseGasPrice *big.Int) *big.Int {
+	if t.Type() == thorTx.TypeLegacy {
+		return t.EffectiveGasPrice(nil, baseGasPrice)
+	}
+	return new(big.Int).Set(t.MaxFeePerGas())
+}
+
 // MeshTransactionEncoder handles encoding and decoding of Mesh transactions
 type MeshTransactionEncoder struct {
 	vechainClient meshthor.VeChainClientInterface
@@ -30,10 +40,10 @@ type MeshTransactionEncoder struct {
 }
 
 // NewMeshTransactionEncoder creates a new Mesh transaction encoder
-func NewMeshTransactionEncoder(vechainClient meshthor.VeChainClientInterface) *MeshTransactionEncoder {
+func NewMeshTransactionEncoder(vechainClient meshthor.VeChainClientInterface, tokenRegistry *meshcommon.TokenRegistry) *MeshTransactionEncoder {
 	return &MeshTransactionEncoder{
 		vechainClient: vechainClient,
-		clauseParser:  meshoperations.NewClauseParser(vechainClient, meshoperations.NewOperationsExtractor()),
+		clauseParser:  meshoperations.NewClauseParser(vechainClient, meshoperations.NewOperationsExtractor(tokenRegistry)),
 	}
 }
 
@@ -129,6 +139,14 @@ func (e *MeshTransactionEncoder) ParseTransactionOperationsFromAPI(tx *api.JSONE
 		delegatorAddr = tx.Delegator.String()
 	}
 
+	// Expanded blocks carry the receipt, so the fee operation can report the VTHO actually paid
+	// and VET transfers can be taken from the clause outputs
+	if tx.Paid != nil {
+		fee := meshoperations.NewTransactionFee(tx.GasUsed, tx.GasPayer, tx.Paid, tx.Reward)
+		outputs := meshoperations.JSONOutputsToClauseOutputs(tx.Outputs)
+		return e.clauseParser.ParseExecutedTransactionOperations(meshoperations.JSONClausesToClauseData(tx.Clauses), outputs, tx.Origin.String(), delegatorAddr, fee, &status)
+	}
+
 	return e.clauseParser.ParseTransactionOperationsFromJSONClauses(tx.Clauses, tx.Origin.String(), delegatorAddr, tx.Gas, &status)
 }
 
@@ -196,7 +214,7 @@ func (e *MeshTransactionEncoder) parseTransactionSignersAndOperations(meshTx *Me
 		clauseData[i] = meshoperations.ClauseAdapter{Clause: apiClause}
 	}
 
-	operations, err := e.clauseParser.ParseTransactionOperationsFromClauseData(clauseData, originAddr, delegatorAddr, uint64(meshTx.Gas()), nil)
+	operations, err := e.clauseParser.ParseConstructionOperations(clauseData, originAddr, delegatorAddr, uint64(meshTx.Gas()))
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to parse operations")
 	}
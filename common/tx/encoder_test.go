@@ -83,14 +83,35 @@ func createTestConfig() *config.Config {
 }
 
 func TestNewMeshTransactionEncoder(t *testing.T) {
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 	if encoder == nil {
 		t.Errorf("NewMeshTransactionEncoder() returned nil")
 	}
 }
 
+func TestMeshTransaction_MaxGasPrice(t *testing.T) {
+	baseGasPrice := big.NewInt(1000)
+
+	legacy := &MeshTransaction{Transaction: createTestVeChainTransaction()}
+	if gasPrice := legacy.MaxGasPrice(baseGasPrice); gasPrice.Cmp(baseGasPrice) != 0 {
+		t.Errorf("MaxGasPrice() legacy = %v, want %v", gasPrice, baseGasPrice)
+	}
+
+	builder := thorTx.NewBuilder(thorTx.TypeLegacy)
+	builder.GasPriceCoef(255)
+	withCoef := &MeshTransaction{Transaction: builder.Build()}
+	if gasPrice := withCoef.MaxGasPrice(baseGasPrice); gasPrice.Int64() != 2000 {
+		t.Errorf("MaxGasPrice() legacy with coef = %v, want 2000", gasPrice)
+	}
+
+	dynamic := &MeshTransaction{Transaction: createTestVeChainDynamicTransaction()}
+	if gasPrice := dynamic.MaxGasPrice(baseGasPrice); gasPrice.Cmp(dynamic.MaxFeePerGas()) != 0 {
+		t.Errorf("MaxGasPrice() dynamic = %v, want %v", gasPrice, dynamic.MaxFeePerGas())
+	}
+}
+
 func TestEncodeUnsignedTransaction_Legacy(t *testing.T) {
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 	vechainTx := createTestVeChainTransaction()
 	origin := []byte{0x03, 0xe3, 0x2e, 0x59, 0x60, 0x78, 0x1c, 0xe0, 0xb4, 0x3d, 0x8c, 0x29, 0x52, 0xee, 0xea, 0x4b, 0x95, 0xe2, 0x86, 0xb1, 0xbb, 0x5f, 0x8c, 0x1f, 0x0c, 0x9f, 0x09, 0x98, 0x3b, 0xa7, 0x14, 0x1d, 0x2f}
 	delegator := []byte{}
@@ -109,7 +130,7 @@ func TestEncodeUnsignedTransaction_Legacy(t *testing.T) {
 }
 
 func TestEncodeUnsignedTransaction_Dynamic(t *testing.T) {
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 
 	vechainTx := createTestVeChainDynamicTransaction()
 	origin := []byte{0x03, 0xe3, 0x2e, 0x59, 0x60, 0x78, 0x1c, 0xe0, 0xb4, 0x3d, 0x8c, 0x29, 0x52, 0xee, 0xea, 0x4b, 0x95, 0xe2, 0x86, 0xb1, 0xbb, 0x5f, 0x8c, 0x1f, 0x0c, 0x9f, 0x09, 0x98, 0x3b, 0xa7, 0x14, 0x1d, 0x2f}
@@ -129,7 +150,7 @@ func TestEncodeUnsignedTransaction_Dynamic(t *testing.T) {
 }
 
 func TestDecodeUnsignedTransaction_ValidData(t *testing.T) {
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 
 	// First encode a transaction
 	vechainTx := createTestVeChainTransaction()
@@ -181,7 +202,7 @@ func TestDecodeUnsignedTransaction_ValidData(t *testing.T) {
 }
 
 func TestDecodeUnsignedTransaction_Dynamic(t *testing.T) {
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 
 	// First encode a dynamic transaction
 	vechainTx := createTestVeChainDynamicTransaction()
@@ -238,7 +259,7 @@ func TestDecodeUnsignedTransaction_Dynamic(t *testing.T) {
 }
 
 func TestDecodeSignedTransaction_ValidData(t *testing.T) {
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 
 	// First encode a signed transaction
 	meshTx := createTestMeshTransaction()
@@ -286,7 +307,7 @@ func TestDecodeSignedTransaction_ValidData(t *testing.T) {
 }
 
 func TestDecodeSignedTransaction_Dynamic(t *testing.T) {
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 
 	// Create a dynamic mesh transaction
 	vechainTx := createTestVeChainDynamicTransaction()
@@ -353,7 +374,7 @@ func TestDecodeSignedTransaction_Dynamic(t *testing.T) {
 }
 
 func TestEncodeSignedTransaction_Legacy(t *testing.T) {
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 	meshTx := createTestMeshTransaction()
 
 	encoded, err := encoder.EncodeTransaction(meshTx)
@@ -391,7 +412,7 @@ func TestParseTransactionOperationsFromAPI(t *testing.T) {
 		}(),
 	}
 
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 	operations, err := encoder.ParseTransactionOperationsFromAPI(tx)
 	if err != nil {
 		t.Errorf("ParseTransactionOperationsFromAPI() error = %v", err)
@@ -435,7 +456,7 @@ func TestParseTransactionOperationsFromAPI_WithDelegation(t *testing.T) {
 		Gas:       21000,
 	}
 
-	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient())
+	encoder := NewMeshTransactionEncoder(meshthor.NewMockVeChainClient(), nil)
 	operations, err := encoder.ParseTransactionOperationsFromAPI(tx)
 	if err != nil {
 		t.Errorf("ParseTransactionOperationsFromAPI() error = %v", err)
@@ -475,7 +496,7 @@ func TestParseTransactionOperationsFromAPI_WithDelegation(t *testing.T) {
 
 func TestParseTransactionSignersAndOperations(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	encoder := NewMeshTransactionEncoder(mockClient)
+	encoder := NewMeshTransactionEncoder(mockClient, nil)
 
 	tests := []struct {
 		name            string
@@ -647,7 +668,7 @@ func TestParseTransactionSignersAndOperations(t *testing.T) {
 
 func TestParseTransactionFromBytes_ErrorHandling(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	encoder := NewMeshTransactionEncoder(mockClient)
+	encoder := NewMeshTransactionEncoder(mockClient, nil)
 
 	tests := []struct {
 		name      string
@@ -694,7 +715,7 @@ func TestParseTransactionFromBytes_ErrorHandling(t *testing.T) {
 
 func TestParseTransactionFromBytes_ValidTransaction(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	encoder := NewMeshTransactionEncoder(mockClient)
+	encoder := NewMeshTransactionEncoder(mockClient, nil)
 
 	// Create a valid unsigned transaction
 	builder := thorTx.NewBuilder(thorTx.TypeLegacy)
@@ -752,7 +773,7 @@ func TestParseTransactionFromBytes_ValidTransaction(t *testing.T) {
 
 func TestDecodeUnsignedTransaction_ErrorHandling(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	encoder := NewMeshTransactionEncoder(mockClient)
+	encoder := NewMeshTransactionEncoder(mockClient, nil)
 
 	tests := []struct {
 		name      string
@@ -790,7 +811,7 @@ func TestDecodeUnsignedTransaction_ErrorHandling(t *testing.T) {
 
 func TestDecodeSignedTransaction_ErrorHandling(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	encoder := NewMeshTransactionEncoder(mockClient)
+	encoder := NewMeshTransactionEncoder(mockClient, nil)
 
 	tests := []struct {
 		name      string
@@ -10,6 +10,7 @@ import (
 	meshcrypto "github.com/vechain/mesh/common/crypto"
 	"github.com/vechain/mesh/common/vip180/contracts"
 	"github.com/vechain/thor/v2/abi"
+	"github.com/vechain/thor/v2/thor"
 )
 
 // vip180TransferData represents decoded transfer data from a VIP180 token
@@ -18,6 +19,13 @@ type vip180TransferData struct {
 	Value *big.Int
 }
 
+// vip180TransferEvent represents a decoded Transfer event emitted by a VIP180 token
+type vip180TransferEvent struct {
+	From  thor.Address
+	To    thor.Address
+	Value *big.Int
+}
+
 type VIP180Encoder struct {
 	abi          *abi.ABI
 	bytesHandler *meshcrypto.BytesHandler
@@ -102,3 +110,39 @@ func (e *VIP180Encoder) EncodeVIP180TransferCallData(to string, amount string) (
 
 	return "0x" + hex.EncodeToString(data), nil
 }
+
+// TransferEventID returns the topic identifying Transfer(address,address,uint256) event logs
+func (e *VIP180Encoder) TransferEventID() thor.Bytes32 {
+	event, exists := e.abi.EventByName("Transfer")
+	if !exists {
+		panic("transfer event not found in ABI")
+	}
+	return event.ID()
+}
+
+// DecodeVIP180TransferEvent decodes a Transfer(address,address,uint256) event log.
+// Events with a different signature or layout (e.g. VIP181 transfers with an indexed token id) are rejected.
+func (e *VIP180Encoder) DecodeVIP180TransferEvent(topics []thor.Bytes32, data string) (*vip180TransferEvent, error) {
+	event, exists := e.abi.EventByName("Transfer")
+	if !exists {
+		return nil, fmt.Errorf("transfer event not found in ABI")
+	}
+
+	if len(topics) != 3 || topics[0] != event.ID() {
+		return nil, fmt.Errorf("not a VIP180 transfer event")
+	}
+
+	dataBytes, err := e.bytesHandler.DecodeHexStringWithPrefix(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex data: %w", err)
+	}
+	if len(dataBytes) != 32 {
+		return nil, fmt.Errorf("invalid transfer event data length: %d", len(dataBytes))
+	}
+
+	return &vip180TransferEvent{
+		From:  thor.BytesToAddress(topics[1].Bytes()),
+		To:    thor.BytesToAddress(topics[2].Bytes()),
+		Value: new(big.Int).SetBytes(dataBytes),
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	meshtests "github.com/vechain/mesh/tests"
+	"github.com/vechain/thor/v2/thor"
 )
 
 func TestIsVIP180TransferCallData(t *testing.T) {
@@ -154,3 +155,70 @@ func TestEncodeVIP180TransferCallData(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeVIP180TransferEvent(t *testing.T) {
+	encoder := NewVIP180Encoder()
+	transferEvent, _ := encoder.abi.EventByName("Transfer")
+	approvalEvent, _ := encoder.abi.EventByName("Approval")
+
+	from, _ := thor.ParseAddress(meshtests.FirstSoloAddress)
+	to, _ := thor.ParseAddress(meshtests.TestAddress1)
+	fromTopic := thor.BytesToBytes32(from.Bytes())
+	toTopic := thor.BytesToBytes32(to.Bytes())
+	amountData := "0x0000000000000000000000000000000000000000000000000de0b6b3a7640000"
+
+	tests := []struct {
+		name    string
+		topics  []thor.Bytes32
+		data    string
+		wantErr bool
+	}{
+		{
+			name:   "Valid transfer event",
+			topics: []thor.Bytes32{transferEvent.ID(), fromTopic, toTopic},
+			data:   amountData,
+		},
+		{
+			name:    "Other event",
+			topics:  []thor.Bytes32{approvalEvent.ID(), fromTopic, toTopic},
+			data:    amountData,
+			wantErr: true,
+		},
+		{
+			name:    "Indexed token id",
+			topics:  []thor.Bytes32{transferEvent.ID(), fromTopic, toTopic, {}},
+			data:    "0x",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid data length",
+			topics:  []thor.Bytes32{transferEvent.ID(), fromTopic, toTopic},
+			data:    "0x01",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid hex data",
+			topics:  []thor.Bytes32{transferEvent.ID(), fromTopic, toTopic},
+			data:    "0xzz",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := encoder.DecodeVIP180TransferEvent(tt.topics, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeVIP180TransferEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.From != from || result.To != to {
+				t.Errorf("DecodeVIP180TransferEvent() From = %v, To = %v", result.From, result.To)
+			}
+			if result.Value.String() != "1000000000000000000" {
+				t.Errorf("DecodeVIP180TransferEvent() Value = %v, want 1000000000000000000", result.Value.String())
+			}
+		})
+	}
+}
@@ -11,6 +11,15 @@ import (
 	"github.com/vechain/thor/v2/thor"
 )
 
+// ContractCallError is returned when a contract method could not be called, as opposed to
+// a call whose result could not be decoded because the contract does not implement the method
+type ContractCallError struct {
+	Err error
+}
+
+func (e *ContractCallError) Error() string { return e.Err.Error() }
+func (e *ContractCallError) Unwrap() error { return e.Err }
+
 // VIP180Contract represents a VIP180 token contract
 type VIP180Contract struct {
 	address      string
@@ -76,7 +85,7 @@ func (c *VIP180Contract) BalanceOf(owner string) (*big.Int, error) {
 
 	result, err := c.client.CallContract(c.address, fmt.Sprintf("0x%x", callData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to call balanceOf: %w", err)
+		return nil, fmt.Errorf("failed to call balanceOf: %w", &ContractCallError{Err: err})
 	}
 
 	return c.decodeBigIntResult(method, result)
@@ -96,7 +105,7 @@ func (c *VIP180Contract) callStringMethod(methodName string) (string, error) {
 
 	result, err := c.client.CallContract(c.address, fmt.Sprintf("0x%x", callData))
 	if err != nil {
-		return "", fmt.Errorf("failed to call %s: %w", methodName, err)
+		return "", fmt.Errorf("failed to call %s: %w", methodName, &ContractCallError{Err: err})
 	}
 
 	return c.decodeStringResult(method, result)
@@ -116,7 +125,7 @@ func (c *VIP180Contract) callInt32Method(methodName string) (int32, error) {
 
 	result, err := c.client.CallContract(c.address, fmt.Sprintf("0x%x", callData))
 	if err != nil {
-		return 0, fmt.Errorf("failed to call %s: %w", methodName, err)
+		return 0, fmt.Errorf("failed to call %s: %w", methodName, &ContractCallError{Err: err})
 	}
 
 	return c.decodeInt32Result(method, result)
@@ -136,7 +145,7 @@ func (c *VIP180Contract) callBigIntMethod(methodName string) (*big.Int, error) {
 
 	result, err := c.client.CallContract(c.address, fmt.Sprintf("0x%x", callData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to call %s: %w", methodName, err)
+		return nil, fmt.Errorf("failed to call %s: %w", methodName, &ContractCallError{Err: err})
 	}
 
 	return c.decodeBigIntResult(method, result)
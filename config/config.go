@@ -1,33 +1,95 @@
 package config
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	meshcommon "github.com/vechain/mesh/common"
+	"github.com/vechain/thor/v2/thor"
 )
 
+// EventsJournalStartGenesis starts the block events journal at the genesis block
+const EventsJournalStartGenesis = "genesis"
+
+// supportedNetwork is the canonical name and chain tag of a public or solo network
+type supportedNetwork struct {
+	name     string
+	chainTag byte
+}
+
+// supportedNetworks maps the known network names to their network, any other name is a custom network
+var supportedNetworks = map[string]supportedNetwork{
+	meshcommon.MainNetwork: {name: meshcommon.MainNetwork, chainTag: 0x4a},
+	"mainnet":              {name: meshcommon.MainNetwork, chainTag: 0x4a},
+	meshcommon.TestNetwork: {name: meshcommon.TestNetwork, chainTag: 0x27},
+	"testnet":              {name: meshcommon.TestNetwork, chainTag: 0x27},
+	meshcommon.SoloNetwork: {name: meshcommon.SoloNetwork, chainTag: 0xf6},
+}
+
+// eventsJournalFile is the name of the block events journal in the data directory
+const eventsJournalFile = "events.journal"
+
+// DefaultSyncStallThreshold is the stall threshold used when none is configured, six blocks
+const DefaultSyncStallThreshold = time.Minute
+
 // Config holds the service configuration
 type Config struct {
-	MeshVersion       string                   `json:"meshVersion"`
-	Port              int                      `json:"port"`
-	Mode              string                   `json:"mode"`
-	Network           string                   `json:"network"`
-	NodeAPI           string                   `json:"nodeApi"`
-	ChainTag          byte                     `json:"chainTag"`
-	APIVersion        string                   `json:"apiVersion"`
-	NodeVersion       string                   `json:"nodeVersion"`
-	ServiceName       string                   `json:"serviceName"`
-	BaseGasPrice      string                   `json:"baseGasPrice"`
-	InitialBaseFee    string                   `json:"initialBaseFee"`
-	Expiration        uint32                   `json:"expiration"`
-	NetworkIdentifier *types.NetworkIdentifier `json:"-"`
-	SoloOnDemand      bool                     `json:"soloOnDemand"`
+	MeshVersion         string                    `json:"meshVersion"`
+	Port                int                       `json:"port"`
+	Mode                string                    `json:"mode"`
+	Network             string                    `json:"network"`
+	NodeAPI             string                    `json:"nodeApi"`
+	ChainTag            byte                      `json:"chainTag"`
+	APIVersion          string                    `json:"apiVersion"`
+	NodeVersion         string                    `json:"nodeVersion"`
+	ServiceName         string                    `json:"serviceName"`
+	BaseGasPrice        string                    `json:"baseGasPrice"`
+	InitialBaseFee      string                    `json:"initialBaseFee"`
+	Expiration          uint32                    `json:"expiration"`
+	NetworkIdentifier   *types.NetworkIdentifier  `json:"-"`
+	SoloOnDemand        bool                      `json:"soloOnDemand"`
+	Tokens              map[string][]TokenConfig  `json:"tokens"`
+	TokenDiscovery      bool                      `json:"tokenDiscovery"`
+	TokenCacheSize      int                       `json:"tokenCacheSize"`
+	TokenRegistry       *meshcommon.TokenRegistry `json:"-"`
+	EventsJournalPath   string                    `json:"eventsJournalPath"`
+	EventsJournalStart  string                    `json:"eventsJournalStart"`
+	GasEstimationMargin uint64                    `json:"gasEstimationMargin"`
+	GasPriceCoef        uint8                     `json:"gasPriceCoef"`
+	DelegatorURL        string                    `json:"delegatorUrl"`
+	Sponsor             *SponsorConfig            `json:"sponsor"`
+	MempoolPreview      bool                      `json:"mempoolPreview"`
+	BlockCacheSizeMB    int64                     `json:"blockCacheSizeMB"`
+	SyncStallThreshold  uint32                    `json:"syncStallThreshold"`
+}
+
+// SponsorConfig configures mesh as fee delegator for the transactions matching its policy.
+// Empty lists and zero limits are not enforced.
+type SponsorConfig struct {
+	// PrivateKey is the hex encoded key of the gas payer, better set with SPONSOR_PRIVATE_KEY
+	PrivateKey       string   `json:"privateKey"`
+	AllowedOrigins   []string `json:"allowedOrigins"`
+	AllowedContracts []string `json:"allowedContracts"`
+	MaxGas           uint64   `json:"maxGas"`
+	// DailyBudget is the VTHO, in wei, that can be sponsored per origin and per UTC day
+	DailyBudget string `json:"dailyBudget"`
+}
+
+// TokenConfig describes a supported VIP180 token
+type TokenConfig struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Decimals int32  `json:"decimals"`
 }
 
 // NewConfig creates a new configuration by loading from JSON and environment variables
@@ -69,6 +131,15 @@ func NewConfig() (*Config, error) {
 	// Set derived fields
 	config.setDerivedFields()
 
+	// Build the token registry for the configured network
+	if err := config.setTokenRegistry(); err != nil {
+		return nil, fmt.Errorf("invalid token configuration: %v", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %v", err)
+	}
+
 	return &config, nil
 }
 
@@ -88,6 +159,49 @@ func (c *Config) loadFromEnv() {
 		}
 	}
 
+	if eventsJournalPath := os.Getenv("EVENTS_JOURNAL_PATH"); eventsJournalPath != "" {
+		c.EventsJournalPath = eventsJournalPath
+	}
+
+	if eventsJournalStart := os.Getenv("EVENTS_JOURNAL_START"); eventsJournalStart != "" {
+		c.EventsJournalStart = eventsJournalStart
+	}
+
+	if tokenDiscovery := os.Getenv("TOKEN_DISCOVERY"); tokenDiscovery != "" {
+		if tokenDiscoveryBool, err := strconv.ParseBool(tokenDiscovery); err == nil {
+			c.TokenDiscovery = tokenDiscoveryBool
+		}
+	}
+
+	if delegatorURL := os.Getenv("DELEGATOR_URL"); delegatorURL != "" {
+		c.DelegatorURL = delegatorURL
+	}
+
+	if sponsorPrivateKey := os.Getenv("SPONSOR_PRIVATE_KEY"); sponsorPrivateKey != "" {
+		if c.Sponsor == nil {
+			c.Sponsor = &SponsorConfig{}
+		}
+		c.Sponsor.PrivateKey = sponsorPrivateKey
+	}
+
+	if mempoolPreview := os.Getenv("MEMPOOL_PREVIEW"); mempoolPreview != "" {
+		if mempoolPreviewBool, err := strconv.ParseBool(mempoolPreview); err == nil {
+			c.MempoolPreview = mempoolPreviewBool
+		}
+	}
+
+	if blockCacheSize := os.Getenv("BLOCK_CACHE_SIZE_MB"); blockCacheSize != "" {
+		if size, err := strconv.ParseInt(blockCacheSize, 10, 64); err == nil {
+			c.BlockCacheSizeMB = size
+		}
+	}
+
+	if syncStallThreshold := os.Getenv("SYNC_STALL_THRESHOLD"); syncStallThreshold != "" {
+		if threshold, err := strconv.ParseUint(syncStallThreshold, 10, 32); err == nil {
+			c.SyncStallThreshold = uint32(threshold)
+		}
+	}
+
 	// TODO: Delete the snippet (will always be true) once Thor is updated again in this regard
 	if soloOnDemand := os.Getenv("SOLO_ONDEMAND"); soloOnDemand != "" {
 		if soloOnDemandBool, err := strconv.ParseBool(soloOnDemand); err == nil {
@@ -99,25 +213,100 @@ func (c *Config) loadFromEnv() {
 // setDerivedFields sets fields that are derived from other configuration values
 func (c *Config) setDerivedFields() {
 	// Set network identifier based on network
-	var networkName string
-	switch c.Network {
-	case meshcommon.MainNetwork, "mainnet":
-		networkName = meshcommon.MainNetwork
-		c.ChainTag = 0x4a // Mainnet chain tag
-	case meshcommon.TestNetwork, "testnet":
-		networkName = meshcommon.TestNetwork
-		c.ChainTag = 0x27 // Testnet chain tag
-	case meshcommon.SoloNetwork:
-		networkName = meshcommon.SoloNetwork
-		c.ChainTag = 0xf6 // Solo chain tag
-	default:
-		networkName = "custom"
+	networkName := "custom"
+	if network, ok := supportedNetworks[c.Network]; ok {
+		networkName = network.name
+		c.ChainTag = network.chainTag
 	}
 
 	c.NetworkIdentifier = &types.NetworkIdentifier{
 		Blockchain: meshcommon.BlockchainName,
 		Network:    networkName,
 	}
+
+	// The block events journal is kept with the node data unless a path is configured
+	if c.EventsJournalPath == "" {
+		c.EventsJournalPath = filepath.Join(meshcommon.DataDirectory, eventsJournalFile)
+	}
+}
+
+// setTokenRegistry builds the token registry from the tokens configured for the network.
+// Only the listed tokens are supported, unless token discovery is enabled to also support
+// the VIP180 tokens found on chain.
+func (c *Config) setTokenRegistry() error {
+	tokens := c.Tokens[c.NetworkIdentifier.Network]
+
+	currencies := make([]*types.Currency, 0, len(tokens))
+	for _, token := range tokens {
+		currencies = append(currencies, &types.Currency{
+			Symbol:   token.Symbol,
+			Decimals: token.Decimals,
+			Metadata: map[string]any{
+				"contractAddress": token.Address,
+			},
+		})
+	}
+
+	registry, err := meshcommon.NewTokenRegistry(currencies, !c.TokenDiscovery, c.TokenCacheSize)
+	if err != nil {
+		return err
+	}
+	c.TokenRegistry = registry
+	return nil
+}
+
+// validate checks the sponsor key, addresses and budget
+func (s *SponsorConfig) validate() error {
+	privateKey, err := hex.DecodeString(strings.TrimPrefix(s.PrivateKey, "0x"))
+	if err != nil || len(privateKey) != 32 {
+		return fmt.Errorf("privateKey must be a 32 bytes hex string")
+	}
+	for _, address := range append(slices.Clone(s.AllowedOrigins), s.AllowedContracts...) {
+		if _, err := thor.ParseAddress(address); err != nil {
+			return fmt.Errorf("invalid address %s: %v", address, err)
+		}
+	}
+	if s.GetDailyBudget() == nil && s.DailyBudget != "" {
+		return fmt.Errorf("invalid dailyBudget: %s", s.DailyBudget)
+	}
+	return nil
+}
+
+// GetDailyBudget returns the daily budget per origin, nil when there is no budget
+func (s *SponsorConfig) GetDailyBudget() *big.Int {
+	budget, ok := new(big.Int).SetString(s.DailyBudget, 10)
+	if !ok || budget.Sign() < 0 {
+		return nil
+	}
+	return budget
+}
+
+// Validate checks the settings the server needs to serve requests and reports the first invalid one.
+// It runs when the configuration is loaded and on every readiness check.
+func (c *Config) Validate() error {
+	if c.Mode != meshcommon.OnlineMode && c.Mode != meshcommon.OfflineMode {
+		return fmt.Errorf("invalid mode: %s", c.Mode)
+	}
+	// Networks other than the supported ones are custom networks, identified by the configured chain tag
+	if c.Network == "" {
+		return fmt.Errorf("network is required")
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid port: %d", c.Port)
+	}
+	switch c.EventsJournalStart {
+	case "", EventsJournalStartGenesis, meshcommon.RevisionFinalized:
+	default:
+		if _, err := strconv.ParseUint(c.EventsJournalStart, 10, 32); err != nil {
+			return fmt.Errorf("invalid eventsJournalStart: %s", c.EventsJournalStart)
+		}
+	}
+	if c.Sponsor != nil {
+		if err := c.Sponsor.validate(); err != nil {
+			return fmt.Errorf("invalid sponsor configuration: %v", err)
+		}
+	}
+	return nil
 }
 
 // IsOnlineMode returns true if running in online mode
@@ -149,6 +338,30 @@ func (c *Config) PrintConfig() {
 	)
 }
 
+// GetBlockCacheBytes returns the memory, in bytes, given to each of the block and block response caches.
+// The configured size is shared equally between them.
+func (c *Config) GetBlockCacheBytes() int64 {
+	return c.BlockCacheSizeMB * 1024 * 1024 / 2
+}
+
+// GetEventsJournalStart returns the revision of the block a new block events journal starts at:
+// the genesis block when none is configured, the finalized block or a block number
+func (c *Config) GetEventsJournalStart() string {
+	if c.EventsJournalStart == "" || c.EventsJournalStart == EventsJournalStartGenesis {
+		return "0"
+	}
+	return c.EventsJournalStart
+}
+
+// GetSyncStallThreshold returns how long the node head can stay the same before the node is reported as stalled.
+// The threshold is configured in seconds, DefaultSyncStallThreshold is used when it is not set.
+func (c *Config) GetSyncStallThreshold() time.Duration {
+	if c.SyncStallThreshold == 0 {
+		return DefaultSyncStallThreshold
+	}
+	return time.Duration(c.SyncStallThreshold) * time.Second
+}
+
 // GetBaseGasPrice returns the base gas price as a big.Int
 func (c *Config) GetBaseGasPrice() *big.Int {
 	if c.BaseGasPrice == "" {
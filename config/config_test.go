@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	meshcommon "github.com/vechain/mesh/common"
 )
@@ -230,6 +231,59 @@ func TestSetDerivedFields(t *testing.T) {
 	}
 }
 
+func TestSetTokenRegistry(t *testing.T) {
+	tokens := map[string][]TokenConfig{
+		meshcommon.MainNetwork: {
+			{Address: "0x1234567890123456789012345678901234567890", Symbol: "USDC", Decimals: 6},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		network       string
+		tokens        map[string][]TokenConfig
+		discovery     bool
+		wantAllowList bool
+		wantTokens    int
+		wantErr       bool
+	}{
+		{name: "configured network", network: meshcommon.MainNetwork, tokens: tokens, wantAllowList: true, wantTokens: 1},
+		{name: "network without tokens", network: meshcommon.TestNetwork, tokens: tokens, wantAllowList: true},
+		{name: "no tokens configured", network: meshcommon.SoloNetwork, wantAllowList: true},
+		{name: "discovery", network: meshcommon.SoloNetwork, discovery: true},
+		{name: "discovery with configured tokens", network: meshcommon.MainNetwork, tokens: tokens, discovery: true, wantTokens: 1},
+		{
+			name:    "spoofed symbol",
+			network: meshcommon.MainNetwork,
+			tokens: map[string][]TokenConfig{
+				meshcommon.MainNetwork: {{Address: "0x1234567890123456789012345678901234567890", Symbol: "VTHO", Decimals: 18}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &Config{Network: tt.network, Tokens: tt.tokens, TokenDiscovery: tt.discovery}
+			config.setDerivedFields()
+
+			err := config.setTokenRegistry()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setTokenRegistry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if config.TokenRegistry.IsAllowList() != tt.wantAllowList {
+				t.Errorf("setTokenRegistry() allow-list = %v, want %v", config.TokenRegistry.IsAllowList(), tt.wantAllowList)
+			}
+			if len(config.TokenRegistry.Currencies()) != tt.wantTokens {
+				t.Errorf("setTokenRegistry() tokens = %v, want %v", len(config.TokenRegistry.Currencies()), tt.wantTokens)
+			}
+		})
+	}
+}
+
 func TestIsOnlineMode(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -569,6 +623,40 @@ func TestGetBaseGasPrice(t *testing.T) {
 	}
 }
 
+func TestSponsorConfigValidate(t *testing.T) {
+	privateKey := strings.Repeat("11", 32)
+	tests := []struct {
+		name    string
+		config  SponsorConfig
+		wantErr bool
+	}{
+		{name: "key only", config: SponsorConfig{PrivateKey: privateKey}},
+		{
+			name: "full policy",
+			config: SponsorConfig{
+				PrivateKey:       "0x" + privateKey,
+				AllowedOrigins:   []string{"0xf077b491b355e64048ce21e3a6fc4751eeea77fa"},
+				AllowedContracts: []string{"0x0000000000000000000000000000456e65726779"},
+				MaxGas:           100000,
+				DailyBudget:      "1000000000000000000",
+			},
+		},
+		{name: "missing key", config: SponsorConfig{}, wantErr: true},
+		{name: "short key", config: SponsorConfig{PrivateKey: "1234"}, wantErr: true},
+		{name: "invalid origin", config: SponsorConfig{PrivateKey: privateKey, AllowedOrigins: []string{"0x12"}}, wantErr: true},
+		{name: "invalid contract", config: SponsorConfig{PrivateKey: privateKey, AllowedContracts: []string{"contract"}}, wantErr: true},
+		{name: "invalid budget", config: SponsorConfig{PrivateKey: privateKey, DailyBudget: "-1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 // TODO: Delete the test once Thor is updated again in this regard
 func TestLoadFromEnv_SoloOnDemand(t *testing.T) {
 	t.Run("sets true when SOLO_ONDEMAND=true", func(t *testing.T) {
@@ -628,3 +716,121 @@ func TestLoadFromEnv_SoloOnDemand(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadFromEnv_TokenDiscovery(t *testing.T) {
+	t.Setenv("TOKEN_DISCOVERY", "true")
+	cfg := &Config{}
+	cfg.loadFromEnv()
+	if !cfg.TokenDiscovery {
+		t.Errorf("TokenDiscovery = false, want true with TOKEN_DISCOVERY=true")
+	}
+}
+
+func TestGetSyncStallThreshold(t *testing.T) {
+	if got := (&Config{}).GetSyncStallThreshold(); got != DefaultSyncStallThreshold {
+		t.Errorf("GetSyncStallThreshold() = %v, want default %v", got, DefaultSyncStallThreshold)
+	}
+
+	t.Setenv("SYNC_STALL_THRESHOLD", "90")
+	cfg := &Config{SyncStallThreshold: 30}
+	cfg.loadFromEnv()
+	if got := cfg.GetSyncStallThreshold(); got != 90*time.Second {
+		t.Errorf("GetSyncStallThreshold() = %v, want 90s", got)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := Config{Mode: meshcommon.OfflineMode, Network: meshcommon.TestNetwork, Port: 8080}
+	tests := []struct {
+		name    string
+		change  func(c *Config)
+		wantErr bool
+	}{
+		{name: "valid", change: func(c *Config) {}},
+		{name: "online solo", change: func(c *Config) { c.Mode, c.Network = meshcommon.OnlineMode, meshcommon.SoloNetwork }},
+		{name: "invalid mode", change: func(c *Config) { c.Mode = "archive" }, wantErr: true},
+		{name: "custom network", change: func(c *Config) { c.Network, c.ChainTag = "custom", 0x99 }},
+		{name: "missing network", change: func(c *Config) { c.Network = "" }, wantErr: true},
+		{name: "invalid port", change: func(c *Config) { c.Port = 0 }, wantErr: true},
+		{name: "finalized journal start", change: func(c *Config) { c.EventsJournalStart = meshcommon.RevisionFinalized }},
+		{name: "invalid journal start", change: func(c *Config) { c.EventsJournalStart = "best" }, wantErr: true},
+		{name: "invalid sponsor", change: func(c *Config) { c.Sponsor = &SponsorConfig{} }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.change(&config)
+			if err := config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetEventsJournalStart(t *testing.T) {
+	tests := map[string]string{
+		"":                           "0",
+		EventsJournalStartGenesis:    "0",
+		meshcommon.RevisionFinalized: meshcommon.RevisionFinalized,
+		"1000":                       "1000",
+	}
+	for start, want := range tests {
+		config := &Config{EventsJournalStart: start}
+		if got := config.GetEventsJournalStart(); got != want {
+			t.Errorf("GetEventsJournalStart() with %q = %s, want %s", start, got, want)
+		}
+	}
+}
+
+func TestSetDerivedFields_EventsJournalPath(t *testing.T) {
+	config := &Config{Network: meshcommon.TestNetwork}
+	config.setDerivedFields()
+	if want := filepath.Join(meshcommon.DataDirectory, "events.journal"); config.EventsJournalPath != want {
+		t.Errorf("setDerivedFields() EventsJournalPath = %s, want %s", config.EventsJournalPath, want)
+	}
+
+	config = &Config{Network: meshcommon.TestNetwork, EventsJournalPath: "/var/lib/mesh/events.journal"}
+	config.setDerivedFields()
+	if config.EventsJournalPath != "/var/lib/mesh/events.journal" {
+		t.Errorf("setDerivedFields() EventsJournalPath = %s, want the configured path", config.EventsJournalPath)
+	}
+}
+
+func TestNewConfig_RejectsInvalidConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	cfgDir := filepath.Join(tempDir, "config")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatalf("failed to create temp config dir: %v", err)
+	}
+	jsonContent := `{"port": 8080, "mode": "archive", "network": "test"}`
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.json"), []byte(jsonContent), 0o644); err != nil {
+		t.Fatalf("failed to write temp config.json: %v", err)
+	}
+	t.Chdir(tempDir)
+
+	if _, err := NewConfig(); err == nil || !strings.Contains(err.Error(), "invalid mode") {
+		t.Errorf("NewConfig() error = %v, want the invalid mode reported", err)
+	}
+}
+
+func TestNewConfig_CustomNetwork(t *testing.T) {
+	tempDir := t.TempDir()
+	cfgDir := filepath.Join(tempDir, "config")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatalf("failed to create temp config dir: %v", err)
+	}
+	jsonContent := `{"port": 8080, "mode": "online", "network": "custom", "chainTag": 153}`
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.json"), []byte(jsonContent), 0o644); err != nil {
+		t.Fatalf("failed to write temp config.json: %v", err)
+	}
+	t.Chdir(tempDir)
+
+	cfg, err := NewConfig()
+	if err != nil {
+		t.Fatalf("NewConfig() error = %v", err)
+	}
+	if cfg.NetworkIdentifier.Network != "custom" || cfg.ChainTag != 153 {
+		t.Errorf("NewConfig() network = %v, chain tag = %d, want the custom network and its chain tag", cfg.NetworkIdentifier, cfg.ChainTag)
+	}
+}
@@ -27,6 +27,9 @@ func main() {
 	}
 
 	meshServer := createMeshServer(cfg)
+	if thorServer != nil {
+		meshServer.MonitorThorProcess(thorServer)
+	}
 	startServer(meshServer)
 	printEndpoints(meshServer)
 	waitForShutdown(meshServer)
@@ -106,6 +109,7 @@ func createAsserter(cfg *meshconfig.Config) (*asserter.Asserter, error) {
 		meshcommon.OperationTypeFee,
 		meshcommon.OperationTypeFeeDelegation,
 		meshcommon.OperationTypeContractCall,
+		meshcommon.OperationTypeContractDeploy,
 	}
 
 	supportedNetworks := []*types.NetworkIdentifier{cfg.NetworkIdentifier}
@@ -131,7 +135,7 @@ func createAsserter(cfg *meshconfig.Config) (*asserter.Asserter, error) {
 		supportedOperationTypes,
 		cfg.Mode == meshcommon.OnlineMode, // historical balance lookup
 		supportedNetworks,
-		[]string{meshcommon.CallMethodInspectClauses},
+		[]string{meshcommon.CallMethodInspectClauses, meshcommon.CallMethodSponsorTransaction, meshcommon.CallMethodTransactionStatus},
 		false,
 		"",
 	)
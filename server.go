@@ -11,6 +11,7 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/server"
 
 	meshcommon "github.com/vechain/mesh/common"
+	meshmetrics "github.com/vechain/mesh/common/metrics"
 	meshconfig "github.com/vechain/mesh/config"
 	"github.com/vechain/mesh/services"
 	meshthor "github.com/vechain/mesh/thor"
@@ -18,24 +19,49 @@ import (
 
 // VeChainMeshServer implements the Mesh API for VeChain
 type VeChainMeshServer struct {
-	server   *http.Server
-	asserter *asserter.Asserter
-	config   *meshconfig.Config
+	server        *http.Server
+	asserter      *asserter.Asserter
+	config        *meshconfig.Config
+	vechainClient meshthor.VeChainClientInterface
+	tracker       *services.TxTracker
+	blockJournal  *services.BlockJournal
+	health        *services.HealthService
+	// backgroundCtx is cancelled when the server stops, ending the tracking of submitted transactions,
+	// the sync of the block events journal and the updates of the block height metrics
+	backgroundCtx  context.Context
+	stopBackground context.CancelFunc
 }
 
 // NewVeChainMeshServer creates a new server instance
 func NewVeChainMeshServer(cfg *meshconfig.Config, asrt *asserter.Asserter) (*VeChainMeshServer, error) {
-	vechainClient := meshthor.NewVeChainClient(cfg.NodeAPI)
+	// Finalized blocks are served from memory, to every service, only the calls reaching the node are timed
+	vechainClient := meshthor.NewCachingVeChainClient(
+		meshthor.NewInstrumentedVeChainClient(meshthor.NewVeChainClient(cfg.NodeAPI)),
+		cfg.GetBlockCacheBytes(),
+	)
 
 	// Initialize services
 	networkService := services.NewNetworkService(vechainClient, cfg)
-	accountService := services.NewAccountService(vechainClient)
-	constructionService := services.NewConstructionService(vechainClient, cfg)
-	blockService := services.NewBlockService(vechainClient)
-	mempoolService := services.NewMempoolService(vechainClient)
-	eventsService := services.NewEventsService(vechainClient)
-	searchService := services.NewSearchService(vechainClient)
-	callService := services.NewCallService(vechainClient, cfg)
+	accountService := services.NewAccountService(vechainClient, cfg)
+	tracker := services.NewTxTracker(vechainClient)
+	constructionService := services.NewConstructionService(vechainClient, cfg, tracker)
+	blockService := services.NewBlockService(vechainClient, cfg)
+	mempoolService := services.NewMempoolService(vechainClient, cfg)
+	blockJournal, err := services.NewBlockJournal(vechainClient, cfg.EventsJournalPath, cfg.GetEventsJournalStart())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open block events journal: %w", err)
+	}
+	eventsService := services.NewEventsService(vechainClient, blockJournal)
+	searchService := services.NewSearchService(vechainClient, cfg)
+	var sponsor *services.Sponsor
+	if cfg.Sponsor != nil {
+		sponsor, err = services.NewSponsor(cfg.Sponsor, cfg.GetBaseGasPrice())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sponsor: %w", err)
+		}
+	}
+	callService := services.NewCallService(vechainClient, cfg, sponsor, tracker)
+	healthService := services.NewHealthService(vechainClient, cfg, networkService.SyncMonitor())
 
 	// Create API controllers
 	networkController := server.NewNetworkAPIController(networkService, asrt)
@@ -59,30 +85,41 @@ func NewVeChainMeshServer(cfg *meshconfig.Config, asrt *asserter.Asserter) (*VeC
 		callController,
 	)
 
-	// Create a custom mux to add health endpoint
+	// Create a custom mux to add the health endpoints, /health is kept as an alias of the liveness endpoint
 	mux := http.NewServeMux()
-	mux.HandleFunc(meshcommon.HealthEndpoint, func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte(`{"status":"ok"}`))
-	})
+	mux.Handle(meshcommon.HealthEndpoint, services.LivenessHandler())
+	mux.Handle("GET "+meshcommon.LivenessEndpoint, services.LivenessHandler())
+	mux.Handle("GET "+meshcommon.ReadinessEndpoint, services.ReadinessHandler(healthService))
+
+	// Network status carries the finality checkpoints, which the SDK controller cannot return
+	mux.Handle("POST "+meshcommon.NetworkStatusEndpoint, services.NetworkStatusHandler(networkService, asrt))
+
+	mux.Handle("GET "+meshcommon.MetricsEndpoint, meshmetrics.Handler())
 
 	// All other routes go through the Mesh router and middleware
 	mux.Handle("/", router)
 
-	// Apply middleware stack: offline mode validation, logging, and CORS
+	// Apply middleware stack: offline mode validation, logging, CORS and metrics
 	offlineRouter := services.OfflineModeMiddleware(cfg)(mux)
 	loggedRouter := server.LoggerMiddleware(offlineRouter)
 	corsRouter := server.CorsMiddleware(loggedRouter)
+	metricsRouter := meshmetrics.Middleware(corsRouter)
 
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
 	meshServer := &VeChainMeshServer{
 		server: &http.Server{
 			Addr:        fmt.Sprintf(":%d", cfg.Port),
-			Handler:     corsRouter,
+			Handler:     metricsRouter,
 			ReadTimeout: 30 * time.Second,
 		},
-		asserter: asrt,
-		config:   cfg,
+		asserter:       asrt,
+		config:         cfg,
+		vechainClient:  vechainClient,
+		tracker:        tracker,
+		blockJournal:   blockJournal,
+		health:         healthService,
+		backgroundCtx:  backgroundCtx,
+		stopBackground: stopBackground,
 	}
 
 	cfg.PrintConfig()
@@ -93,20 +130,36 @@ func NewVeChainMeshServer(cfg *meshconfig.Config, asrt *asserter.Asserter) (*VeC
 // Start starts the server
 func (v *VeChainMeshServer) Start() error {
 	log.Printf("Starting VeChain Mesh API server on port %s", v.server.Addr)
+	go v.tracker.Run(v.backgroundCtx, services.TxTrackerInterval)
+	if v.config.Mode != meshcommon.OfflineMode {
+		go v.blockJournal.Run(v.backgroundCtx, services.BlockJournalInterval)
+		go meshthor.WatchBlockHeights(v.backgroundCtx, v.vechainClient, meshthor.BlockHeightsInterval)
+	}
+
 	return v.server.ListenAndServe()
 }
 
 // Stop stops the server
 func (v *VeChainMeshServer) Stop(ctx context.Context) error {
 	log.Println("Stopping VeChain Mesh API server...")
+	v.stopBackground()
 	return v.server.Shutdown(ctx)
 }
 
+// MonitorThorProcess makes the readiness of the server depend on the Thor child process,
+// it must be called before the server starts
+func (v *VeChainMeshServer) MonitorThorProcess(process services.ThorProcess) {
+	v.health.SetThorProcess(process)
+}
+
 // GetEndpoints returns a list of all registered endpoints
 func (v *VeChainMeshServer) GetEndpoints() ([]string, error) {
 	// Return standard Mesh API endpoints
 	endpoints := []string{
 		fmt.Sprintf("GET %s", meshcommon.HealthEndpoint),
+		fmt.Sprintf("GET %s", meshcommon.LivenessEndpoint),
+		fmt.Sprintf("GET %s", meshcommon.ReadinessEndpoint),
+		fmt.Sprintf("GET %s", meshcommon.MetricsEndpoint),
 		fmt.Sprintf("POST %s", meshcommon.NetworkListEndpoint),
 		fmt.Sprintf("POST %s", meshcommon.NetworkOptionsEndpoint),
 		fmt.Sprintf("POST %s", meshcommon.NetworkStatusEndpoint),
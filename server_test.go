@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ func createTestAsserter() (*asserter.Asserter, error) {
 		meshcommon.OperationTypeFee,
 		meshcommon.OperationTypeFeeDelegation,
 		meshcommon.OperationTypeContractCall,
+		meshcommon.OperationTypeContractDeploy,
 	}
 
 	return asserter.NewServer(
@@ -255,6 +257,9 @@ func TestVeChainMeshServer_GetEndpoints(t *testing.T) {
 	// Check for specific expected endpoints
 	expectedEndpoints := map[string]bool{
 		"GET /health":                   false,
+		"GET /health/live":              false,
+		"GET /health/ready":             false,
+		"GET /metrics":                  false,
 		"POST /network/list":            false,
 		"POST /network/status":          false,
 		"POST /network/options":         false,
@@ -388,3 +393,72 @@ func TestVeChainMeshServer_HealthEndpoint(t *testing.T) {
 		t.Errorf("health endpoint response = %v, want {\"status\":\"ok\"}", response)
 	}
 }
+
+func TestVeChainMeshServer_MetricsEndpoint(t *testing.T) {
+	config := &meshconfig.Config{
+		NodeAPI: "http://localhost:8669",
+		Network: meshcommon.TestNetwork,
+		Mode:    meshcommon.OfflineMode,
+		Port:    8080,
+	}
+
+	asrt, err := createTestAsserter()
+	if err != nil {
+		t.Fatalf("Failed to create asserter: %v", err)
+	}
+
+	server, err := NewVeChainMeshServer(config, asrt)
+	if err != nil {
+		t.Fatalf("NewVeChainMeshServer() error = %v", err)
+	}
+
+	// Served requests are counted by endpoint
+	server.server.Handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, meshcommon.HealthEndpoint, nil))
+
+	req := httptest.NewRequest(http.MethodGet, meshcommon.MetricsEndpoint, nil)
+	w := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("metrics endpoint status code = %v, want %v", w.Code, http.StatusOK)
+	}
+	if want := `mesh_http_requests_total{endpoint="/health",status="200"}`; !strings.Contains(w.Body.String(), want) {
+		t.Errorf("metrics endpoint body is missing %s", want)
+	}
+}
+
+func TestVeChainMeshServer_ReadinessEndpoint(t *testing.T) {
+	config := &meshconfig.Config{
+		NodeAPI: "http://localhost:8669",
+		Network: meshcommon.TestNetwork,
+		Mode:    meshcommon.OfflineMode,
+		Port:    8080,
+	}
+
+	asrt, err := createTestAsserter()
+	if err != nil {
+		t.Fatalf("Failed to create asserter: %v", err)
+	}
+
+	server, err := NewVeChainMeshServer(config, asrt)
+	if err != nil {
+		t.Fatalf("NewVeChainMeshServer() error = %v", err)
+	}
+
+	// Offline, the server is ready as soon as its configuration is valid
+	req := httptest.NewRequest(http.MethodGet, meshcommon.ReadinessEndpoint, nil)
+	w := httptest.NewRecorder()
+	server.server.Handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("readiness endpoint status code = %v, want %v, body: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var response map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal readiness response: %v", err)
+	}
+	if response["status"] != "ready" {
+		t.Errorf("readiness endpoint response = %v, want a ready status", response)
+	}
+}
@@ -8,18 +8,21 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 	meshcommon "github.com/vechain/mesh/common"
 	"github.com/vechain/mesh/common/vip180"
+	meshconfig "github.com/vechain/mesh/config"
 	meshthor "github.com/vechain/mesh/thor"
 )
 
 // AccountService handles account-related endpoints
 type AccountService struct {
 	vechainClient meshthor.VeChainClientInterface
+	config        *meshconfig.Config
 }
 
 // NewAccountService creates a new account service
-func NewAccountService(vechainClient meshthor.VeChainClientInterface) *AccountService {
+func NewAccountService(vechainClient meshthor.VeChainClientInterface, config *meshconfig.Config) *AccountService {
 	return &AccountService{
 		vechainClient: vechainClient,
+		config:        config,
 	}
 }
 
@@ -35,9 +38,16 @@ func (a *AccountService) AccountBalance(
 				"error": err.Error(),
 			})
 		}
+		for _, currency := range req.Currencies {
+			if err := a.config.TokenRegistry.ValidateCurrency(currency); err != nil {
+				return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrUnsupportedToken, map[string]any{
+					"error": err.Error(),
+				})
+			}
+		}
 	}
 
-	// Determine revision from request or use "best"
+	// Determine revision from request or use "best", the hash can also be the finalized or justified revision
 	revision := "best"
 	if req.BlockIdentifier != nil {
 		if req.BlockIdentifier.Hash != nil && *req.BlockIdentifier.Hash != "" {
@@ -82,6 +92,9 @@ func (a *AccountService) AccountBalance(
 			Hash:  block.ID.String(),
 		},
 		Balances: balances,
+		Metadata: map[string]any{
+			"finalized": block.IsFinalized,
+		},
 	}, nil
 }
 
@@ -124,11 +137,11 @@ func (a *AccountService) validateCurrencies(currencies []*types.Currency) error
 // getCurrenciesToQuery determines which currencies to query based on request
 func (a *AccountService) getCurrenciesToQuery(requestCurrencies []*types.Currency) []*types.Currency {
 	if len(requestCurrencies) == 0 {
-		// Default: return VET and VTHO
-		return []*types.Currency{
+		// Default: return VET, VTHO and the registered tokens
+		return append([]*types.Currency{
 			meshcommon.VETCurrency,
 			meshcommon.VTHOCurrency,
-		}
+		}, a.config.TokenRegistry.Currencies()...)
 	}
 	return requestCurrencies
 }
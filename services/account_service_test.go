@@ -8,6 +8,7 @@ import (
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/ethereum/go-ethereum/common/math"
 	meshcommon "github.com/vechain/mesh/common"
+	meshconfig "github.com/vechain/mesh/config"
 	meshtests "github.com/vechain/mesh/tests"
 	meshthor "github.com/vechain/mesh/thor"
 	"github.com/vechain/thor/v2/api"
@@ -16,7 +17,7 @@ import (
 func TestNewAccountService(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
 
-	service := NewAccountService(mockClient)
+	service := NewAccountService(mockClient, &meshconfig.Config{})
 
 	if service == nil {
 		t.Fatal("NewAccountService() returned nil")
@@ -59,7 +60,7 @@ func TestAccountService_AccountBalance_ValidRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockClient := meshthor.NewMockVeChainClient()
-			service := NewAccountService(mockClient)
+			service := NewAccountService(mockClient, &meshconfig.Config{})
 
 			request := &types.AccountBalanceRequest{
 				NetworkIdentifier: &types.NetworkIdentifier{
@@ -214,7 +215,7 @@ func TestAccountService_AccountBalance_WithCurrenciesAndBlocks(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockClient := meshthor.NewMockVeChainClient()
-			service := NewAccountService(mockClient)
+			service := NewAccountService(mockClient, &meshconfig.Config{})
 
 			request := &types.AccountBalanceRequest{
 				NetworkIdentifier: &types.NetworkIdentifier{
@@ -244,7 +245,7 @@ func TestAccountService_AccountBalance_WithCurrenciesAndBlocks(t *testing.T) {
 
 func TestAccountService_AccountBalance_WithVIP180Token_Success(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewAccountService(mockClient)
+	service := NewAccountService(mockClient, &meshconfig.Config{})
 
 	// Set up mock to return a valid balance
 	mockClient.SetMockCallResult("0x0000000000000000000000000000000000000000000000000de0b6b3a7640000") // 1000000000000000000 (1 token)
@@ -291,7 +292,7 @@ func TestAccountService_AccountBalance_WithVIP180Token_Success(t *testing.T) {
 
 func TestAccountService_getVETBalance(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewAccountService(mockClient)
+	service := NewAccountService(mockClient, &meshconfig.Config{})
 
 	t.Run("Successful VET balance retrieval", func(t *testing.T) {
 		// Set up mock account with VET balance
@@ -362,7 +363,7 @@ func TestAccountService_AccountBalance_WithBlockIdentifiers(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockClient := meshthor.NewMockVeChainClient()
-			service := NewAccountService(mockClient)
+			service := NewAccountService(mockClient, &meshconfig.Config{})
 
 			request := &types.AccountBalanceRequest{
 				NetworkIdentifier: &types.NetworkIdentifier{
@@ -440,7 +441,7 @@ func TestAccountService_AccountBalance_ErrorCases(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockClient := meshthor.NewMockVeChainClient()
 			tt.setupMock(mockClient)
-			service := NewAccountService(mockClient)
+			service := NewAccountService(mockClient, &meshconfig.Config{})
 
 			request := &types.AccountBalanceRequest{
 				NetworkIdentifier: &types.NetworkIdentifier{
@@ -466,7 +467,7 @@ func TestAccountService_AccountBalance_ErrorCases(t *testing.T) {
 
 func TestAccountService_AccountBalance_InvalidContractAddressType(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewAccountService(mockClient)
+	service := NewAccountService(mockClient, &meshconfig.Config{})
 
 	request := &types.AccountBalanceRequest{
 		NetworkIdentifier: &types.NetworkIdentifier{
@@ -494,3 +495,72 @@ func TestAccountService_AccountBalance_InvalidContractAddressType(t *testing.T)
 		t.Error("AccountBalance() expected error for invalid contractAddress type")
 	}
 }
+
+func TestAccountService_AccountBalance_TokenRegistry(t *testing.T) {
+	registry, err := meshcommon.NewTokenRegistry([]*types.Currency{{
+		Symbol:   "USDC",
+		Decimals: 6,
+		Metadata: map[string]any{"contractAddress": "0x1234567890123456789012345678901234567890"},
+	}}, true, 0)
+	if err != nil {
+		t.Fatalf("NewTokenRegistry() error = %v", err)
+	}
+	mockClient := meshthor.NewMockVeChainClient()
+	service := NewAccountService(mockClient, &meshconfig.Config{TokenRegistry: registry})
+	mockClient.SetMockCallResult("0x00000000000000000000000000000000000000000000000000000000000f4240") // 1000000
+
+	request := &types.AccountBalanceRequest{
+		NetworkIdentifier: &types.NetworkIdentifier{
+			Blockchain: meshcommon.BlockchainName,
+			Network:    meshcommon.TestNetwork,
+		},
+		AccountIdentifier: &types.AccountIdentifier{
+			Address: meshtests.FirstSoloAddress,
+		},
+	}
+
+	// Registered tokens are queried by default
+	response, errResp := service.AccountBalance(context.Background(), request)
+	if errResp != nil {
+		t.Fatalf("AccountBalance() error = %v", errResp)
+	}
+	if len(response.Balances) != 3 {
+		t.Fatalf("AccountBalance() balances count = %v, want 3", len(response.Balances))
+	}
+	if response.Balances[2].Currency.Symbol != "USDC" || response.Balances[2].Value != "1000000" {
+		t.Errorf("AccountBalance() token balance = %v %v, want 1000000 USDC", response.Balances[2].Value, response.Balances[2].Currency.Symbol)
+	}
+
+	// Spoofed tokens reusing a known symbol are rejected
+	for _, symbol := range []string{"USDC", "VTHO"} {
+		request.Currencies = []*types.Currency{{
+			Symbol:   symbol,
+			Decimals: 18,
+			Metadata: map[string]any{"contractAddress": "0x0000000000000000000000000000000000000001"},
+		}}
+		_, errResp = service.AccountBalance(context.Background(), request)
+		if errResp == nil || errResp.Code != meshcommon.ErrUnsupportedToken {
+			t.Errorf("AccountBalance() with spoofed %s error = %v, want ErrUnsupportedToken", symbol, errResp)
+		}
+	}
+}
+
+func TestAccountService_AccountBalance_Finalized(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	service := NewAccountService(mockClient, &meshconfig.Config{})
+	mockClient.MockBlock.IsFinalized = true
+	revision := meshcommon.RevisionFinalized
+
+	response, err := service.AccountBalance(context.Background(), &types.AccountBalanceRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		AccountIdentifier: &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+		BlockIdentifier:   &types.PartialBlockIdentifier{Hash: &revision},
+	})
+	if err != nil {
+		t.Fatalf("AccountBalance() error = %v", err)
+	}
+	// The response identifies the finalized block rather than the revision name
+	if response.BlockIdentifier.Hash != mockClient.MockBlock.ID.String() || response.Metadata["finalized"] != true {
+		t.Errorf("AccountBalance() block = %v, metadata = %v", response.BlockIdentifier, response.Metadata)
+	}
+}
@@ -0,0 +1,663 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/thor"
+)
+
+const (
+	// journalRecordSize is the size of an encoded journal record: type, block number, block ID and parent ID
+	journalRecordSize = 1 + 4 + 32 + 32
+
+	// journalTailSize is the number of canonical blocks kept in memory to unwind reorgs
+	journalTailSize = 1024
+
+	// journalHeaderSize is the size of the encoded journal header: magic, genesis ID and start block number
+	journalHeaderSize = 4 + 32 + 4
+
+	// journalSyncBatch bounds the number of blocks fetched from the node while holding the journal lock
+	journalSyncBatch = 1000
+
+	// journalRequestSyncBatch bounds the number of blocks fetched from the node by a request,
+	// catching up with the node is left to the background sync
+	journalRequestSyncBatch = 100
+
+	// BlockJournalInterval is the time between two background syncs of the journal, about one block
+	BlockJournalInterval = 10 * time.Second
+
+	// journalFetchWorkers bounds the number of block headers fetched concurrently from the node
+	journalFetchWorkers = 8
+
+	journalRecordAdded   byte = 0
+	journalRecordRemoved byte = 1
+)
+
+// journalMagic starts every journal file
+var journalMagic = []byte("MBJ1")
+
+// ErrJournalNetworkMismatch is returned when the journal was built from another chain than the node's
+var ErrJournalNetworkMismatch = errors.New("block events journal belongs to another network")
+
+// journalHeader identifies the chain a journal was built from and the block its first event is for
+type journalHeader struct {
+	genesisID thor.Bytes32
+	start     uint32
+}
+
+// journalRecord is a block event stored in the journal, its sequence is its position in the journal
+type journalRecord struct {
+	removed  bool
+	number   uint32
+	id       thor.Bytes32
+	parentID thor.Bytes32
+}
+
+// journalStore persists journal records in sequence order
+type journalStore interface {
+	// Header returns the journal header, false when none was stored yet
+	Header() (journalHeader, bool, error)
+	// SetHeader stores the header of an empty journal
+	SetHeader(header journalHeader) error
+	// Len returns the number of stored records
+	Len() (int64, error)
+	// Read returns up to count records starting at sequence
+	Read(sequence, count int64) ([]journalRecord, error)
+	// Append stores records at the end of the journal
+	Append(records []journalRecord) error
+}
+
+// BlockJournal tracks the canonical chain and records a block_added event for each block that joins it
+// and a block_removed event for each block that leaves it on a reorganization.
+// Sequences are the positions of the events in the journal, so they are monotonically increasing and
+// never reused. The journal starts at a configured block, the first event is the addition of that block,
+// and without reorgs the sequence of a block_added event is its distance to the start block.
+// The journal records the genesis block of its chain and refuses to serve a node of another chain.
+type BlockJournal struct {
+	mu            sync.Mutex
+	vechainClient meshthor.VeChainClientInterface
+	store         journalStore
+	length        int64
+	// start is the revision of the first journaled block, resolved when the journal is created
+	start string
+	// prepared is set once the journal is checked against the chain of the node
+	prepared    bool
+	startNumber int64
+	// headNumber is the number of the canonical head, startNumber-1 when the journal holds no block
+	headNumber int64
+	// tail holds the most recent canonical blocks, head last
+	tail []journalRecord
+}
+
+// NewBlockJournal creates a block journal persisted at path, or kept in memory when path is empty.
+// A new journal starts at the block of the start revision, such as "0" or "finalized".
+func NewBlockJournal(vechainClient meshthor.VeChainClientInterface, path, start string) (*BlockJournal, error) {
+	var store journalStore = &memoryJournalStore{}
+	if path != "" {
+		fileStore, err := newFileJournalStore(path)
+		if err != nil {
+			return nil, err
+		}
+		store = fileStore
+	}
+
+	journal := &BlockJournal{
+		vechainClient: vechainClient,
+		store:         store,
+		start:         start,
+		headNumber:    -1,
+	}
+	if err := journal.load(); err != nil {
+		return nil, err
+	}
+	return journal, nil
+}
+
+// load restores the journal start, length and canonical head from the store
+func (j *BlockJournal) load() error {
+	header, ok, err := j.store.Header()
+	if err != nil {
+		return err
+	}
+	if ok {
+		j.startNumber = int64(header.start)
+		j.headNumber = j.startNumber - 1
+	}
+
+	length, err := j.store.Len()
+	if err != nil {
+		return err
+	}
+	j.length = length
+	if err := j.loadTail(); err != nil {
+		return err
+	}
+	if len(j.tail) > 0 {
+		j.headNumber = int64(j.tail[len(j.tail)-1].number)
+	}
+	return nil
+}
+
+// loadTail rebuilds the in-memory tail of the canonical chain by scanning the journal backwards.
+// Every block_removed event cancels the closest block_added event preceding it that is not yet cancelled.
+func (j *BlockJournal) loadTail() error {
+	var tail []journalRecord
+	pending := 0
+	for end := j.length; end > 0 && len(tail) < journalTailSize; {
+		start := max(end-journalSyncBatch, 0)
+		records, err := j.store.Read(start, end-start)
+		if err != nil {
+			return err
+		}
+		for i := len(records) - 1; i >= 0 && len(tail) < journalTailSize; i-- {
+			switch {
+			case records[i].removed:
+				pending++
+			case pending > 0:
+				pending--
+			default:
+				tail = append(tail, records[i])
+			}
+		}
+		end = start
+	}
+
+	// Reverse to keep the head last
+	for i, k := 0, len(tail)-1; i < k; i, k = i+1, k-1 {
+		tail[i], tail[k] = tail[k], tail[i]
+	}
+	j.tail = tail
+	return nil
+}
+
+// Events syncs the journal with the node and returns up to limit events starting at offset,
+// along with the maximum sequence known or expected from the current best block.
+// A request journals at most journalRequestSyncBatch blocks, events beyond are served once the
+// background sync has caught up with them.
+func (j *BlockJournal) Events(offset, limit int64) ([]*types.BlockEvent, int64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	bestNumber, err := j.bestNumber()
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := j.sync(bestNumber, offset+limit, journalRequestSyncBatch); err != nil {
+		return nil, 0, err
+	}
+
+	maxSequence := max(j.length-1, j.length-1+bestNumber-j.headNumber)
+	if offset >= j.length {
+		return []*types.BlockEvent{}, maxSequence, nil
+	}
+
+	records, err := j.store.Read(offset, min(limit, j.length-offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	events := make([]*types.BlockEvent, 0, len(records))
+	for i, record := range records {
+		eventType := types.ADDED
+		if record.removed {
+			eventType = types.REMOVED
+		}
+		events = append(events, &types.BlockEvent{
+			Sequence: offset + int64(i),
+			BlockIdentifier: &types.BlockIdentifier{
+				Index: int64(record.number),
+				Hash:  record.id.String(),
+			},
+			Type: eventType,
+		})
+	}
+	return events, maxSequence, nil
+}
+
+// Run syncs the journal with the node every interval until the context is done,
+// so that requests find the journal caught up with the best block
+func (j *BlockJournal) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.catchUp(ctx); err != nil {
+			log.Printf("Failed to sync block events journal: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// catchUp syncs the journal up to the best block, releasing the lock between batches so that
+// requests are served while a long catch-up is in progress
+func (j *BlockJournal) catchUp(ctx context.Context) error {
+	for ctx.Err() == nil {
+		j.mu.Lock()
+		bestNumber, err := j.bestNumber()
+		if err == nil {
+			err = j.sync(bestNumber, math.MaxInt64, journalSyncBatch)
+		}
+		caughtUp := j.headNumber >= bestNumber
+		j.mu.Unlock()
+
+		if err != nil || caughtUp {
+			return err
+		}
+	}
+	return nil
+}
+
+// bestNumber returns the number of the best block of the node
+func (j *BlockJournal) bestNumber() (int64, error) {
+	bestBlock, err := j.vechainClient.GetBlockHeader("best")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get best block: %w", err)
+	}
+	return int64(bestBlock.Number), nil
+}
+
+// prepare checks once that the journal was built from the chain of the node.
+// A new journal records the genesis block of the chain and the block it starts at.
+func (j *BlockJournal) prepare() error {
+	if j.prepared {
+		return nil
+	}
+
+	genesis, err := j.vechainClient.GetBlockHeader("0")
+	if err != nil {
+		return fmt.Errorf("failed to get genesis block: %w", err)
+	}
+	header, ok, err := j.store.Header()
+	if err != nil {
+		return err
+	}
+	if ok {
+		if header.genesisID != genesis.ID {
+			return fmt.Errorf("%w: journal of genesis %s, node of genesis %s", ErrJournalNetworkMismatch, header.genesisID, genesis.ID)
+		}
+		j.prepared = true
+		return nil
+	}
+
+	start, err := j.vechainClient.GetBlockHeader(j.start)
+	if err != nil {
+		return fmt.Errorf("failed to get start block %s: %w", j.start, err)
+	}
+	if err := j.store.SetHeader(journalHeader{genesisID: genesis.ID, start: start.Number}); err != nil {
+		return err
+	}
+	j.startNumber = int64(start.Number)
+	j.headNumber = j.startNumber - 1
+	j.prepared = true
+	return nil
+}
+
+// sync unwinds blocks that are no longer canonical and appends the following canonical blocks
+// until the journal holds target events, the best block is reached or batch blocks were fetched.
+// Block headers are fetched concurrently, a block missing from the node fails the sync without leaving gaps.
+func (j *BlockJournal) sync(bestNumber, target, batch int64) error {
+	if err := j.prepare(); err != nil {
+		return err
+	}
+	if err := j.unwind(bestNumber); err != nil {
+		return err
+	}
+
+	for fetched := int64(0); j.length < target && j.headNumber < bestNumber && fetched < batch; {
+		count := min(target-j.length, bestNumber-j.headNumber, batch-fetched)
+		headers, fetchErr := j.fetchHeaders(j.headNumber+1, count)
+		fetched += count
+
+		reorganized, err := j.extend(headers)
+		if err != nil {
+			return err
+		}
+		if reorganized {
+			continue
+		}
+		if fetchErr != nil {
+			return fetchErr
+		}
+	}
+	return nil
+}
+
+// extend records the fetched headers following the canonical head, in order.
+// When a header does not extend the head, the head was reorganized out while syncing:
+// it is removed and the remaining headers are discarded.
+func (j *BlockJournal) extend(headers []*api.JSONBlockSummary) (bool, error) {
+	var records []journalRecord
+	for _, header := range headers {
+		head, ok, err := j.head()
+		if err != nil {
+			return false, err
+		}
+		if ok && header.ParentID != head.id {
+			if err := j.persist(records); err != nil {
+				return false, err
+			}
+			return true, j.remove()
+		}
+
+		record := journalRecord{number: header.Number, id: header.ID, parentID: header.ParentID}
+		records = append(records, record)
+		j.push(record)
+	}
+	return false, j.persist(records)
+}
+
+// fetchHeaders fetches count consecutive block headers starting at number with a bounded pool of workers.
+// It returns the headers in order up to the first block that could not be fetched, along with its error.
+func (j *BlockJournal) fetchHeaders(number, count int64) ([]*api.JSONBlockSummary, error) {
+	headers := make([]*api.JSONBlockSummary, count)
+	errs := make([]error, count)
+
+	next := make(chan int64)
+	var wg sync.WaitGroup
+	for range min(journalFetchWorkers, count) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				headers[i], errs[i] = j.fetchHeader(number + i)
+			}
+		}()
+	}
+	for i := range count {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return headers[:i], err
+		}
+	}
+	return headers, nil
+}
+
+// fetchHeader fetches the header of the canonical block at number
+func (j *BlockJournal) fetchHeader(number int64) (*api.JSONBlockSummary, error) {
+	header, err := j.vechainClient.GetBlockHeader(strconv.FormatInt(number, 10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", number, err)
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	if int64(header.Number) != number {
+		return nil, fmt.Errorf("block %d returned for number %d", header.Number, number)
+	}
+	return header, nil
+}
+
+// unwind removes the canonical head while it is no longer part of the node's canonical chain
+func (j *BlockJournal) unwind(bestNumber int64) error {
+	for {
+		head, ok, err := j.head()
+		if err != nil || !ok {
+			return err
+		}
+		if int64(head.number) <= bestNumber {
+			header, err := j.vechainClient.GetBlockHeader(strconv.FormatInt(int64(head.number), 10))
+			if err != nil {
+				return fmt.Errorf("failed to get block %d: %w", head.number, err)
+			}
+			if header != nil && header.ID == head.id {
+				return nil
+			}
+		}
+		if err := j.remove(); err != nil {
+			return err
+		}
+	}
+}
+
+// head returns the canonical head, reloading the tail from the store when it has been unwound entirely
+func (j *BlockJournal) head() (journalRecord, bool, error) {
+	if len(j.tail) == 0 && j.headNumber >= j.startNumber {
+		if err := j.loadTail(); err != nil {
+			return journalRecord{}, false, err
+		}
+	}
+	if len(j.tail) == 0 {
+		return journalRecord{}, false, nil
+	}
+	return j.tail[len(j.tail)-1], true, nil
+}
+
+// push makes a block the canonical head, the caller is responsible for persisting its event
+func (j *BlockJournal) push(record journalRecord) {
+	j.tail = append(j.tail, record)
+	if len(j.tail) > journalTailSize {
+		j.tail = j.tail[len(j.tail)-journalTailSize:]
+	}
+	j.headNumber = int64(record.number)
+}
+
+// remove records a block_removed event for the canonical head
+func (j *BlockJournal) remove() error {
+	head := j.tail[len(j.tail)-1]
+	removed := head
+	removed.removed = true
+	if err := j.store.Append([]journalRecord{removed}); err != nil {
+		return err
+	}
+	j.length++
+	j.tail = j.tail[:len(j.tail)-1]
+	j.headNumber = int64(head.number) - 1
+	return nil
+}
+
+// persist persists block_added events already pushed to the tail
+func (j *BlockJournal) persist(records []journalRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	if err := j.store.Append(records); err != nil {
+		return err
+	}
+	j.length += int64(len(records))
+	return nil
+}
+
+// memoryJournalStore keeps journal records in memory
+type memoryJournalStore struct {
+	header  *journalHeader
+	records []journalRecord
+}
+
+func (s *memoryJournalStore) Header() (journalHeader, bool, error) {
+	if s.header == nil {
+		return journalHeader{}, false, nil
+	}
+	return *s.header, true, nil
+}
+
+func (s *memoryJournalStore) SetHeader(header journalHeader) error {
+	s.header = &header
+	return nil
+}
+
+func (s *memoryJournalStore) Len() (int64, error) {
+	return int64(len(s.records)), nil
+}
+
+func (s *memoryJournalStore) Read(sequence, count int64) ([]journalRecord, error) {
+	end := min(sequence+count, int64(len(s.records)))
+	if sequence < 0 || sequence > end {
+		return nil, fmt.Errorf("invalid journal range %d-%d", sequence, end)
+	}
+	return append([]journalRecord(nil), s.records[sequence:end]...), nil
+}
+
+func (s *memoryJournalStore) Append(records []journalRecord) error {
+	s.records = append(s.records, records...)
+	return nil
+}
+
+// fileJournalStore keeps journal records in an append-only file of fixed size records following a header
+type fileJournalStore struct {
+	file   *os.File
+	header *journalHeader
+}
+
+// newFileJournalStore opens the journal file at path, dropping a partially written header or last record.
+// Files without a valid header are refused rather than overwritten.
+func newFileJournalStore(path string) (*fileJournalStore, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create journal directory: %w", err)
+		}
+	}
+	file, err := os.OpenFile(filepath.Clean(path), os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to stat journal: %w", err)
+	}
+	store := &fileJournalStore{file: file}
+	size := info.Size()
+	if size >= journalHeaderSize {
+		buf := make([]byte, journalHeaderSize)
+		if _, err := file.ReadAt(buf, 0); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to read journal header: %w", err)
+		}
+		if !bytes.Equal(buf[:len(journalMagic)], journalMagic) {
+			_ = file.Close()
+			return nil, fmt.Errorf("journal %s has no valid header, remove it to rebuild the journal", path)
+		}
+		store.header = &journalHeader{
+			genesisID: thor.BytesToBytes32(buf[4:36]),
+			start:     binary.BigEndian.Uint32(buf[36:40]),
+		}
+	}
+
+	// A journal shorter than a header holds no record, its header was partially written
+	partial := size
+	if store.header != nil {
+		partial = (size - journalHeaderSize) % journalRecordSize
+	}
+	if partial != 0 {
+		if err := file.Truncate(size - partial); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("failed to truncate journal: %w", err)
+		}
+	}
+	return store, nil
+}
+
+func (s *fileJournalStore) Header() (journalHeader, bool, error) {
+	if s.header == nil {
+		return journalHeader{}, false, nil
+	}
+	return *s.header, true, nil
+}
+
+func (s *fileJournalStore) SetHeader(header journalHeader) error {
+	buf := append([]byte(nil), journalMagic...)
+	buf = append(buf, header.genesisID.Bytes()...)
+	buf = binary.BigEndian.AppendUint32(buf, header.start)
+	if _, err := s.file.WriteAt(buf, 0); err != nil {
+		return fmt.Errorf("failed to write journal header: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	s.header = &header
+	return nil
+}
+
+func (s *fileJournalStore) Len() (int64, error) {
+	if s.header == nil {
+		return 0, nil
+	}
+	info, err := s.file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat journal: %w", err)
+	}
+	return (info.Size() - journalHeaderSize) / journalRecordSize, nil
+}
+
+func (s *fileJournalStore) Read(sequence, count int64) ([]journalRecord, error) {
+	buf := make([]byte, count*journalRecordSize)
+	n, err := s.file.ReadAt(buf, journalHeaderSize+sequence*journalRecordSize)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	records := make([]journalRecord, 0, n/journalRecordSize)
+	for offset := 0; offset+journalRecordSize <= n; offset += journalRecordSize {
+		records = append(records, decodeJournalRecord(buf[offset:offset+journalRecordSize]))
+	}
+	return records, nil
+}
+
+func (s *fileJournalStore) Append(records []journalRecord) error {
+	if s.header == nil {
+		return fmt.Errorf("journal header must be written before records")
+	}
+	info, err := s.file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat journal: %w", err)
+	}
+
+	buf := make([]byte, 0, len(records)*journalRecordSize)
+	for _, record := range records {
+		buf = encodeJournalRecord(buf, record)
+	}
+	if _, err := s.file.WriteAt(buf, info.Size()); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	return nil
+}
+
+// encodeJournalRecord appends the binary encoding of a record to buf
+func encodeJournalRecord(buf []byte, record journalRecord) []byte {
+	recordType := journalRecordAdded
+	if record.removed {
+		recordType = journalRecordRemoved
+	}
+	buf = append(buf, recordType)
+	buf = binary.BigEndian.AppendUint32(buf, record.number)
+	buf = append(buf, record.id.Bytes()...)
+	return append(buf, record.parentID.Bytes()...)
+}
+
+// decodeJournalRecord decodes a record encoded by encodeJournalRecord
+func decodeJournalRecord(buf []byte) journalRecord {
+	return journalRecord{
+		removed:  buf[0] == journalRecordRemoved,
+		number:   binary.BigEndian.Uint32(buf[1:5]),
+		id:       thor.BytesToBytes32(buf[5:37]),
+		parentID: thor.BytesToBytes32(buf[37:69]),
+	}
+}
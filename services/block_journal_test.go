@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	meshthor "github.com/vechain/mesh/thor"
+)
+
+func TestBlockJournal_Persistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal", "events.journal")
+	mockClient := meshthor.NewMockVeChainClient()
+	chain := newTestChain(10, nil)
+	mockClient.SetMockBlock(chain[9])
+	mockClient.SetBlocksByNumber(chain)
+
+	journal, err := NewBlockJournal(mockClient, path, "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	if _, _, err := journal.Events(0, 10); err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+
+	// A fork replacing the last two blocks is recorded after the journal is reopened
+	fork := newTestChain(10, chain[:8])
+	mockClient.SetMockBlock(fork[9])
+	mockClient.SetBlocksByNumber(fork)
+
+	reopened, err := NewBlockJournal(mockClient, path, "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	if reopened.length != 10 || reopened.headNumber != 9 {
+		t.Fatalf("Expected 10 events with head 9, got %d events with head %d", reopened.length, reopened.headNumber)
+	}
+
+	events, maxSequence, err := reopened.Events(10, 10)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	if maxSequence != 13 || len(events) != 4 {
+		t.Fatalf("Expected 4 events up to sequence 13, got %d up to %d", len(events), maxSequence)
+	}
+	if events[0].Type != types.REMOVED || events[0].BlockIdentifier.Hash != chain[9].ID.String() {
+		t.Errorf("Expected removal of block 9, got %v", events[0])
+	}
+	if events[3].Type != types.ADDED || events[3].BlockIdentifier.Hash != fork[9].ID.String() {
+		t.Errorf("Expected addition of forked block 9, got %v", events[3])
+	}
+}
+
+func TestBlockJournal_TruncatesPartialRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.journal")
+	mockClient := meshthor.NewMockVeChainClient()
+	chain := newTestChain(3, nil)
+	mockClient.SetMockBlock(chain[2])
+	mockClient.SetBlocksByNumber(chain)
+
+	journal, err := NewBlockJournal(mockClient, path, "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	if _, _, err := journal.Events(0, 3); err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+
+	// Simulate a crash in the middle of a write
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("failed to open journal: %v", err)
+	}
+	if _, err := file.Write([]byte{0, 0, 0}); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+	_ = file.Close()
+
+	reopened, err := NewBlockJournal(mockClient, path, "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	if reopened.length != 3 || reopened.headNumber != 2 {
+		t.Errorf("Expected 3 events with head 2, got %d events with head %d", reopened.length, reopened.headNumber)
+	}
+}
+
+func TestBlockJournal_ShorterFork(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	chain := newTestChain(6, nil)
+	mockClient.SetMockBlock(chain[5])
+	mockClient.SetBlocksByNumber(chain)
+
+	journal, err := NewBlockJournal(mockClient, "", "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	if _, _, err := journal.Events(0, 6); err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+
+	// The best block moves back to a fork of block 4
+	fork := newTestChain(5, chain[:4])
+	mockClient.SetMockBlock(fork[4])
+	mockClient.SetBlocksByNumber(fork)
+
+	events, maxSequence, err := journal.Events(6, 10)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	expected := []types.BlockEventType{types.REMOVED, types.REMOVED, types.ADDED}
+	if len(events) != len(expected) || maxSequence != 8 {
+		t.Fatalf("Expected %d events up to sequence 8, got %d up to %d", len(expected), len(events), maxSequence)
+	}
+	for i, eventType := range expected {
+		if events[i].Type != eventType {
+			t.Errorf("Event %d: expected type %s, got %s", i, eventType, events[i].Type)
+		}
+	}
+	if events[2].BlockIdentifier.Hash != fork[4].ID.String() {
+		t.Errorf("Expected addition of forked block 4, got %v", events[2])
+	}
+}
+
+func TestBlockJournal_ReorgDeeperThanTail(t *testing.T) {
+	length := journalTailSize + 10
+	mockClient := meshthor.NewMockVeChainClient()
+	chain := newTestChain(length, nil)
+	mockClient.SetMockBlock(chain[length-1])
+	mockClient.SetBlocksByNumber(chain)
+
+	journal, err := NewBlockJournal(mockClient, "", "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	for offset := int64(0); offset < int64(length); offset += 100 {
+		if _, _, err := journal.Events(offset, 100); err != nil {
+			t.Fatalf("Events() error = %v", err)
+		}
+	}
+
+	// Every block after the first 5 is replaced
+	fork := newTestChain(length, chain[:5])
+	mockClient.SetMockBlock(fork[length-1])
+	mockClient.SetBlocksByNumber(fork)
+
+	events, _, err := journal.Events(int64(length), int64(length-5))
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	if len(events) != length-5 {
+		t.Fatalf("Expected %d events, got %d", length-5, len(events))
+	}
+	for i, event := range events {
+		if event.Type != types.REMOVED {
+			t.Fatalf("Event %d: expected type %s, got %s", i, types.REMOVED, event.Type)
+		}
+	}
+	if last := events[len(events)-1].BlockIdentifier; last.Index != 5 || last.Hash != chain[5].ID.String() {
+		t.Errorf("Expected removal of block 5 last, got %v", last)
+	}
+	if journal.headNumber != 4 {
+		t.Errorf("Expected head 4, got %d", journal.headNumber)
+	}
+}
+
+func TestBlockJournal_CatchUp(t *testing.T) {
+	length := journalSyncBatch + 10
+	mockClient := meshthor.NewMockVeChainClient()
+	chain := newTestChain(length, nil)
+	mockClient.SetMockBlock(chain[length-1])
+	mockClient.SetBlocksByNumber(chain)
+
+	journal, err := NewBlockJournal(mockClient, "", "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+
+	// A request only journals a bounded number of blocks
+	events, maxSequence, err := journal.Events(int64(length-5), 5)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	if len(events) != 0 || maxSequence != int64(length-1) {
+		t.Errorf("Expected no events up to sequence %d, got %d up to %d", length-1, len(events), maxSequence)
+	}
+	if journal.length != journalRequestSyncBatch {
+		t.Errorf("Expected %d events synced, got %d", journalRequestSyncBatch, journal.length)
+	}
+
+	// The background sync catches up with the best block
+	if err := journal.catchUp(context.Background()); err != nil {
+		t.Fatalf("catchUp() error = %v", err)
+	}
+	events, _, err = journal.Events(int64(length-5), 5)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	if len(events) != 5 || events[4].BlockIdentifier.Hash != chain[length-1].ID.String() {
+		t.Errorf("Expected the last 5 blocks after catching up, got %v", events)
+	}
+}
+
+func TestBlockJournal_StartBlock(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	chain := newTestChain(10, nil)
+	mockClient.SetMockBlock(chain[9])
+	mockClient.SetBlocksByNumber(chain)
+
+	journal, err := NewBlockJournal(mockClient, filepath.Join(t.TempDir(), "events.journal"), "6")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	events, maxSequence, err := journal.Events(0, 10)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	if len(events) != 4 || maxSequence != 3 {
+		t.Fatalf("Expected 4 events up to sequence 3, got %d up to %d", len(events), maxSequence)
+	}
+	if first := events[0].BlockIdentifier; first.Index != 6 || first.Hash != chain[6].ID.String() {
+		t.Errorf("Expected the journal to start at block 6, got %v", first)
+	}
+}
+
+func TestBlockJournal_NetworkMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.journal")
+	mockClient := meshthor.NewMockVeChainClient()
+	chain := newTestChain(3, nil)
+	mockClient.SetMockBlock(chain[2])
+	mockClient.SetBlocksByNumber(chain)
+
+	journal, err := NewBlockJournal(mockClient, path, "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	if _, _, err := journal.Events(0, 3); err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+
+	// The same file opened against a node of another chain
+	other := newTestChain(3, nil)
+	other[0].ID[1] = 0xff
+	otherClient := meshthor.NewMockVeChainClient()
+	otherClient.SetMockBlock(other[2])
+	otherClient.SetBlocksByNumber(other)
+
+	reopened, err := NewBlockJournal(otherClient, path, "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	if _, _, err := reopened.Events(0, 3); !errors.Is(err, ErrJournalNetworkMismatch) {
+		t.Errorf("Events() error = %v, want %v", err, ErrJournalNetworkMismatch)
+	}
+}
+
+func TestBlockJournal_RefusesFileWithoutHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.journal")
+	if err := os.WriteFile(path, make([]byte, journalHeaderSize+journalRecordSize), 0o600); err != nil {
+		t.Fatalf("failed to write journal: %v", err)
+	}
+	if _, err := NewBlockJournal(meshthor.NewMockVeChainClient(), path, "0"); err == nil {
+		t.Error("NewBlockJournal() expected error for a file without header")
+	}
+}
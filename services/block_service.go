@@ -2,12 +2,14 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	meshcommon "github.com/vechain/mesh/common"
 	meshtx "github.com/vechain/mesh/common/tx"
+	meshconfig "github.com/vechain/mesh/config"
 	meshthor "github.com/vechain/mesh/thor"
 	"github.com/vechain/thor/v2/api"
 )
@@ -17,14 +19,17 @@ type BlockService struct {
 	vechainClient meshthor.VeChainClientInterface
 	encoder       *meshtx.MeshTransactionEncoder
 	builder       *meshtx.TransactionBuilder
+	// responses holds the responses built for finalized blocks, nil when caching is disabled
+	responses *meshcommon.BlockCache[*types.BlockResponse]
 }
 
 // NewBlockService creates a new block service
-func NewBlockService(vechainClient meshthor.VeChainClientInterface) *BlockService {
+func NewBlockService(vechainClient meshthor.VeChainClientInterface, config *meshconfig.Config) *BlockService {
 	return &BlockService{
 		vechainClient: vechainClient,
-		encoder:       meshtx.NewMeshTransactionEncoder(vechainClient),
+		encoder:       meshtx.NewMeshTransactionEncoder(vechainClient, config.TokenRegistry),
 		builder:       meshtx.NewTransactionBuilder(),
+		responses:     meshcommon.NewBlockCache[*types.BlockResponse](config.GetBlockCacheBytes()),
 	}
 }
 
@@ -33,6 +38,10 @@ func (b *BlockService) Block(
 	ctx context.Context,
 	req *types.BlockRequest,
 ) (*types.BlockResponse, *types.Error) {
+	if response, ok := b.getCachedResponse(*req.BlockIdentifier); ok {
+		return response, nil
+	}
+
 	block, err := b.getBlockByPartialIdentifier(*req.BlockIdentifier)
 	if err != nil {
 		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrBlockNotFound, map[string]any{
@@ -40,6 +49,10 @@ func (b *BlockService) Block(
 		})
 	}
 
+	if response, ok := b.responses.Get(block.ID.String()); ok {
+		return response, nil
+	}
+
 	parent, err := b.getParentBlock(block)
 	if err != nil {
 		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrBlockNotFound, map[string]any{
@@ -53,6 +66,7 @@ func (b *BlockService) Block(
 			"error": err.Error(),
 		})
 	}
+	b.cacheResponse(block, response)
 
 	return response, nil
 }
@@ -116,6 +130,30 @@ func (b *BlockService) getBlockByPartialIdentifier(blockIdentifier types.Partial
 	return nil, fmt.Errorf("invalid block identifier")
 }
 
+// getCachedResponse returns the cached response of a finalized block by its partial identifier
+func (b *BlockService) getCachedResponse(blockIdentifier types.PartialBlockIdentifier) (*types.BlockResponse, bool) {
+	if blockIdentifier.Hash != nil && *blockIdentifier.Hash != "" {
+		return b.responses.Get(*blockIdentifier.Hash)
+	}
+	if blockIdentifier.Index != nil && *blockIdentifier.Index >= 0 && *blockIdentifier.Index <= math.MaxUint32 {
+		return b.responses.GetByNumber(uint32(*blockIdentifier.Index))
+	}
+	return nil, false
+}
+
+// cacheResponse caches the response built for a block once the block is finalized.
+// The size of the response is estimated from its JSON encoding.
+func (b *BlockService) cacheResponse(block *api.JSONExpandedBlock, response *types.BlockResponse) {
+	if b.responses == nil || !block.IsFinalized {
+		return
+	}
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	b.responses.Put(block.ID.String(), block.Number, response, int64(len(encoded)))
+}
+
 // getParentBlock gets the parent block of the given block
 func (b *BlockService) getParentBlock(block *api.JSONExpandedBlock) (*api.JSONExpandedBlock, error) {
 	if block.Number == 0 {
@@ -178,6 +216,9 @@ func (b *BlockService) buildMeshBlock(block, parent *api.JSONExpandedBlock) (*ty
 		ParentBlockIdentifier: parentBlockIdentifier,
 		Timestamp:             safeBestBlockTimestamp,
 		Transactions:          transactions,
+		Metadata: map[string]any{
+			"finalized": block.IsFinalized,
+		},
 	}
 
 	response := &types.BlockResponse{
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	meshcommon "github.com/vechain/mesh/common"
+	meshconfig "github.com/vechain/mesh/config"
+	meshtests "github.com/vechain/mesh/tests"
 	meshthor "github.com/vechain/mesh/thor"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/thor"
@@ -16,7 +19,7 @@ import (
 
 func TestNewBlockService(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	if service == nil || service.vechainClient != mockClient {
 		t.Errorf("NewBlockService() returned nil or client mismatch")
@@ -27,7 +30,7 @@ func TestNewBlockService(t *testing.T) {
 
 func TestBlockService_Block_ValidRequest(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	// Create request with valid block identifier
 	request := &types.BlockRequest{
@@ -54,7 +57,7 @@ func TestBlockService_Block_ValidRequest(t *testing.T) {
 
 func TestBlockService_BlockTransaction_ValidRequest(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	// Create request with valid block and transaction identifiers
 	request := &types.BlockTransactionRequest{
@@ -84,7 +87,7 @@ func TestBlockService_BlockTransaction_ValidRequest(t *testing.T) {
 
 func TestBlockService_BlockTransaction_InvalidBlockIdentifier(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	request := &types.BlockTransactionRequest{
 		NetworkIdentifier: &types.NetworkIdentifier{
@@ -111,7 +114,7 @@ func TestBlockService_BlockTransaction_InvalidBlockIdentifier(t *testing.T) {
 
 func TestBlockService_BlockTransaction_NilTransactionIdentifier(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	request := &types.BlockTransactionRequest{
 		NetworkIdentifier: &types.NetworkIdentifier{
@@ -138,7 +141,7 @@ func TestBlockService_BlockTransaction_NilTransactionIdentifier(t *testing.T) {
 
 func TestBlockService_BlockTransaction_EmptyTransactionHash(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	request := &types.BlockTransactionRequest{
 		NetworkIdentifier: &types.NetworkIdentifier{
@@ -167,7 +170,7 @@ func TestBlockService_BlockTransaction_EmptyTransactionHash(t *testing.T) {
 
 func TestBlockService_BlockTransaction_BlockNotFound(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	mockClient.SetMockError(errors.New("block not found"))
 
@@ -198,7 +201,7 @@ func TestBlockService_BlockTransaction_BlockNotFound(t *testing.T) {
 
 func TestBlockService_BlockTransaction_TransactionNotFoundInBlock(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	request := &types.BlockTransactionRequest{
 		NetworkIdentifier: &types.NetworkIdentifier{
@@ -227,7 +230,7 @@ func TestBlockService_BlockTransaction_TransactionNotFoundInBlock(t *testing.T)
 
 func TestBlockService_Block_WithHashIdentifier(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	// Create request with hash identifier
 	request := &types.BlockRequest{
@@ -254,7 +257,7 @@ func TestBlockService_Block_WithHashIdentifier(t *testing.T) {
 
 func TestBlockService_Block_WithBothIdentifiers(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	// Create request with both index and hash identifiers
 	request := &types.BlockRequest{
@@ -282,7 +285,7 @@ func TestBlockService_Block_WithBothIdentifiers(t *testing.T) {
 
 func TestBlockService_Block_ErrorCases(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	t.Run("Block not found", func(t *testing.T) {
 		// Set up mock to return error
@@ -309,7 +312,7 @@ func TestBlockService_Block_ErrorCases(t *testing.T) {
 
 func TestBlockService_Block_TimestampOverflow(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	// Force block timestamp such that timestamp*1000 > MaxInt64
 	if mockClient.MockBlock != nil && mockClient.MockBlock.JSONBlockSummary != nil {
@@ -337,7 +340,7 @@ func TestBlockService_Block_TimestampOverflow(t *testing.T) {
 
 func TestBlockService_Block_WithHashBlockIdentifier(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	// Create request with hash block identifier
 	request := &types.BlockTransactionRequest{
@@ -367,7 +370,7 @@ func TestBlockService_Block_WithHashBlockIdentifier(t *testing.T) {
 
 func TestBlockService_getBlockByIdentifier(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	t.Run("Get block by number", func(t *testing.T) {
 		// Set up mock block
@@ -447,7 +450,7 @@ func TestBlockService_getBlockByIdentifier(t *testing.T) {
 
 func TestBlockService_getBlockByPartialIdentifier(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewBlockService(mockClient)
+	service := NewBlockService(mockClient, &meshconfig.Config{})
 
 	t.Run("Get block by number", func(t *testing.T) {
 		// Set up mock block
@@ -527,3 +530,129 @@ func TestBlockService_getBlockByPartialIdentifier(t *testing.T) {
 		}
 	})
 }
+
+func TestBlockService_Block_CachedResponse(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	service := NewBlockService(mockClient, &meshconfig.Config{BlockCacheSizeMB: 2})
+	index := int64(100)
+	request := &types.BlockRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		BlockIdentifier:   &types.PartialBlockIdentifier{Index: &index},
+	}
+
+	// Blocks above the finalized checkpoint are rebuilt on every request
+	if _, err := service.Block(context.Background(), request); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	mockClient.SetMockBlockError(errors.New("node unavailable"))
+	if _, err := service.Block(context.Background(), request); err == nil {
+		t.Fatal("Block() expected the node to be queried for a block that is not finalized")
+	}
+
+	mockClient.SetMockBlockError(nil)
+	mockClient.MockBlock.IsFinalized = true
+	response, err := service.Block(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+
+	// Finalized blocks are then served without the node, by index and by hash
+	mockClient.SetMockBlockError(errors.New("node unavailable"))
+	hash := response.Block.BlockIdentifier.Hash
+	for _, identifier := range []*types.PartialBlockIdentifier{{Index: &index}, {Hash: &hash}} {
+		cached, err := service.Block(context.Background(), &types.BlockRequest{
+			NetworkIdentifier: request.NetworkIdentifier,
+			BlockIdentifier:   identifier,
+		})
+		if err != nil {
+			t.Fatalf("Block() error = %v", err)
+		}
+		if cached != response {
+			t.Errorf("Block() expected the cached response")
+		}
+	}
+}
+
+func TestBlockService_Block_Finalized(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	service := NewBlockService(mockClient, &meshconfig.Config{})
+	index := int64(100)
+	request := &types.BlockRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		BlockIdentifier:   &types.PartialBlockIdentifier{Index: &index},
+	}
+
+	for _, finalized := range []bool{false, true} {
+		mockClient.MockBlock.IsFinalized = finalized
+		response, err := service.Block(context.Background(), request)
+		if err != nil {
+			t.Fatalf("Block() error = %v", err)
+		}
+		if response.Block.Metadata["finalized"] != finalized {
+			t.Errorf("Block() metadata = %v, want finalized %v", response.Block.Metadata, finalized)
+		}
+	}
+}
+
+// flakyCallClient fails the first contract calls, like a node that is briefly unavailable
+type flakyCallClient struct {
+	*meshthor.MockVeChainClient
+	failures int
+}
+
+func (c *flakyCallClient) CallContract(contractAddress, callData string) (string, error) {
+	if c.failures > 0 {
+		c.failures--
+		return "", errors.New("node unavailable")
+	}
+	return c.MockVeChainClient.CallContract(contractAddress, callData)
+}
+
+func TestBlockService_Block_TokenLookupFailure(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	mockClient.MockBlock.IsFinalized = true
+	token := thor.MustParseAddress("0x" + strings.Repeat("cd", 20))
+	mockClient.MockBlock.Transactions[0].Clauses = []*api.JSONClause{{
+		To:   &token,
+		Data: "0xa9059cbb" + fmt.Sprintf("%064s%064x", strings.TrimPrefix(meshtests.TestAddress1, "0x"), 500),
+	}}
+	mockClient.SetMockCallResults([]string{
+		// symbol() = "ABC", decimals() = 18
+		"0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000034142430000000000000000000000000000000000000000000000000000000000",
+		"0x0000000000000000000000000000000000000000000000000000000000000012",
+	})
+	client := &flakyCallClient{MockVeChainClient: mockClient, failures: 1}
+	service := NewBlockService(client, &meshconfig.Config{BlockCacheSizeMB: 2})
+	index := int64(100)
+	request := &types.BlockRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		BlockIdentifier:   &types.PartialBlockIdentifier{Index: &index},
+	}
+
+	// The token lookup fails, the block must not be served nor cached without its token transfer
+	if _, err := service.Block(context.Background(), request); err == nil {
+		t.Fatal("Block() expected an error when the token lookup fails")
+	}
+
+	response, err := service.Block(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	tokenOperations := 0
+	for _, op := range response.Block.Transactions[0].Operations {
+		if op.Amount != nil && op.Amount.Currency.Symbol == "ABC" {
+			tokenOperations++
+		}
+	}
+	if tokenOperations != 2 {
+		t.Fatalf("Block() reported %d ABC token operations, want 2", tokenOperations)
+	}
+
+	cached, err := service.Block(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	if cached != response {
+		t.Errorf("Block() expected the cached response")
+	}
+}
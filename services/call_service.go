@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	meshcommon "github.com/vechain/mesh/common"
 	meshoperations "github.com/vechain/mesh/common/operations"
+	meshtx "github.com/vechain/mesh/common/tx"
 	meshconfig "github.com/vechain/mesh/config"
 	meshthor "github.com/vechain/mesh/thor"
 	"github.com/vechain/thor/v2/api"
@@ -20,32 +23,49 @@ type CallService struct {
 	vechainClient meshthor.VeChainClientInterface
 	config        *meshconfig.Config
 	clauseParser  *meshoperations.ClauseParser
+	encoder       *meshtx.MeshTransactionEncoder
+	// sponsor signs delegated transactions as gas payer, nil when sponsoring is not configured
+	sponsor *Sponsor
+	// tracker reports the status of the submitted transactions, nil when not tracking
+	tracker *TxTracker
 }
 
-// NewCallService creates a new call service
-func NewCallService(vechainClient meshthor.VeChainClientInterface, config *meshconfig.Config) *CallService {
+// NewCallService creates a new call service, sponsor and tracker can be nil
+func NewCallService(vechainClient meshthor.VeChainClientInterface, config *meshconfig.Config, sponsor *Sponsor, tracker *TxTracker) *CallService {
 	return &CallService{
 		vechainClient: vechainClient,
 		config:        config,
-		clauseParser:  meshoperations.NewClauseParser(vechainClient, meshoperations.NewOperationsExtractor()),
+		clauseParser:  meshoperations.NewClauseParser(vechainClient, meshoperations.NewOperationsExtractor(config.TokenRegistry)),
+		encoder:       meshtx.NewMeshTransactionEncoder(vechainClient, config.TokenRegistry),
+		sponsor:       sponsor,
+		tracker:       tracker,
 	}
 }
 
-// Call invokes a network-specific procedure call
-// For VeChain, this implements the InspectClauses functionality to simulate transactions
+// Call invokes a network-specific procedure call.
+// For VeChain, it simulates clauses with InspectClauses and signs sponsored transactions as gas payer.
 func (c *CallService) Call(
 	ctx context.Context,
 	req *types.CallRequest,
 ) (*types.CallResponse, *types.Error) {
-	// Validate method
-	if req.Method != meshcommon.CallMethodInspectClauses {
+	switch req.Method {
+	case meshcommon.CallMethodInspectClauses:
+		return c.inspectClauses(req)
+	case meshcommon.CallMethodSponsorTransaction:
+		return c.sponsorTransaction(req)
+	case meshcommon.CallMethodTransactionStatus:
+		return c.transactionStatus(req)
+	default:
 		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestBody, map[string]any{
 			"error":             "unsupported method",
 			"method":            req.Method,
-			"supported_methods": []string{meshcommon.CallMethodInspectClauses},
+			"supported_methods": []string{meshcommon.CallMethodInspectClauses, meshcommon.CallMethodSponsorTransaction, meshcommon.CallMethodTransactionStatus},
 		})
 	}
+}
 
+// inspectClauses simulates the clauses given in the parameters
+func (c *CallService) inspectClauses(req *types.CallRequest) (*types.CallResponse, *types.Error) {
 	// Parse parameters into BatchCallData
 	batchCallData, err := c.parseBatchCallDataFromParameters(req.Parameters)
 	if err != nil {
@@ -79,6 +99,109 @@ func (c *CallService) Call(
 	}, nil
 }
 
+// sponsorTransaction signs as gas payer the unsigned delegated transaction given in the parameters,
+// as returned by /construction/payloads, when it matches the sponsorship policy.
+// The signature of the origin over the transaction is required to authenticate the origin.
+func (c *CallService) sponsorTransaction(req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	if c.sponsor == nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestBody, map[string]any{
+			"error": "transaction sponsoring is not configured",
+		})
+	}
+
+	unsignedTx, ok := req.Parameters["unsigned_transaction"].(string)
+	if !ok {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestBody, map[string]any{
+			"error": "unsigned_transaction is required and must be a string",
+		})
+	}
+	txBytes, err := hexutil.Decode(unsignedTx)
+	if err != nil {
+		return nil, meshcommon.GetError(meshcommon.ErrInvalidTransactionHex)
+	}
+	meshTx, err := c.encoder.DecodeUnsignedTransaction(txBytes)
+	if err != nil {
+		return nil, meshcommon.GetError(meshcommon.ErrFailedToDecodeUnsignedTransaction)
+	}
+
+	originSig, ok := req.Parameters["origin_signature"].(string)
+	if !ok {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestBody, map[string]any{
+			"error": "origin_signature is required and must be a string",
+		})
+	}
+	originSignature, err := hexutil.Decode(originSig)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestBody, map[string]any{
+			"error": "origin_signature must be a hex string",
+		})
+	}
+
+	signature, err := c.sponsor.Sign(meshTx, originSignature)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrSponsorshipRejected, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	return &types.CallResponse{
+		Result: map[string]any{
+			"delegator": c.sponsor.Address(),
+			"signature": hexutil.Encode(signature),
+		},
+		Idempotent: false, // Signatures are charged to the daily budget of the origin
+	}, nil
+}
+
+// transactionStatus returns the status of a transaction submitted through /construction/submit
+func (c *CallService) transactionStatus(req *types.CallRequest) (*types.CallResponse, *types.Error) {
+	if c.tracker == nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestBody, map[string]any{
+			"error": "transaction tracking is not enabled",
+		})
+	}
+
+	hash, ok := req.Parameters["transaction_hash"].(string)
+	if !ok {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestBody, map[string]any{
+			"error": "transaction_hash is required and must be a string",
+		})
+	}
+	txID, err := thor.ParseBytes32(hash)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidTransactionHash, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	tracked, ok := c.tracker.Status(txID)
+	if !ok {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrTransactionNotFound, map[string]any{
+			"error": "transaction was not submitted through this server or is no longer tracked",
+		})
+	}
+
+	result := map[string]any{
+		"transaction_hash": tracked.ID.String(),
+		"status":           tracked.Status,
+		"submitted_at":     tracked.SubmittedAt.UTC().Format(time.RFC3339),
+		"updated_at":       tracked.UpdatedAt.UTC().Format(time.RFC3339),
+		"block_ref":        tracked.BlockRef,
+		"expiration":       tracked.Expiration,
+	}
+	if tracked.Block != nil {
+		result["block_identifier"] = map[string]any{
+			"index": tracked.Block.Index,
+			"hash":  tracked.Block.Hash,
+		}
+	}
+
+	return &types.CallResponse{
+		Result:     result,
+		Idempotent: tracked.IsFinal(),
+	}, nil
+}
+
 // parseBatchCallDataFromParameters converts request parameters to api.BatchCallData
 func (c *CallService) parseBatchCallDataFromParameters(params map[string]any) (*api.BatchCallData, error) {
 	batchCallData := &api.BatchCallData{}
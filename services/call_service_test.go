@@ -3,12 +3,16 @@ package services
 import (
 	"context"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	meshcommon "github.com/vechain/mesh/common"
+	meshtx "github.com/vechain/mesh/common/tx"
 	meshconfig "github.com/vechain/mesh/config"
+	meshtests "github.com/vechain/mesh/tests"
 	meshthor "github.com/vechain/mesh/thor"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/thor"
@@ -21,7 +25,7 @@ func createMockCallService() *CallService {
 func createMockCallServiceWithClient(client *meshthor.MockVeChainClient) *CallService {
 	config := &meshconfig.Config{}
 	config.Network = meshcommon.SoloNetwork
-	return NewCallService(client, config)
+	return NewCallService(client, config, nil, nil)
 }
 
 func createTestCallRequest(method string, params map[string]any) *types.CallRequest {
@@ -681,3 +685,115 @@ func TestCallService_Call_WithRevertedTransaction(t *testing.T) {
 		t.Errorf("Call() result vmError = %v, want 'execution reverted'", vmError)
 	}
 }
+
+func TestCallService_Call_SponsorTransaction(t *testing.T) {
+	sponsor := createTestSponsor(t, &meshconfig.SponsorConfig{MaxGas: 60000})
+	service := NewCallService(meshthor.NewMockVeChainClient(), &meshconfig.Config{}, sponsor, nil)
+
+	encode := func(meshTx *meshtx.MeshTransaction) string {
+		txBytes, err := service.encoder.EncodeTransaction(meshTx)
+		if err != nil {
+			t.Fatalf("EncodeTransaction() error = %v", err)
+		}
+		return hexutil.Encode(txBytes)
+	}
+
+	meshTx := createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0)
+	response, err := service.Call(context.Background(), createTestCallRequest(meshcommon.CallMethodSponsorTransaction, map[string]any{
+		"unsigned_transaction": encode(meshTx),
+		"origin_signature":     hexutil.Encode(originSignature(meshTx, meshtests.TestAddress1PrivateKey)),
+	}))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if response.Result["delegator"] != meshtests.TestDelegatorAddress || response.Idempotent {
+		t.Errorf("Call() result = %v, idempotent = %v", response.Result, response.Idempotent)
+	}
+	if signature, ok := response.Result["signature"].(string); !ok || len(signature) != 2+65*2 {
+		t.Errorf("Call() signature = %v, want 65 bytes", response.Result["signature"])
+	}
+
+	// Transactions outside the policy are rejected
+	meshTx = createSponsoredTransaction(meshtests.FirstSoloAddress, 70000, 0)
+	_, err = service.Call(context.Background(), createTestCallRequest(meshcommon.CallMethodSponsorTransaction, map[string]any{
+		"unsigned_transaction": encode(meshTx),
+		"origin_signature":     hexutil.Encode(originSignature(meshTx, meshtests.TestAddress1PrivateKey)),
+	}))
+	if err == nil || err.Code != int32(meshcommon.ErrSponsorshipRejected) {
+		t.Errorf("Call() error = %v, want sponsorship rejected", err)
+	}
+
+	// The origin signature is required
+	_, err = service.Call(context.Background(), createTestCallRequest(meshcommon.CallMethodSponsorTransaction, map[string]any{
+		"unsigned_transaction": encode(createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0)),
+	}))
+	if err == nil || err.Code != int32(meshcommon.ErrInvalidRequestBody) {
+		t.Errorf("Call() error = %v, want invalid request body", err)
+	}
+
+	_, err = service.Call(context.Background(), createTestCallRequest(meshcommon.CallMethodSponsorTransaction, map[string]any{}))
+	if err == nil || err.Code != int32(meshcommon.ErrInvalidRequestBody) {
+		t.Errorf("Call() error = %v, want invalid request body", err)
+	}
+}
+
+func TestCallService_Call_SponsorTransaction_NotConfigured(t *testing.T) {
+	service := createMockCallService()
+
+	_, err := service.Call(context.Background(), createTestCallRequest(meshcommon.CallMethodSponsorTransaction, map[string]any{
+		"unsigned_transaction": "0x00",
+	}))
+	if err == nil || err.Code != int32(meshcommon.ErrInvalidRequestBody) {
+		t.Errorf("Call() error = %v, want invalid request body", err)
+	}
+}
+
+func TestCallService_Call_TransactionStatus(t *testing.T) {
+	mockClient := createTrackerClient(100)
+	tracker := NewTxTracker(mockClient)
+	service := NewCallService(mockClient, &meshconfig.Config{}, nil, tracker)
+	trx := createTrackedTransaction(t, 1)
+	tracker.Track(trx)
+
+	response, err := service.Call(context.Background(), createTestCallRequest(meshcommon.CallMethodTransactionStatus, map[string]any{
+		"transaction_hash": trx.ID().String(),
+	}))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if response.Result["status"] != TxStatusPending || response.Result["block_ref"] != uint32(90) || response.Idempotent {
+		t.Errorf("Call() result = %v, idempotent = %v", response.Result, response.Idempotent)
+	}
+
+	mockClient.MockReceipts[trx.ID()] = &api.Receipt{Meta: api.ReceiptMeta{BlockNumber: 100}}
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	response, err = service.Call(context.Background(), createTestCallRequest(meshcommon.CallMethodTransactionStatus, map[string]any{
+		"transaction_hash": trx.ID().String(),
+	}))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if response.Result["status"] != TxStatusIncluded || response.Result["block_identifier"] == nil || !response.Idempotent {
+		t.Errorf("Call() result = %v, idempotent = %v", response.Result, response.Idempotent)
+	}
+
+	_, err = service.Call(context.Background(), createTestCallRequest(meshcommon.CallMethodTransactionStatus, map[string]any{
+		"transaction_hash": createTrackedTransaction(t, 2).ID().String(),
+	}))
+	if err == nil || err.Code != int32(meshcommon.ErrTransactionNotFound) {
+		t.Errorf("Call() error = %v, want transaction not found", err)
+	}
+}
+
+func TestCallService_Call_TransactionStatus_NotConfigured(t *testing.T) {
+	service := createMockCallService()
+
+	_, err := service.Call(context.Background(), createTestCallRequest(meshcommon.CallMethodTransactionStatus, map[string]any{
+		"transaction_hash": "0x" + strings.Repeat("00", 32),
+	}))
+	if err == nil || err.Code != int32(meshcommon.ErrInvalidRequestBody) {
+		t.Errorf("Call() error = %v, want invalid request body", err)
+	}
+}
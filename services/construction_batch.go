@@ -0,0 +1,429 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	meshcommon "github.com/vechain/mesh/common"
+	meshoperations "github.com/vechain/mesh/common/operations"
+	meshtx "github.com/vechain/mesh/common/tx"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Batch mode builds one independent transaction per origin from the operations of a construction flow.
+// Preprocess options and metadata hold one entry per origin under the batch key, and the unsigned and
+// signed batch transactions are JSON arrays of the hex encoded transactions, in the order of the origins.
+
+// batchHashesMetadataKey lists the hashes of the transactions of a batch
+const batchHashesMetadataKey = "transactionHashes"
+
+// preprocessBatch builds the options of one transaction per origin of the operations
+func (c *ConstructionService) preprocessBatch(req *types.ConstructionPreprocessRequest, delegator string) (*types.ConstructionPreprocessResponse, *types.Error) {
+	groups, err := c.operationsExtractor.GroupOperationsByOrigin(req.Operations)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	batch := make([]map[string]any, 0, len(groups))
+	requiredPublicKeys := make([]*types.AccountIdentifier, 0, len(groups)+1)
+	for _, group := range groups {
+		clauses, rosettaErr := c.preprocessClauses(group.Operations)
+		if rosettaErr != nil {
+			return nil, rosettaErr
+		}
+		batch = append(batch, map[string]any{
+			"origin":  group.Origin,
+			"clauses": clauses,
+		})
+		requiredPublicKeys = append(requiredPublicKeys, &types.AccountIdentifier{Address: group.Origin})
+	}
+
+	// The fee delegator, if any, pays for every transaction of the batch
+	if delegator != "" && !slices.ContainsFunc(groups, func(group meshoperations.OriginOperations) bool {
+		return strings.EqualFold(group.Origin, delegator)
+	}) {
+		requiredPublicKeys = append(requiredPublicKeys, &types.AccountIdentifier{Address: delegator})
+	}
+
+	options := preprocessOptions(req.Metadata, delegator)
+	options[meshcommon.BatchMetadataKey] = batch
+	return &types.ConstructionPreprocessResponse{
+		Options:            options,
+		RequiredPublicKeys: requiredPublicKeys,
+	}, nil
+}
+
+// batchMetadata gets the metadata of each transaction of a batch, the suggested fee is the fee of the whole batch
+func (c *ConstructionService) batchMetadata(
+	ctx context.Context,
+	req *types.ConstructionMetadataRequest,
+	batch any,
+) (*types.ConstructionMetadataResponse, *types.Error) {
+	entries, err := batchEntries(batch)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	metadata := make([]map[string]any, 0, len(entries))
+	totalFee := new(big.Int)
+	for _, entry := range entries {
+		options := maps.Clone(req.Options)
+		delete(options, meshcommon.BatchMetadataKey)
+		options["origin"] = entry["origin"]
+		options["clauses"] = entry["clauses"]
+
+		response, rosettaErr := c.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+			NetworkIdentifier: req.NetworkIdentifier,
+			Options:           options,
+		})
+		if rosettaErr != nil {
+			return nil, rosettaErr
+		}
+		response.Metadata["origin"] = entry["origin"]
+		metadata = append(metadata, response.Metadata)
+
+		for _, fee := range response.SuggestedFee {
+			value, ok := new(big.Int).SetString(fee.Value, 10)
+			if !ok {
+				return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInternalServerError, map[string]any{
+					"error": fmt.Sprintf("invalid suggested fee %s", fee.Value),
+				})
+			}
+			totalFee.Add(totalFee, value)
+		}
+	}
+
+	return &types.ConstructionMetadataResponse{
+		Metadata: map[string]any{
+			meshcommon.BatchMetadataKey: metadata,
+		},
+		SuggestedFee: []*types.Amount{
+			{
+				Value:    totalFee.String(),
+				Currency: meshcommon.VTHOCurrency,
+			},
+		},
+	}, nil
+}
+
+// batchPayloads creates the unsigned transaction and signing payloads of each origin of a batch
+func (c *ConstructionService) batchPayloads(
+	ctx context.Context,
+	req *types.ConstructionPayloadsRequest,
+	batch any,
+) (*types.ConstructionPayloadsResponse, *types.Error) {
+	entries, err := batchEntries(batch)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": err.Error(),
+		})
+	}
+	metadataByOrigin := make(map[string]map[string]any, len(entries))
+	for _, entry := range entries {
+		origin, _ := entry["origin"].(string)
+		metadataByOrigin[strings.ToLower(origin)] = entry
+	}
+
+	groups, err := c.operationsExtractor.GroupOperationsByOrigin(req.Operations)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	// Public keys are matched to the origins and the fee delegator by their address
+	publicKeys := make(map[string]*types.PublicKey, len(req.PublicKeys))
+	for _, publicKey := range req.PublicKeys {
+		address, err := c.bytesHandler.ComputeAddress(publicKey)
+		if err != nil {
+			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidPublicKeyFormat, map[string]any{
+				"error": err.Error(),
+			})
+		}
+		publicKeys[strings.ToLower(address)] = publicKey
+	}
+
+	unsignedTxs := make([]string, 0, len(groups))
+	var payloads []*types.SigningPayload
+	for _, group := range groups {
+		metadata, ok := metadataByOrigin[group.Origin]
+		if !ok {
+			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+				"error":  "missing metadata of origin",
+				"origin": group.Origin,
+			})
+		}
+		originKey, ok := publicKeys[group.Origin]
+		if !ok {
+			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidPublicKeyFormat, map[string]any{
+				"error":  "missing public key of origin",
+				"origin": group.Origin,
+			})
+		}
+		txPublicKeys := []*types.PublicKey{originKey}
+		if delegator := c.operationsExtractor.GetFeeDelegatorAccount(metadata); delegator != "" {
+			if delegatorKey, ok := publicKeys[strings.ToLower(delegator)]; ok {
+				txPublicKeys = append(txPublicKeys, delegatorKey)
+			}
+		}
+
+		response, rosettaErr := c.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+			NetworkIdentifier: req.NetworkIdentifier,
+			Operations:        group.Operations,
+			Metadata:          metadata,
+			PublicKeys:        txPublicKeys,
+		})
+		if rosettaErr != nil {
+			return nil, rosettaErr
+		}
+		unsignedTxs = append(unsignedTxs, response.UnsignedTransaction)
+		payloads = append(payloads, response.Payloads...)
+	}
+
+	unsignedTx, err := encodeBatchTransaction(unsignedTxs)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrFailedToEncodeTransaction, map[string]any{
+			"error": err.Error(),
+		})
+	}
+	return &types.ConstructionPayloadsResponse{
+		UnsignedTransaction: unsignedTx,
+		Payloads:            payloads,
+	}, nil
+}
+
+// batchParse parses each transaction of a batch, the operations of the transactions follow each other
+func (c *ConstructionService) batchParse(
+	ctx context.Context,
+	req *types.ConstructionParseRequest,
+) (*types.ConstructionParseResponse, *types.Error) {
+	transactions, err := decodeBatchTransaction(req.Transaction)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidTransactionHex, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	response := &types.ConstructionParseResponse{}
+	metadata := make([]map[string]any, 0, len(transactions))
+	for _, transaction := range transactions {
+		parsed, rosettaErr := c.ConstructionParse(ctx, &types.ConstructionParseRequest{
+			NetworkIdentifier: req.NetworkIdentifier,
+			Signed:            req.Signed,
+			Transaction:       transaction,
+		})
+		if rosettaErr != nil {
+			return nil, rosettaErr
+		}
+
+		offset := int64(len(response.Operations))
+		for _, op := range parsed.Operations {
+			op.OperationIdentifier.Index += offset
+			for _, related := range op.RelatedOperations {
+				related.Index += offset
+			}
+		}
+		response.Operations = append(response.Operations, parsed.Operations...)
+		metadata = append(metadata, parsed.Metadata)
+
+		for _, signer := range parsed.AccountIdentifierSigners {
+			if !slices.ContainsFunc(response.AccountIdentifierSigners, func(account *types.AccountIdentifier) bool {
+				return strings.EqualFold(account.Address, signer.Address)
+			}) {
+				response.AccountIdentifierSigners = append(response.AccountIdentifierSigners, signer)
+			}
+		}
+	}
+
+	response.Metadata = map[string]any{
+		meshcommon.BatchMetadataKey: metadata,
+	}
+	return response, nil
+}
+
+// batchCombine signs each transaction of a batch with the signatures of its own signing payloads
+func (c *ConstructionService) batchCombine(
+	ctx context.Context,
+	req *types.ConstructionCombineRequest,
+) (*types.ConstructionCombineResponse, *types.Error) {
+	unsignedTxs, err := decodeBatchTransaction(req.UnsignedTransaction)
+	if err != nil {
+		return nil, meshcommon.GetError(meshcommon.ErrInvalidUnsignedTransactionParameter)
+	}
+
+	signedTxs := make([]string, 0, len(unsignedTxs))
+	for _, unsignedTx := range unsignedTxs {
+		txBytes, err := c.bytesHandler.DecodeHexStringWithPrefix(unsignedTx)
+		if err != nil {
+			return nil, meshcommon.GetError(meshcommon.ErrInvalidUnsignedTransactionParameter)
+		}
+		meshTx, err := c.encoder.DecodeUnsignedTransaction(txBytes)
+		if err != nil {
+			return nil, meshcommon.GetError(meshcommon.ErrFailedToDecodeUnsignedTransaction)
+		}
+
+		response, rosettaErr := c.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+			NetworkIdentifier:   req.NetworkIdentifier,
+			UnsignedTransaction: unsignedTx,
+			Signatures:          transactionSignatures(meshTx, req.Signatures),
+		})
+		if rosettaErr != nil {
+			return nil, rosettaErr
+		}
+		signedTxs = append(signedTxs, response.SignedTransaction)
+	}
+
+	signedTx, err := encodeBatchTransaction(signedTxs)
+	if err != nil {
+		return nil, meshcommon.GetError(meshcommon.ErrFailedToEncodeSignedTransaction)
+	}
+	return &types.ConstructionCombineResponse{
+		SignedTransaction: signedTx,
+	}, nil
+}
+
+// batchHash returns the hash of the first transaction of a batch, the hashes of all of them are in metadata
+func (c *ConstructionService) batchHash(
+	ctx context.Context,
+	req *types.ConstructionHashRequest,
+) (*types.TransactionIdentifierResponse, *types.Error) {
+	signedTxs, err := decodeBatchTransaction(req.SignedTransaction)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidTransactionHex, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	hashes := make([]string, 0, len(signedTxs))
+	for _, signedTx := range signedTxs {
+		response, rosettaErr := c.ConstructionHash(ctx, &types.ConstructionHashRequest{
+			NetworkIdentifier: req.NetworkIdentifier,
+			SignedTransaction: signedTx,
+		})
+		if rosettaErr != nil {
+			return nil, rosettaErr
+		}
+		hashes = append(hashes, response.TransactionIdentifier.Hash)
+	}
+	return batchIdentifierResponse(hashes), nil
+}
+
+// batchSubmit submits the transactions of a batch in order.
+// When a submission fails, the error lists the hashes of the transactions already submitted.
+func (c *ConstructionService) batchSubmit(
+	ctx context.Context,
+	req *types.ConstructionSubmitRequest,
+) (*types.TransactionIdentifierResponse, *types.Error) {
+	signedTxs, err := decodeBatchTransaction(req.SignedTransaction)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidTransactionHex, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	hashes := make([]string, 0, len(signedTxs))
+	for _, signedTx := range signedTxs {
+		response, rosettaErr := c.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+			NetworkIdentifier: req.NetworkIdentifier,
+			SignedTransaction: signedTx,
+		})
+		if rosettaErr != nil {
+			details := maps.Clone(rosettaErr.Details)
+			if details == nil {
+				details = map[string]any{}
+			}
+			details["submitted"] = hashes
+			return nil, meshcommon.GetErrorWithMetadata(int(rosettaErr.Code), details)
+		}
+		hashes = append(hashes, response.TransactionIdentifier.Hash)
+	}
+	return batchIdentifierResponse(hashes), nil
+}
+
+// transactionSignatures returns the signatures of the origin and fee delegator payloads of a transaction
+func transactionSignatures(meshTx *meshtx.MeshTransaction, signatures []*types.Signature) []*types.Signature {
+	hashes := []thor.Bytes32{meshTx.SigningHash()}
+	if meshTx.Features().IsDelegated() {
+		hashes = append(hashes, meshTx.DelegatorSigningHash(thor.BytesToAddress(meshTx.Origin)))
+	}
+
+	var matched []*types.Signature
+	for _, hash := range hashes {
+		for _, signature := range signatures {
+			if signature.SigningPayload != nil && bytes.Equal(signature.SigningPayload.Bytes, hash[:]) {
+				matched = append(matched, signature)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+func batchIdentifierResponse(hashes []string) *types.TransactionIdentifierResponse {
+	return &types.TransactionIdentifierResponse{
+		TransactionIdentifier: &types.TransactionIdentifier{
+			Hash: hashes[0],
+		},
+		Metadata: map[string]any{
+			batchHashesMetadataKey: hashes,
+		},
+	}
+}
+
+// batchEntries returns the per origin entries of batch options or metadata
+func batchEntries(batch any) ([]map[string]any, error) {
+	var entries []map[string]any
+	switch value := batch.(type) {
+	case []map[string]any:
+		entries = value
+	case []any:
+		for i, item := range value {
+			entry, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("batch entry %d must be an object", i)
+			}
+			entries = append(entries, entry)
+		}
+	default:
+		return nil, fmt.Errorf("batch must be a list")
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("batch must not be empty")
+	}
+	return entries, nil
+}
+
+// isBatchTransaction reports whether a transaction string is a batch of transactions
+func isBatchTransaction(transaction string) bool {
+	return strings.HasPrefix(strings.TrimSpace(transaction), "[")
+}
+
+func encodeBatchTransaction(transactions []string) (string, error) {
+	encoded, err := json.Marshal(transactions)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func decodeBatchTransaction(transaction string) ([]string, error) {
+	var transactions []string
+	if err := json.Unmarshal([]byte(transaction), &transactions); err != nil {
+		return nil, fmt.Errorf("invalid batch transaction: %w", err)
+	}
+	if len(transactions) == 0 {
+		return nil, fmt.Errorf("batch transaction is empty")
+	}
+	return transactions, nil
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	meshcommon "github.com/vechain/mesh/common"
+	meshcrypto "github.com/vechain/mesh/common/crypto"
+	meshtests "github.com/vechain/mesh/tests"
+	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
+)
+
+// roundTripJSON returns the value as received by the next endpoint of the construction flow
+func roundTripJSON(t *testing.T, value map[string]any) map[string]any {
+	t.Helper()
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	return decoded
+}
+
+// createBatchOperations sweeps two deposit addresses to the same wallet, each credit is related to its debit
+func createBatchOperations() []*types.Operation {
+	transfer := func(index int64, address, value string, related ...int64) *types.Operation {
+		op := &types.Operation{
+			OperationIdentifier: &types.OperationIdentifier{Index: index},
+			Type:                meshcommon.OperationTypeTransfer,
+			Account:             &types.AccountIdentifier{Address: address},
+			Amount:              &types.Amount{Value: value, Currency: meshcommon.VETCurrency},
+		}
+		for _, relatedIndex := range related {
+			op.RelatedOperations = append(op.RelatedOperations, &types.OperationIdentifier{Index: relatedIndex})
+		}
+		return op
+	}
+	return []*types.Operation{
+		transfer(0, meshtests.TestAddress1, "-1000"),
+		transfer(1, meshtests.FirstSoloAddress, "1000", 0),
+		transfer(2, meshtests.TestDelegatorAddress, "-2000"),
+		transfer(3, meshtests.FirstSoloAddress, "2000", 2),
+	}
+}
+
+func TestConstructionService_Batch(t *testing.T) {
+	service := createMockConstructionService()
+	mockClient := service.vechainClient.(*meshthor.MockVeChainClient)
+	mockClient.SetInspectClausesResult([]*api.CallResult{{GasUsed: 0}})
+	ctx := context.Background()
+	network := createTestNetworkIdentifier(meshcommon.TestNetwork)
+	operations := createBatchOperations()
+
+	// Without batch mode, several origins are rejected
+	if _, rosettaErr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: network,
+		Operations:        operations,
+	}); rosettaErr == nil || rosettaErr.Code != meshcommon.ErrTransactionMultipleOrigins {
+		t.Fatalf("ConstructionPreprocess() error = %v, want multiple origins error", rosettaErr)
+	}
+
+	preprocess, rosettaErr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: network,
+		Operations:        operations,
+		Metadata: map[string]any{
+			meshcommon.BatchMetadataKey: true,
+			"transactionType":           meshcommon.TransactionTypeLegacy,
+			"gasPriceCoef":              float64(0),
+		},
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionPreprocess() error = %v", rosettaErr)
+	}
+	if len(preprocess.RequiredPublicKeys) != 2 {
+		t.Fatalf("ConstructionPreprocess() required public keys = %v, want both origins", preprocess.RequiredPublicKeys)
+	}
+
+	metadata, rosettaErr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: network,
+		Options:           roundTripJSON(t, preprocess.Options),
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionMetadata() error = %v", rosettaErr)
+	}
+	// Each transfer costs the intrinsic gas at the base gas price
+	if want := "-42000000000000000000000"; metadata.SuggestedFee[0].Value != want {
+		t.Errorf("ConstructionMetadata() suggested fee = %s, want %s", metadata.SuggestedFee[0].Value, want)
+	}
+
+	signers := map[string]string{
+		meshtests.TestAddress1:         meshtests.TestAddress1PrivateKey,
+		meshtests.TestDelegatorAddress: meshtests.TestDelegatorPrivateKey,
+	}
+	var publicKeys []*types.PublicKey
+	for _, privateKeyHex := range signers {
+		privateKey, err := ethcrypto.HexToECDSA(privateKeyHex)
+		if err != nil {
+			t.Fatalf("HexToECDSA() error = %v", err)
+		}
+		publicKeys = append(publicKeys, &types.PublicKey{
+			Bytes:     ethcrypto.CompressPubkey(&privateKey.PublicKey),
+			CurveType: meshtests.SECP256k1,
+		})
+	}
+
+	payloads, rosettaErr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: network,
+		Operations:        operations,
+		Metadata:          roundTripJSON(t, metadata.Metadata),
+		PublicKeys:        publicKeys,
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionPayloads() error = %v", rosettaErr)
+	}
+	if len(payloads.Payloads) != 2 {
+		t.Fatalf("ConstructionPayloads() payloads = %v, want one per origin", payloads.Payloads)
+	}
+
+	// Signatures are given in reverse order, they are matched to their transaction by payload
+	var signatures []*types.Signature
+	for i := len(payloads.Payloads) - 1; i >= 0; i-- {
+		payload := payloads.Payloads[i]
+		signature, err := meshcrypto.NewSigningHandler(signers[payload.AccountIdentifier.Address]).SignPayload(hex.EncodeToString(payload.Bytes))
+		if err != nil {
+			t.Fatalf("SignPayload() error = %v", err)
+		}
+		signatureBytes, _ := hex.DecodeString(signature)
+		signatures = append(signatures, &types.Signature{
+			SigningPayload: payload,
+			SignatureType:  types.EcdsaRecovery,
+			Bytes:          signatureBytes,
+		})
+	}
+	combined, rosettaErr := service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   network,
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures:          signatures,
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionCombine() error = %v", rosettaErr)
+	}
+
+	parsed, rosettaErr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: network,
+		Signed:            true,
+		Transaction:       combined.SignedTransaction,
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionParse() error = %v", rosettaErr)
+	}
+	if len(parsed.AccountIdentifierSigners) != 2 || parsed.AccountIdentifierSigners[0].Address != meshtests.TestAddress1 ||
+		parsed.AccountIdentifierSigners[1].Address != meshtests.TestDelegatorAddress {
+		t.Errorf("ConstructionParse() signers = %v, want both origins in order", parsed.AccountIdentifierSigners)
+	}
+	for i, op := range parsed.Operations {
+		if op.OperationIdentifier.Index != int64(i) {
+			t.Errorf("ConstructionParse() operation %d has index %d", i, op.OperationIdentifier.Index)
+		}
+	}
+
+	hash, rosettaErr := service.ConstructionHash(ctx, &types.ConstructionHashRequest{
+		NetworkIdentifier: network,
+		SignedTransaction: combined.SignedTransaction,
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionHash() error = %v", rosettaErr)
+	}
+	hashes, _ := hash.Metadata[batchHashesMetadataKey].([]string)
+	if len(hashes) != 2 || hashes[0] != hash.TransactionIdentifier.Hash || hashes[0] == hashes[1] {
+		t.Errorf("ConstructionHash() = %v with hashes %v", hash.TransactionIdentifier.Hash, hashes)
+	}
+
+	submitted, rosettaErr := service.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: network,
+		SignedTransaction: combined.SignedTransaction,
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionSubmit() error = %v", rosettaErr)
+	}
+	if hashes, _ := submitted.Metadata[batchHashesMetadataKey].([]string); len(hashes) != 2 {
+		t.Errorf("ConstructionSubmit() hashes = %v, want both transactions", submitted.Metadata)
+	}
+
+	mockClient.SetMockError(errors.New("node unavailable"))
+	if _, rosettaErr := service.ConstructionSubmit(ctx, &types.ConstructionSubmitRequest{
+		NetworkIdentifier: network,
+		SignedTransaction: combined.SignedTransaction,
+	}); rosettaErr == nil || rosettaErr.Code != meshcommon.ErrFailedToSubmitTransaction || rosettaErr.Details["submitted"] == nil {
+		t.Errorf("ConstructionSubmit() error = %v, want submission error listing the submitted transactions", rosettaErr)
+	}
+}
+
+func TestConstructionService_Batch_MissingPublicKey(t *testing.T) {
+	service := createMockConstructionService()
+	service.vechainClient.(*meshthor.MockVeChainClient).SetInspectClausesResult([]*api.CallResult{{GasUsed: 0}})
+	ctx := context.Background()
+	network := createTestNetworkIdentifier(meshcommon.TestNetwork)
+
+	preprocess, rosettaErr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: network,
+		Operations:        createBatchOperations(),
+		Metadata: map[string]any{
+			meshcommon.BatchMetadataKey: true,
+			"transactionType":           meshcommon.TransactionTypeLegacy,
+		},
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionPreprocess() error = %v", rosettaErr)
+	}
+	metadata, rosettaErr := service.ConstructionMetadata(ctx, &types.ConstructionMetadataRequest{
+		NetworkIdentifier: network,
+		Options:           roundTripJSON(t, preprocess.Options),
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionMetadata() error = %v", rosettaErr)
+	}
+
+	privateKey, _ := ethcrypto.HexToECDSA(meshtests.TestAddress1PrivateKey)
+	if _, rosettaErr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: network,
+		Operations:        createBatchOperations(),
+		Metadata:          roundTripJSON(t, metadata.Metadata),
+		PublicKeys: []*types.PublicKey{{
+			Bytes:     ethcrypto.CompressPubkey(&privateKey.PublicKey),
+			CurveType: meshtests.SECP256k1,
+		}},
+	}); rosettaErr == nil || rosettaErr.Code != meshcommon.ErrInvalidPublicKeyFormat {
+		t.Errorf("ConstructionPayloads() error = %v, want missing public key error", rosettaErr)
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Fee options accepted in the options of /construction/metadata, and in the metadata of
+// /construction/preprocess which forwards them
+const (
+	feeOptionPercentile    = "feePercentile"
+	feeOptionHistoryBlocks = "feeHistoryBlocks"
+	feeOptionMaxFeePerGas  = "maxFeePerGas"
+	feeOptionGasPriceCoef  = "gasPriceCoef"
+)
+
+const (
+	defaultFeePercentile    = 50
+	defaultFeeHistoryBlocks = 10
+	maxFeeHistoryBlocks     = 1024
+
+	// baseFeeHeadroomMultiplier is applied to the current base fee when computing maxFeePerGas,
+	// so that transactions stay includable while the base fee rises
+	baseFeeHeadroomMultiplier = 2
+)
+
+// forwardedMetadataKeys are the preprocess metadata keys forwarded to the metadata options
+var forwardedMetadataKeys = []string{
+	"transactionType",
+	"expiration",
+	"dependsOn",
+	feeOptionPercentile,
+	feeOptionHistoryBlocks,
+	feeOptionMaxFeePerGas,
+	feeOptionGasPriceCoef,
+}
+
+// feeOptions holds the fee strategy requested by the caller
+type feeOptions struct {
+	percentile    float64
+	historyBlocks uint32
+	maxFeePerGas  *big.Int
+	gasPriceCoef  *uint8
+}
+
+// parseFeeOptions parses the fee strategy from the options of a metadata request, applying defaults
+func parseFeeOptions(options map[string]any) (*feeOptions, error) {
+	fees := &feeOptions{
+		percentile:    defaultFeePercentile,
+		historyBlocks: defaultFeeHistoryBlocks,
+	}
+
+	if raw, ok := options[feeOptionPercentile]; ok {
+		percentile, ok := raw.(float64)
+		if !ok || percentile < 0 || percentile > 100 {
+			return nil, fmt.Errorf("%s must be a number in [0,100]", feeOptionPercentile)
+		}
+		fees.percentile = percentile
+	}
+
+	if raw, ok := options[feeOptionHistoryBlocks]; ok {
+		blocks, ok := raw.(float64)
+		if !ok || blocks != math.Trunc(blocks) || blocks < 1 || blocks > maxFeeHistoryBlocks {
+			return nil, fmt.Errorf("%s must be an integer in [1,%d]", feeOptionHistoryBlocks, maxFeeHistoryBlocks)
+		}
+		fees.historyBlocks = uint32(blocks)
+	}
+
+	if raw, ok := options[feeOptionMaxFeePerGas]; ok {
+		maxFeeStr, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a string", feeOptionMaxFeePerGas)
+		}
+		maxFee, ok := new(big.Int).SetString(maxFeeStr, 10)
+		if !ok || maxFee.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid %s: %s", feeOptionMaxFeePerGas, maxFeeStr)
+		}
+		fees.maxFeePerGas = maxFee
+	}
+
+	if raw, ok := options[feeOptionGasPriceCoef]; ok {
+		coef, ok := raw.(float64)
+		if !ok || coef != math.Trunc(coef) || coef < 0 || coef > math.MaxUint8 {
+			return nil, fmt.Errorf("%s must be an integer in [0,255]", feeOptionGasPriceCoef)
+		}
+		gasPriceCoef := uint8(coef)
+		fees.gasPriceCoef = &gasPriceCoef
+	}
+
+	return fees, nil
+}
+
+// averageReward returns the average of the single reward percentile reported for each block
+func averageReward(rewards [][]*hexutil.Big) *big.Int {
+	sum := big.NewInt(0)
+	count := int64(0)
+	for _, blockRewards := range rewards {
+		if len(blockRewards) == 0 || blockRewards[0] == nil {
+			continue
+		}
+		sum.Add(sum, blockRewards[0].ToInt())
+		count++
+	}
+	if count == 0 {
+		return sum
+	}
+	return sum.Div(sum, big.NewInt(count))
+}
+
+// legacyGasPrice returns the gas price of a legacy transaction, baseGasPrice * (1 + gasPriceCoef/255)
+func legacyGasPrice(baseGasPrice *big.Int, gasPriceCoef uint8) *big.Int {
+	gasPrice := new(big.Int).Mul(baseGasPrice, big.NewInt(int64(gasPriceCoef)))
+	gasPrice.Div(gasPrice, big.NewInt(math.MaxUint8))
+	return gasPrice.Add(gasPrice, baseGasPrice)
+}
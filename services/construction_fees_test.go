@@ -0,0 +1,103 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestParseFeeOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]any
+		check   func(t *testing.T, fees *feeOptions)
+		wantErr bool
+	}{
+		{
+			name:    "defaults",
+			options: map[string]any{},
+			check: func(t *testing.T, fees *feeOptions) {
+				if fees.percentile != defaultFeePercentile || fees.historyBlocks != defaultFeeHistoryBlocks {
+					t.Errorf("parseFeeOptions() = %+v, want defaults", fees)
+				}
+				if fees.maxFeePerGas != nil || fees.gasPriceCoef != nil {
+					t.Errorf("parseFeeOptions() = %+v, want no cap and no coefficient", fees)
+				}
+			},
+		},
+		{
+			name: "all options",
+			options: map[string]any{
+				feeOptionPercentile:    float64(90),
+				feeOptionHistoryBlocks: float64(20),
+				feeOptionMaxFeePerGas:  "1000",
+				feeOptionGasPriceCoef:  float64(128),
+			},
+			check: func(t *testing.T, fees *feeOptions) {
+				if fees.percentile != 90 || fees.historyBlocks != 20 {
+					t.Errorf("parseFeeOptions() = %+v", fees)
+				}
+				if fees.maxFeePerGas == nil || fees.maxFeePerGas.Int64() != 1000 {
+					t.Errorf("parseFeeOptions() maxFeePerGas = %v, want 1000", fees.maxFeePerGas)
+				}
+				if fees.gasPriceCoef == nil || *fees.gasPriceCoef != 128 {
+					t.Errorf("parseFeeOptions() gasPriceCoef = %v, want 128", fees.gasPriceCoef)
+				}
+			},
+		},
+		{name: "percentile out of range", options: map[string]any{feeOptionPercentile: float64(101)}, wantErr: true},
+		{name: "fractional history blocks", options: map[string]any{feeOptionHistoryBlocks: 1.5}, wantErr: true},
+		{name: "history blocks too large", options: map[string]any{feeOptionHistoryBlocks: float64(maxFeeHistoryBlocks + 1)}, wantErr: true},
+		{name: "numeric max fee", options: map[string]any{feeOptionMaxFeePerGas: float64(1000)}, wantErr: true},
+		{name: "zero max fee", options: map[string]any{feeOptionMaxFeePerGas: "0"}, wantErr: true},
+		{name: "gas price coefficient out of range", options: map[string]any{feeOptionGasPriceCoef: float64(256)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fees, err := parseFeeOptions(tt.options)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseFeeOptions() expected error, got %+v", fees)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFeeOptions() error = %v", err)
+			}
+			tt.check(t, fees)
+		})
+	}
+}
+
+func TestAverageReward(t *testing.T) {
+	rewards := [][]*hexutil.Big{
+		{(*hexutil.Big)(big.NewInt(100))},
+		{},
+		{(*hexutil.Big)(big.NewInt(300))},
+	}
+	if reward := averageReward(rewards); reward.Int64() != 200 {
+		t.Errorf("averageReward() = %v, want 200", reward)
+	}
+	if reward := averageReward(nil); reward.Sign() != 0 {
+		t.Errorf("averageReward() = %v, want 0", reward)
+	}
+}
+
+func TestLegacyGasPrice(t *testing.T) {
+	baseGasPrice := big.NewInt(255000)
+	tests := []struct {
+		coef     uint8
+		expected int64
+	}{
+		{0, 255000},
+		{128, 383000},
+		{255, 510000},
+	}
+	for _, tt := range tests {
+		if gasPrice := legacyGasPrice(baseGasPrice, tt.coef); gasPrice.Int64() != tt.expected {
+			t.Errorf("legacyGasPrice(%d) = %v, want %d", tt.coef, gasPrice, tt.expected)
+		}
+	}
+}
@@ -2,21 +2,25 @@ package services
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math"
 	"math/big"
+	"strings"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	ethmath "github.com/ethereum/go-ethereum/common/math"
 	meshcommon "github.com/vechain/mesh/common"
 	meshcrypto "github.com/vechain/mesh/common/crypto"
+	"github.com/vechain/mesh/common/delegation"
 	meshoperations "github.com/vechain/mesh/common/operations"
 	meshtx "github.com/vechain/mesh/common/tx"
 	"github.com/vechain/mesh/common/vip180"
 	"github.com/vechain/mesh/config"
 	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient/bind"
 	"github.com/vechain/thor/v2/tx"
 )
 
@@ -30,20 +34,30 @@ type ConstructionService struct {
 	operationsExtractor *meshoperations.OperationsExtractor
 	vip180Encoder       *vip180.VIP180Encoder
 	clauseParser        *meshoperations.ClauseParser
+	// delegationClient requests gas payer signatures from the configured VIP-201 service, nil when not configured
+	delegationClient *delegation.Client
+	// tracker watches the submitted transactions, nil when not tracking
+	tracker *TxTracker
 }
 
-// NewConstructionService creates a new construction service
-func NewConstructionService(vechainClient meshthor.VeChainClientInterface, config *config.Config) *ConstructionService {
-	operationsExtractor := meshoperations.NewOperationsExtractor()
+// NewConstructionService creates a new construction service, tracker can be nil
+func NewConstructionService(vechainClient meshthor.VeChainClientInterface, config *config.Config, tracker *TxTracker) *ConstructionService {
+	operationsExtractor := meshoperations.NewOperationsExtractor(config.TokenRegistry)
+	var delegationClient *delegation.Client
+	if config.DelegatorURL != "" {
+		delegationClient = delegation.NewClient(config.DelegatorURL)
+	}
 	return &ConstructionService{
 		vechainClient:       vechainClient,
-		encoder:             meshtx.NewMeshTransactionEncoder(vechainClient),
+		encoder:             meshtx.NewMeshTransactionEncoder(vechainClient, config.TokenRegistry),
 		builder:             meshtx.NewTransactionBuilder(),
 		config:              config,
 		bytesHandler:        meshcrypto.NewBytesHandler(),
 		operationsExtractor: operationsExtractor,
 		vip180Encoder:       vip180.NewVIP180Encoder(),
 		clauseParser:        meshoperations.NewClauseParser(vechainClient, operationsExtractor),
+		delegationClient:    delegationClient,
+		tracker:             tracker,
 	}
 }
 
@@ -80,6 +94,26 @@ func (c *ConstructionService) ConstructionPreprocess(
 	ctx context.Context,
 	req *types.ConstructionPreprocessRequest,
 ) (*types.ConstructionPreprocessResponse, *types.Error) {
+	// Validate the expiration and dependency requested for the transaction
+	if _, err := meshtx.ParseExpiration(req.Metadata, c.config.Expiration); err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": err.Error(),
+		})
+	}
+	if _, err := meshtx.ParseDependsOn(req.Metadata); err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	// Get fee delegator from metadata
+	delegator := c.operationsExtractor.GetFeeDelegatorAccount(req.Metadata)
+
+	// Operations of several origins are built as one transaction per origin in batch mode
+	if batch, _ := req.Metadata[meshcommon.BatchMetadataKey].(bool); batch {
+		return c.preprocessBatch(req, delegator)
+	}
+
 	// Get transaction origins
 	origins := c.operationsExtractor.GetTxOrigins(req.Operations)
 	if len(origins) > 1 {
@@ -88,65 +122,141 @@ func (c *ConstructionService) ConstructionPreprocess(
 		return nil, meshcommon.GetError(meshcommon.ErrTransactionOriginNotExist)
 	}
 
-	// Get fee delegator from metadata
-	delegator := c.operationsExtractor.GetFeeDelegatorAccount(req.Metadata)
+	clauses, rosettaErr := c.preprocessClauses(req.Operations)
+	if rosettaErr != nil {
+		return nil, rosettaErr
+	}
 
-	// Get VET and token operations
-	vetOpers := c.operationsExtractor.GetVETOperations(req.Operations)
-	tokensOpers := c.operationsExtractor.GetTokensOperations(req.Operations)
+	// Build response, the origin and fee delegator are used to simulate the clauses
+	options := preprocessOptions(req.Metadata, delegator)
+	options["clauses"] = clauses
+	options["origin"] = origins[0]
+	response := &types.ConstructionPreprocessResponse{
+		Options: options,
+		RequiredPublicKeys: []*types.AccountIdentifier{
+			{Address: origins[0]},
+		},
+	}
 
-	// Validate operations
-	if len(vetOpers) == 0 && len(tokensOpers) == 0 {
-		return nil, meshcommon.GetError(meshcommon.ErrNoTransferOperation)
+	// Add delegator to required public keys if present
+	if delegator != "" && delegator != origins[0] {
+		response.RequiredPublicKeys = append(response.RequiredPublicKeys, &types.AccountIdentifier{
+			Address: delegator,
+		})
 	}
 
-	// Build clauses
-	var clauses []map[string]any
+	return response, nil
+}
 
-	// Add VET transfer clauses
-	for _, op := range vetOpers {
-		clauses = append(clauses, map[string]any{
-			"to":    op["to"],
-			"value": op["value"],
-			"data":  "0x",
+// preprocessClauses validates the operations of a transaction and returns its clauses
+func (c *ConstructionService) preprocessClauses(operations []*types.Operation) ([]map[string]any, *types.Error) {
+	// Get VET, token, contract call and contract deployment operations
+	vetOpers := c.operationsExtractor.GetVETOperations(operations)
+	tokensOpers := c.operationsExtractor.GetTokensOperations(operations)
+	contractCallOpers, err := c.operationsExtractor.GetContractCallOperations(operations)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": err.Error(),
+		})
+	}
+	contractDeployOpers, err := c.operationsExtractor.GetContractDeployOperations(operations)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": err.Error(),
 		})
 	}
 
-	// Add VIP180 token transfer clauses
-	for _, op := range tokensOpers {
-		// Encode VIP180 transfer call data
-		transferData, err := c.vip180Encoder.EncodeVIP180TransferCallData(op["to"], op["value"])
+	// Validate operations
+	if len(vetOpers) == 0 && len(tokensOpers) == 0 && len(contractCallOpers) == 0 && len(contractDeployOpers) == 0 {
+		return nil, meshcommon.GetError(meshcommon.ErrNoTransferOperation)
+	}
+
+	// Validate token currencies against the token registry
+	for _, op := range operations {
+		if op.Amount == nil || op.Amount.Currency == nil {
+			continue
+		}
+		if err := c.config.TokenRegistry.ValidateCurrency(op.Amount.Currency); err != nil {
+			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrUnsupportedToken, map[string]any{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	// Build clauses in operation index order, which is the order of the clauses in the transaction
+	var clauses []map[string]any
+	for _, op := range meshoperations.SortOperations(operations) {
+		clause, err := c.operationClause(op)
 		if err != nil {
 			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInternalServerError, map[string]any{
 				"error": err.Error(),
 			})
 		}
+		if clause != nil {
+			clauses = append(clauses, clause)
+		}
+	}
+	return clauses, nil
+}
 
-		clauses = append(clauses, map[string]any{
-			"to":    op["token"],
-			"value": "0",
-			"data":  transferData,
-		})
+// preprocessOptions returns the options shared by the transactions of a preprocess request
+func preprocessOptions(metadata map[string]any, delegator string) map[string]any {
+	options := map[string]any{}
+	if delegator != "" {
+		options[meshcommon.DelegatorAccountMetadataKey] = delegator
 	}
+	for _, key := range forwardedMetadataKeys {
+		if value, ok := metadata[key]; ok {
+			options[key] = value
+		}
+	}
+	return options
+}
 
-	// Build response
-	response := &types.ConstructionPreprocessResponse{
-		Options: map[string]any{
-			"clauses": clauses,
-		},
-		RequiredPublicKeys: []*types.AccountIdentifier{
-			{Address: origins[0]},
-		},
+// operationClause returns the clause added to the transaction by an operation, nil for operations without clause
+func (c *ConstructionService) operationClause(op *types.Operation) (map[string]any, error) {
+	single := []*types.Operation{op}
+
+	// VET transfer
+	if vetOpers := c.operationsExtractor.GetVETOperations(single); len(vetOpers) > 0 {
+		return map[string]any{
+			"to":    vetOpers[0]["to"],
+			"value": vetOpers[0]["value"],
+			"data":  "0x",
+		}, nil
 	}
 
-	// Add delegator to required public keys if present
-	if delegator != "" && delegator != origins[0] {
-		response.RequiredPublicKeys = append(response.RequiredPublicKeys, &types.AccountIdentifier{
-			Address: delegator,
-		})
+	// VIP180 token transfer
+	if tokensOpers := c.operationsExtractor.GetTokensOperations(single); len(tokensOpers) > 0 {
+		transferData, err := c.vip180Encoder.EncodeVIP180TransferCallData(tokensOpers[0]["to"], tokensOpers[0]["value"])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"to":    tokensOpers[0]["token"],
+			"value": "0",
+			"data":  transferData,
+		}, nil
 	}
 
-	return response, nil
+	// Contract call or contract deployment
+	contractCallOpers, err := c.operationsExtractor.GetContractCallOperations(single)
+	if err != nil {
+		return nil, err
+	}
+	contractDeployOpers, err := c.operationsExtractor.GetContractDeployOperations(single)
+	if err != nil {
+		return nil, err
+	}
+	contractOpers := append(contractCallOpers, contractDeployOpers...)
+	if len(contractOpers) == 0 {
+		return nil, nil
+	}
+	return map[string]any{
+		"to":    contractOpers[0]["to"],
+		"value": contractOpers[0]["value"],
+		"data":  contractOpers[0]["data"],
+	}, nil
 }
 
 // ConstructionMetadata gets metadata for construction
@@ -154,17 +264,25 @@ func (c *ConstructionService) ConstructionMetadata(
 	ctx context.Context,
 	req *types.ConstructionMetadataRequest,
 ) (*types.ConstructionMetadataResponse, *types.Error) {
-	// Determine transaction type
-	transactionType := c.operationsExtractor.GetStringFromOptions(req.Options, "transactionType")
+	if batch, ok := req.Options[meshcommon.BatchMetadataKey]; ok {
+		return c.batchMetadata(ctx, req, batch)
+	}
 
-	// Calculate gas and create blockRef
-	gas, err := c.calculateGas(req.Options)
+	// Determine transaction type and fee strategy
+	transactionType := c.operationsExtractor.GetStringFromOptions(req.Options, "transactionType")
+	fees, err := parseFeeOptions(req.Options)
 	if err != nil {
-		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrGettingBlockchainMetadata, map[string]any{
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
 			"error": err.Error(),
 		})
 	}
 
+	// Estimate gas and create blockRef
+	gas, gasErr := c.calculateGas(req.Options)
+	if gasErr != nil {
+		return nil, gasErr
+	}
+
 	bestBlock, err := c.vechainClient.GetBlock("best")
 	if err != nil {
 		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrGettingBlockchainMetadata, map[string]any{
@@ -180,13 +298,20 @@ func (c *ConstructionService) ConstructionMetadata(
 	}
 
 	// Build metadata based on transaction type
-	metadata, gasPrice, err := c.buildMetadata(transactionType, fmt.Sprintf("0x%x", blockRef), c.config.ChainTag, gas, nonce)
+	metadata, gasPrice, err := c.buildMetadata(transactionType, fmt.Sprintf("0x%x", blockRef), c.config.ChainTag, gas, nonce, fees)
 	if err != nil {
 		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrGettingBlockchainMetadata, map[string]any{
 			"error": err.Error(),
 		})
 	}
 
+	// Expiration, dependency and fee delegator given in preprocess are passed on to payloads
+	for _, key := range []string{"expiration", "dependsOn", meshcommon.DelegatorAccountMetadataKey} {
+		if value, ok := req.Options[key]; ok {
+			metadata[key] = value
+		}
+	}
+
 	// Calculate fee and build response
 	if gas > math.MaxInt64 {
 		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInternalServerError, map[string]any{
@@ -212,6 +337,10 @@ func (c *ConstructionService) ConstructionPayloads(
 	ctx context.Context,
 	req *types.ConstructionPayloadsRequest,
 ) (*types.ConstructionPayloadsResponse, *types.Error) {
+	if batch, ok := req.Metadata[meshcommon.BatchMetadataKey]; ok {
+		return c.batchPayloads(ctx, req, batch)
+	}
+
 	// Get transaction origin from operations
 	origins := c.operationsExtractor.GetTxOrigins(req.Operations)
 	txOrigin := origins[0]
@@ -240,8 +369,16 @@ func (c *ConstructionService) ConstructionPayloads(
 		})
 	}
 
+	// Without the delegator public key, the gas payer signature is requested from the delegation service in combine
+	remoteDelegation := hasFeeDelegation && len(req.PublicKeys) == 1
+	if remoteDelegation && c.delegationClient == nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidPublicKeyFormat, map[string]any{
+			"error": "Delegator public key is required when no delegation service is configured",
+		})
+	}
+
 	// Validate delegator address if present
-	if hasFeeDelegation {
+	if hasFeeDelegation && !remoteDelegation {
 		delegatorAddress, err := c.bytesHandler.ComputeAddress(req.PublicKeys[1])
 		if err != nil {
 			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidPublicKeyFormat, map[string]any{
@@ -288,7 +425,15 @@ func (c *ConstructionService) ConstructionPayloads(
 	}
 
 	var delegatorBytes []byte
-	if hasFeeDelegation {
+	if remoteDelegation {
+		delegatorBytes, err = c.bytesHandler.DecodeHexStringWithPrefix(txDelegator)
+		if err != nil {
+			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+				"error":         err.Error(),
+				"delegatorAddr": txDelegator,
+			})
+		}
+	} else if hasFeeDelegation {
 		delegatorAddr, err := c.bytesHandler.ComputeAddress(req.PublicKeys[1])
 		if err != nil {
 			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidPublicKeyFormat, map[string]any{
@@ -327,6 +472,10 @@ func (c *ConstructionService) ConstructionParse(
 	ctx context.Context,
 	req *types.ConstructionParseRequest,
 ) (*types.ConstructionParseResponse, *types.Error) {
+	if isBatchTransaction(req.Transaction) {
+		return c.batchParse(ctx, req)
+	}
+
 	txBytes, err := c.bytesHandler.DecodeHexStringWithPrefix(req.Transaction)
 	if err != nil {
 		return nil, meshcommon.GetError(meshcommon.ErrInvalidTransactionHex)
@@ -341,20 +490,7 @@ func (c *ConstructionService) ConstructionParse(
 		return nil, meshcommon.GetError(meshcommon.ErrFailedToDecodeUnsignedTransaction)
 	}
 
-	// Calculate gas price based on transaction type
-	var gasPrice *big.Int
-	if meshTx.Type() == tx.TypeLegacy {
-		gasPrice = c.config.GetBaseGasPrice()
-	} else {
-		dynamicGasPrice, err := c.vechainClient.GetDynamicGasPrice()
-		if err != nil {
-			gasPrice = c.config.GetBaseGasPrice()
-		} else {
-			gasPrice = new(big.Int).Add(dynamicGasPrice.BaseFee, dynamicGasPrice.Reward)
-		}
-	}
-
-	// Calculate fee amount
+	// Calculate the maximum fee from the transaction fields alone, so that parsing works offline
 	gas := meshTx.Gas()
 	if gas > math.MaxInt64 {
 		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInternalServerError, map[string]any{
@@ -362,7 +498,12 @@ func (c *ConstructionService) ConstructionParse(
 		})
 	}
 	safeGas := int64(gas)
-	feeAmount := new(big.Int).Mul(big.NewInt(safeGas), gasPrice)
+	feeAmount := new(big.Int).Mul(big.NewInt(safeGas), meshTx.MaxGasPrice(c.config.GetBaseGasPrice()))
+
+	// The clause parser reports the gas limit as fee, it is replaced by the maximum fee
+	if count := len(operations); count > 0 && isFeeOperation(operations[count-1]) {
+		operations = operations[:count-1]
+	}
 
 	// Add fee operation
 	delegatorAddr := thor.BytesToAddress(meshTx.Delegator)
@@ -408,6 +549,9 @@ func (c *ConstructionService) ConstructionParse(
 		"gas":        meshTx.Gas(),
 		"nonce":      fmt.Sprintf("0x%x", meshTx.Nonce()),
 	}
+	if dependsOn := meshTx.DependsOn(); dependsOn != nil {
+		metadata["dependsOn"] = dependsOn.String()
+	}
 
 	if meshTx.Type() == tx.TypeLegacy {
 		metadata["gasPriceCoef"] = meshTx.GasPriceCoef()
@@ -434,6 +578,10 @@ func (c *ConstructionService) ConstructionCombine(
 	ctx context.Context,
 	req *types.ConstructionCombineRequest,
 ) (*types.ConstructionCombineResponse, *types.Error) {
+	if isBatchTransaction(req.UnsignedTransaction) {
+		return c.batchCombine(ctx, req)
+	}
+
 	txBytes, err := c.bytesHandler.DecodeHexStringWithPrefix(req.UnsignedTransaction)
 	if err != nil {
 		return nil, meshcommon.GetError(meshcommon.ErrInvalidUnsignedTransactionParameter)
@@ -455,6 +603,14 @@ func (c *ConstructionService) ConstructionCombine(
 		combinedSig := append(originSig.Bytes, delegatorSig.Bytes...)
 		meshTx.Transaction = meshTx.WithSignature(combinedSig)
 
+	} else if len(req.Signatures) == 1 && meshTx.Features().IsDelegated() {
+		// VIP191 Fee Delegation with the gas payer signature requested from the delegation service
+		combinedSig, rosettaErr := c.requestDelegatorSignature(meshTx, req.Signatures[0].Bytes)
+		if rosettaErr != nil {
+			return nil, rosettaErr
+		}
+		meshTx.Transaction = meshTx.WithSignature(combinedSig)
+
 	} else if len(req.Signatures) == 1 {
 		// Regular transaction: only origin signature
 		sig := req.Signatures[0]
@@ -474,11 +630,46 @@ func (c *ConstructionService) ConstructionCombine(
 	}, nil
 }
 
+// requestDelegatorSignature requests the gas payer signature of a delegated transaction from the delegation service.
+// It returns the origin signature followed by the gas payer signature, checked against the delegator of the transaction.
+func (c *ConstructionService) requestDelegatorSignature(meshTx *meshtx.MeshTransaction, originSig []byte) ([]byte, *types.Error) {
+	if c.delegationClient == nil {
+		return nil, meshcommon.GetError(meshcommon.ErrInvalidNumberOfSignatures)
+	}
+
+	origin := thor.BytesToAddress(meshTx.Origin)
+	delegatorSig, err := c.delegationClient.RequestSignature(origin, meshTx.Transaction)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrFeeDelegationFailed, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	combinedSig := append(append([]byte{}, originSig...), delegatorSig...)
+	delegator, err := meshTx.WithSignature(combinedSig).Delegator()
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrFeeDelegationFailed, map[string]any{
+			"error": err.Error(),
+		})
+	}
+	if expected := thor.BytesToAddress(meshTx.Delegator); len(meshTx.Delegator) > 0 && *delegator != expected {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrDelegatorAddressMismatch, map[string]any{
+			"expected": expected.String(),
+			"got":      delegator.String(),
+		})
+	}
+	return combinedSig, nil
+}
+
 // ConstructionHash gets the hash of a transaction
 func (c *ConstructionService) ConstructionHash(
 	ctx context.Context,
 	req *types.ConstructionHashRequest,
 ) (*types.TransactionIdentifierResponse, *types.Error) {
+	if isBatchTransaction(req.SignedTransaction) {
+		return c.batchHash(ctx, req)
+	}
+
 	txBytes, err := c.bytesHandler.DecodeHexStringWithPrefix(req.SignedTransaction)
 	if err != nil {
 		return nil, meshcommon.GetError(meshcommon.ErrInvalidTransactionHex)
@@ -501,6 +692,10 @@ func (c *ConstructionService) ConstructionSubmit(
 	ctx context.Context,
 	req *types.ConstructionSubmitRequest,
 ) (*types.TransactionIdentifierResponse, *types.Error) {
+	if isBatchTransaction(req.SignedTransaction) {
+		return c.batchSubmit(ctx, req)
+	}
+
 	txBytes, err := c.bytesHandler.DecodeHexStringWithPrefix(req.SignedTransaction)
 	if err != nil {
 		return nil, meshcommon.GetError(meshcommon.ErrInvalidTransactionHex)
@@ -519,6 +714,9 @@ func (c *ConstructionService) ConstructionSubmit(
 			"error": err.Error(),
 		})
 	}
+	if c.tracker != nil {
+		c.tracker.Track(meshTx.Transaction)
+	}
 
 	return &types.TransactionIdentifierResponse{
 		TransactionIdentifier: &types.TransactionIdentifier{
@@ -527,46 +725,128 @@ func (c *ConstructionService) ConstructionSubmit(
 	}, nil
 }
 
-// calculateGas calculates gas based on clauses and applies a 20% buffer
-func (c *ConstructionService) calculateGas(options map[string]any) (uint64, error) {
+// calculateGas estimates the gas of the clauses given in options by simulating them with InspectClauses.
+// The origin given in options is the caller and the fee delegator, if any, pays for the gas.
+// The estimate is the intrinsic gas plus the gas used by the clauses, increased by the configured margin.
+func (c *ConstructionService) calculateGas(options map[string]any) (uint64, *types.Error) {
 	clausesRaw, ok := options["clauses"]
 	if !ok {
-		return 0, fmt.Errorf("clauses are required for gas calculation")
+		return 0, meshcommon.GetErrorWithMetadata(meshcommon.ErrGettingBlockchainMetadata, map[string]any{
+			"error": "clauses are required for gas calculation",
+		})
 	}
 
 	txClauses, err := c.clauseParser.ParseClausesFromOptions(clausesRaw)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse clauses: %w", err)
+		return 0, meshcommon.GetErrorWithMetadata(meshcommon.ErrGettingBlockchainMetadata, map[string]any{
+			"error": fmt.Sprintf("failed to parse clauses: %v", err),
+		})
 	}
 
 	if len(txClauses) == 0 {
-		return 0, fmt.Errorf("at least one clause is required for a valid transaction")
+		return 0, meshcommon.GetErrorWithMetadata(meshcommon.ErrGettingBlockchainMetadata, map[string]any{
+			"error": "at least one clause is required for a valid transaction",
+		})
 	}
 
 	intrinsicGas, err := tx.IntrinsicGas(txClauses...)
 	if err != nil {
-		return 0, fmt.Errorf("failed to calculate intrinsic gas: %w", err)
+		return 0, meshcommon.GetErrorWithMetadata(meshcommon.ErrGettingBlockchainMetadata, map[string]any{
+			"error": fmt.Sprintf("failed to calculate intrinsic gas: %v", err),
+		})
+	}
+
+	batchCallData, err := c.buildSimulationCallData(txClauses, options)
+	if err != nil {
+		return 0, meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	results, err := c.vechainClient.InspectClauses(batchCallData)
+	if err != nil {
+		return 0, meshcommon.GetErrorWithMetadata(meshcommon.ErrGettingBlockchainMetadata, map[string]any{
+			"error": fmt.Sprintf("failed to simulate clauses: %v", err),
+		})
+	}
+
+	executionGas := uint64(0)
+	for i, result := range results {
+		if result.Reverted || result.VMError != "" {
+			metadata := map[string]any{
+				"clauseIndex": i,
+				"vmError":     result.VMError,
+			}
+			if reason := decodeRevertReason(result.Data); reason != "" {
+				metadata["revertReason"] = reason
+			}
+			return 0, meshcommon.GetErrorWithMetadata(meshcommon.ErrClauseSimulationReverted, metadata)
+		}
+		executionGas += result.GasUsed
+	}
+
+	gas := intrinsicGas + executionGas
+	return gas + gas*c.config.GasEstimationMargin/100, nil
+}
+
+// buildSimulationCallData builds the InspectClauses request simulating the clauses of a transaction
+func (c *ConstructionService) buildSimulationCallData(txClauses []*tx.Clause, options map[string]any) (*api.BatchCallData, error) {
+	batchCallData := &api.BatchCallData{Clauses: make(api.Clauses, len(txClauses))}
+	for i, clause := range txClauses {
+		batchCallData.Clauses[i] = &api.Clause{
+			To:    clause.To(),
+			Value: (*ethmath.HexOrDecimal256)(clause.Value()),
+			Data:  "0x" + hex.EncodeToString(clause.Data()),
+		}
 	}
 
-	return uint64(float64(intrinsicGas) * 1.2), nil
+	if origin, ok := options["origin"].(string); ok && origin != "" {
+		caller, err := thor.ParseAddress(origin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid origin: %w", err)
+		}
+		batchCallData.Caller = &caller
+	}
+
+	if delegator, ok := options[meshcommon.DelegatorAccountMetadataKey].(string); ok && delegator != "" {
+		gasPayer, err := thor.ParseAddress(delegator)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee delegator: %w", err)
+		}
+		batchCallData.GasPayer = &gasPayer
+	}
+
+	return batchCallData, nil
 }
 
-// buildMetadata builds metadata based on transaction type
-func (c *ConstructionService) buildMetadata(transactionType, blockRef string, chainTag byte, gas uint64, nonce string) (map[string]any, *big.Int, error) {
+// decodeRevertReason decodes the reason of a reverted clause from its output data, if any
+func decodeRevertReason(data string) string {
+	dataBytes, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil || len(dataBytes) == 0 {
+		return ""
+	}
+	reason, err := bind.UnpackRevert(dataBytes)
+	if err != nil {
+		return ""
+	}
+	return reason
+}
+
+// buildMetadata builds metadata based on transaction type.
+// The returned gas price is the highest price the transaction can be charged.
+func (c *ConstructionService) buildMetadata(transactionType, blockRef string, chainTag byte, gas uint64, nonce string, fees *feeOptions) (map[string]any, *big.Int, error) {
 	if transactionType == meshcommon.TransactionTypeLegacy {
-		return c.buildLegacyMetadata(blockRef, chainTag, gas, nonce)
+		return c.buildLegacyMetadata(blockRef, chainTag, gas, nonce, fees)
 	}
-	return c.buildDynamicMetadata(blockRef, chainTag, gas, nonce)
+	return c.buildDynamicMetadata(blockRef, chainTag, gas, nonce, fees)
 }
 
-// buildLegacyMetadata builds metadata for legacy transactions
-func (c *ConstructionService) buildLegacyMetadata(blockRef string, chainTag byte, gas uint64, nonce string) (map[string]any, *big.Int, error) {
-	// Generate random gasPriceCoef (0-255)
-	randomBytes := make([]byte, 1)
-	if _, err := rand.Read(randomBytes); err != nil {
-		return nil, nil, fmt.Errorf("failed to generate random gas price coefficient: %w", err)
+// buildLegacyMetadata builds metadata for legacy transactions, using the requested gasPriceCoef or the configured one
+func (c *ConstructionService) buildLegacyMetadata(blockRef string, chainTag byte, gas uint64, nonce string, fees *feeOptions) (map[string]any, *big.Int, error) {
+	gasPriceCoef := c.config.GasPriceCoef
+	if fees.gasPriceCoef != nil {
+		gasPriceCoef = *fees.gasPriceCoef
 	}
-	gasPriceCoef := randomBytes[0]
 
 	metadata := map[string]any{
 		"transactionType": meshcommon.TransactionTypeLegacy,
@@ -577,18 +857,25 @@ func (c *ConstructionService) buildLegacyMetadata(blockRef string, chainTag byte
 		"gasPriceCoef":    gasPriceCoef,
 	}
 
-	return metadata, c.config.GetBaseGasPrice(), nil
+	return metadata, legacyGasPrice(c.config.GetBaseGasPrice(), gasPriceCoef), nil
 }
 
-// buildDynamicMetadata builds metadata for dynamic fee transactions
-func (c *ConstructionService) buildDynamicMetadata(blockRef string, chainTag byte, gas uint64, nonce string) (map[string]any, *big.Int, error) {
-	// Get dynamic gas price from network
-	dynamicGasPrice, err := c.vechainClient.GetDynamicGasPrice()
+// buildDynamicMetadata builds metadata for dynamic fee transactions from the fee history of the latest blocks.
+// The priority fee is the average of the requested reward percentile over the history window, and
+// maxFeePerGas leaves room for the base fee to double before the transaction is included, within the
+// requested cap.
+func (c *ConstructionService) buildDynamicMetadata(blockRef string, chainTag byte, gas uint64, nonce string, fees *feeOptions) (map[string]any, *big.Int, error) {
+	feesHistory, err := c.vechainClient.GetFeesHistory(fees.historyBlocks, fees.percentile)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get dynamic gas price: %w", err)
+		return nil, nil, fmt.Errorf("failed to get fees history: %w", err)
+	}
+
+	baseFee := big.NewInt(0)
+	if count := len(feesHistory.BaseFeePerGas); count > 0 && feesHistory.BaseFeePerGas[count-1] != nil {
+		baseFee = feesHistory.BaseFeePerGas[count-1].ToInt()
 	}
 
-	if dynamicGasPrice.BaseFee.Cmp(big.NewInt(0)) == 0 {
+	if baseFee.Sign() == 0 {
 		// Case where we are building a dynamic fee transaction but the base fee is 0
 		// This happens when the node is catching up with the chain block-wise
 		metadata := map[string]any{
@@ -603,19 +890,33 @@ func (c *ConstructionService) buildDynamicMetadata(blockRef string, chainTag byt
 		return metadata, c.config.GetBaseGasPrice(), nil
 	}
 
-	// Normal case: use actual base fee and reward
-	gasPrice := new(big.Int).Add(dynamicGasPrice.BaseFee, dynamicGasPrice.Reward)
+	priorityFee := averageReward(feesHistory.Reward)
+	maxFee := new(big.Int).Mul(baseFee, big.NewInt(baseFeeHeadroomMultiplier))
+	maxFee.Add(maxFee, priorityFee)
+
+	if fees.maxFeePerGas != nil {
+		if fees.maxFeePerGas.Cmp(baseFee) < 0 {
+			return nil, nil, fmt.Errorf("maxFeePerGas cap %s is below the current base fee %s", fees.maxFeePerGas, baseFee)
+		}
+		if maxFee.Cmp(fees.maxFeePerGas) > 0 {
+			maxFee = new(big.Int).Set(fees.maxFeePerGas)
+		}
+		if priorityFee.Cmp(maxFee) > 0 {
+			priorityFee = new(big.Int).Set(maxFee)
+		}
+	}
+
 	metadata := map[string]any{
 		"transactionType":      meshcommon.TransactionTypeDynamic,
 		"blockRef":             blockRef,
 		"chainTag":             chainTag,
 		"gas":                  gas,
 		"nonce":                nonce,
-		"maxFeePerGas":         gasPrice.String(),
-		"maxPriorityFeePerGas": dynamicGasPrice.Reward.String(),
+		"maxFeePerGas":         maxFee.String(),
+		"maxPriorityFeePerGas": priorityFee.String(),
 	}
 
-	return metadata, gasPrice, nil
+	return metadata, maxFee, nil
 }
 
 // createSigningPayloads creates signing payloads for the transaction
@@ -690,3 +991,8 @@ func (c *ConstructionService) createDelegatorPayload(vechainTx *tx.Transaction,
 		SignatureType: types.EcdsaRecovery,
 	}, nil
 }
+
+// isFeeOperation reports whether an operation debits the transaction fee
+func isFeeOperation(op *types.Operation) bool {
+	return op.Type == meshcommon.OperationTypeFee || op.Type == meshcommon.OperationTypeFeeDelegation
+}
@@ -3,16 +3,23 @@ package services
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	meshcommon "github.com/vechain/mesh/common"
+	meshcrypto "github.com/vechain/mesh/common/crypto"
 	meshtx "github.com/vechain/mesh/common/tx"
 	meshconfig "github.com/vechain/mesh/config"
 	meshtests "github.com/vechain/mesh/tests"
 	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/thor"
 	thortx "github.com/vechain/thor/v2/tx"
 )
@@ -25,7 +32,7 @@ func createMockConstructionService() *ConstructionService {
 		Mode:         meshcommon.OnlineMode,
 		BaseGasPrice: "1000000000000000000",
 	}
-	return NewConstructionService(mockClient, config)
+	return NewConstructionService(mockClient, config, nil)
 }
 
 func createTestPublicKey() *types.PublicKey {
@@ -50,7 +57,7 @@ func TestNewConstructionService(t *testing.T) {
 		Mode:         meshcommon.OnlineMode,
 		BaseGasPrice: "1000000000000000000", // 1 VTHO
 	}
-	service := NewConstructionService(mockClient, config)
+	service := NewConstructionService(mockClient, config, nil)
 
 	if service == nil {
 		t.Errorf("NewConstructionService() returned nil")
@@ -138,7 +145,15 @@ func TestConstructionService_ConstructionPreprocess_ValidRequest(t *testing.T) {
 
 	// Verify response structure
 	if response.Options == nil {
-		t.Errorf("ConstructionPreprocess() options is nil")
+		t.Fatalf("ConstructionPreprocess() options is nil")
+	}
+	// The origin is the caller when simulating the clauses
+	if response.Options["origin"] != meshtests.FirstSoloAddress {
+		t.Errorf("ConstructionPreprocess() origin = %v, want %v", response.Options["origin"], meshtests.FirstSoloAddress)
+	}
+	// The transaction type is forwarded to the metadata request
+	if response.Options["transactionType"] != meshcommon.TransactionTypeLegacy {
+		t.Errorf("ConstructionPreprocess() transactionType = %v, want %v", response.Options["transactionType"], meshcommon.TransactionTypeLegacy)
 	}
 }
 
@@ -239,6 +254,123 @@ func TestConstructionService_ConstructionPreprocess_VIP180Token(t *testing.T) {
 	}
 }
 
+func TestConstructionService_ConstructionPreprocess_ClauseOrder(t *testing.T) {
+	service := createMockConstructionService()
+	tokenCurrency := &types.Currency{
+		Symbol:   "TVIP",
+		Decimals: 18,
+		Metadata: map[string]any{"contractAddress": "0x1234567890123456789012345678901234567890"},
+	}
+
+	// Operations are listed out of order, clauses follow the operation indexes
+	response, err := service.ConstructionPreprocess(context.Background(), &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Operations: []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 3},
+				Type:                meshcommon.OperationTypeTransfer,
+				Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+				Amount:              &types.Amount{Value: "-1000000000000000000", Currency: meshcommon.VETCurrency},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 2},
+				Type:                meshcommon.OperationTypeTransfer,
+				Account:             &types.AccountIdentifier{Address: meshtests.TestAddress1},
+				Amount:              &types.Amount{Value: "1000000000000000000", Currency: meshcommon.VETCurrency},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				Type:                meshcommon.OperationTypeTransfer,
+				Account:             &types.AccountIdentifier{Address: meshtests.TestAddress1},
+				Amount:              &types.Amount{Value: "5", Currency: tokenCurrency},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                meshcommon.OperationTypeTransfer,
+				Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+				Amount:              &types.Amount{Value: "-5", Currency: tokenCurrency},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ConstructionPreprocess() error = %v", err)
+	}
+
+	clauses := response.Options["clauses"].([]map[string]any)
+	if len(clauses) != 2 {
+		t.Fatalf("ConstructionPreprocess() clauses = %v, want 2 clauses", clauses)
+	}
+	if clauses[0]["to"] != "0x1234567890123456789012345678901234567890" || clauses[0]["value"] != "0" {
+		t.Errorf("ConstructionPreprocess() first clause = %v, want token transfer", clauses[0])
+	}
+	if clauses[1]["to"] != meshtests.TestAddress1 || clauses[1]["value"] != "1000000000000000000" {
+		t.Errorf("ConstructionPreprocess() second clause = %v, want VET transfer", clauses[1])
+	}
+}
+
+func TestConstructionService_ConstructionPreprocess_ExpirationAndDependsOn(t *testing.T) {
+	service := createMockConstructionService()
+	dependsOn := "0x" + strings.Repeat("ab", 32)
+	operations := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                meshcommon.OperationTypeTransfer,
+			Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+			Amount:              &types.Amount{Value: "-1000000000000000000", Currency: meshcommon.VETCurrency},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			Type:                meshcommon.OperationTypeTransfer,
+			Account:             &types.AccountIdentifier{Address: meshtests.TestAddress1},
+			Amount:              &types.Amount{Value: "1000000000000000000", Currency: meshcommon.VETCurrency},
+		},
+	}
+
+	response, err := service.ConstructionPreprocess(context.Background(), &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Operations:        operations,
+		Metadata:          map[string]any{"expiration": float64(18), "dependsOn": dependsOn},
+	})
+	if err != nil {
+		t.Fatalf("ConstructionPreprocess() error = %v", err)
+	}
+	if response.Options["expiration"] != float64(18) || response.Options["dependsOn"] != dependsOn {
+		t.Errorf("ConstructionPreprocess() options = %v, want expiration and dependsOn", response.Options)
+	}
+
+	// Both are passed on by metadata, options are received as JSON
+	var options map[string]any
+	optionsJSON, _ := json.Marshal(response.Options)
+	if err := json.Unmarshal(optionsJSON, &options); err != nil {
+		t.Fatalf("failed to decode options: %v", err)
+	}
+	metadata, err := service.ConstructionMetadata(context.Background(), &types.ConstructionMetadataRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Options:           options,
+	})
+	if err != nil {
+		t.Fatalf("ConstructionMetadata() error = %v", err)
+	}
+	if metadata.Metadata["expiration"] != float64(18) || metadata.Metadata["dependsOn"] != dependsOn {
+		t.Errorf("ConstructionMetadata() metadata = %v, want expiration and dependsOn", metadata.Metadata)
+	}
+
+	for _, invalid := range []map[string]any{
+		{"expiration": float64(0)},
+		{"expiration": "18"},
+		{"dependsOn": "0x1234"},
+	} {
+		_, err := service.ConstructionPreprocess(context.Background(), &types.ConstructionPreprocessRequest{
+			NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+			Operations:        operations,
+			Metadata:          invalid,
+		})
+		if err == nil || err.Code != int32(meshcommon.ErrInvalidRequestParameters) {
+			t.Errorf("ConstructionPreprocess(%v) error = %v, want invalid request parameters", invalid, err)
+		}
+	}
+}
+
 func TestConstructionService_ConstructionMetadata_ValidRequest(t *testing.T) {
 	service := createMockConstructionService()
 
@@ -286,6 +418,99 @@ func TestConstructionService_ConstructionMetadata_ValidRequest(t *testing.T) {
 	}
 }
 
+func TestConstructionService_ConstructionMetadata_SimulatedGas(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	mockClient.SetInspectClausesResult([]*api.CallResult{{GasUsed: 30000}})
+	service := NewConstructionService(mockClient, &meshconfig.Config{
+		Network:             meshcommon.TestNetwork,
+		Mode:                meshcommon.OnlineMode,
+		BaseGasPrice:        "1000000000000000000",
+		GasEstimationMargin: 10,
+	}, nil)
+
+	request := &types.ConstructionMetadataRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Options: map[string]any{
+			"transactionType":                      meshcommon.TransactionTypeLegacy,
+			"origin":                               meshtests.FirstSoloAddress,
+			meshcommon.DelegatorAccountMetadataKey: meshtests.TestAddress1,
+			"clauses": []any{
+				map[string]any{
+					"to":    meshtests.TestAddress1,
+					"value": "0",
+					"data":  "0xd09de08a",
+				},
+			},
+		},
+	}
+
+	response, err := service.ConstructionMetadata(context.Background(), request)
+	if err != nil {
+		t.Fatalf("ConstructionMetadata() error = %v", err)
+	}
+
+	// Intrinsic gas of a call with 4 non-zero data bytes, plus the simulated gas and a 10% margin
+	intrinsicGas := uint64(21000 + 4*68)
+	expectedGas := (intrinsicGas + 30000) * 110 / 100
+	if response.Metadata["gas"] != expectedGas {
+		t.Errorf("ConstructionMetadata() gas = %v, want %v", response.Metadata["gas"], expectedGas)
+	}
+
+	batchCallData := mockClient.LastBatchCallData
+	if batchCallData == nil || len(batchCallData.Clauses) != 1 {
+		t.Fatalf("InspectClauses() called with %v", batchCallData)
+	}
+	if batchCallData.Caller == nil || batchCallData.Caller.String() != meshtests.FirstSoloAddress {
+		t.Errorf("InspectClauses() caller = %v, want %v", batchCallData.Caller, meshtests.FirstSoloAddress)
+	}
+	if batchCallData.GasPayer == nil || batchCallData.GasPayer.String() != meshtests.TestAddress1 {
+		t.Errorf("InspectClauses() gas payer = %v, want %v", batchCallData.GasPayer, meshtests.TestAddress1)
+	}
+	if batchCallData.Clauses[0].Data != "0xd09de08a" {
+		t.Errorf("InspectClauses() clause data = %v, want 0xd09de08a", batchCallData.Clauses[0].Data)
+	}
+}
+
+func TestConstructionService_ConstructionMetadata_SimulationReverted(t *testing.T) {
+	reason := "not owner"
+	revertData := "0x08c379a0" + fmt.Sprintf("%064x%064x", 32, len(reason)) + hex.EncodeToString([]byte(reason)) + strings.Repeat("0", 64-2*len(reason))
+
+	mockClient := meshthor.NewMockVeChainClient()
+	mockClient.SetInspectClausesResult([]*api.CallResult{
+		{GasUsed: 1000},
+		{Data: revertData, GasUsed: 500, Reverted: true, VMError: "execution reverted"},
+	})
+	service := NewConstructionService(mockClient, &meshconfig.Config{
+		Network:      meshcommon.TestNetwork,
+		Mode:         meshcommon.OnlineMode,
+		BaseGasPrice: "1000000000000000000",
+	}, nil)
+
+	clause := map[string]any{
+		"to":    meshtests.TestAddress1,
+		"value": "0",
+		"data":  "0xd09de08a",
+	}
+	request := &types.ConstructionMetadataRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Options: map[string]any{
+			"origin":  meshtests.FirstSoloAddress,
+			"clauses": []any{clause, clause},
+		},
+	}
+
+	_, err := service.ConstructionMetadata(context.Background(), request)
+	if err == nil {
+		t.Fatal("ConstructionMetadata() expected error for reverted simulation")
+	}
+	if err.Code != int32(meshcommon.ErrClauseSimulationReverted) || err.Retriable {
+		t.Errorf("ConstructionMetadata() error = %v, want non retriable code %d", err, meshcommon.ErrClauseSimulationReverted)
+	}
+	if err.Details["clauseIndex"] != 1 || err.Details["vmError"] != "execution reverted" || err.Details["revertReason"] != reason {
+		t.Errorf("ConstructionMetadata() error details = %v", err.Details)
+	}
+}
+
 func TestConstructionService_ConstructionMetadata_ChainTagMatchesConfig(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
 	cfg := &meshconfig.Config{
@@ -304,7 +529,7 @@ func TestConstructionService_ConstructionMetadata_ChainTagMatchesConfig(t *testi
 	case meshcommon.SoloNetwork:
 		cfg.ChainTag = 0xf6
 	}
-	service := NewConstructionService(mockClient, cfg)
+	service := NewConstructionService(mockClient, cfg, nil)
 
 	request := &types.ConstructionMetadataRequest{
 		NetworkIdentifier: &types.NetworkIdentifier{
@@ -361,7 +586,7 @@ func TestConstructionService_ConstructionMetadata_Dynamic_BaseFeeZero(t *testing
 		Mode:         meshcommon.OnlineMode,
 		BaseGasPrice: "1000000000000000000",
 	}
-	service := NewConstructionService(mockClient, cfg)
+	service := NewConstructionService(mockClient, cfg, nil)
 	// Force dynamic gas price BaseFee=0 path
 	mockClient.MockGasPrice.BaseFee = big.NewInt(0)
 
@@ -475,6 +700,100 @@ func TestConstructionService_ConstructionMetadata_DynamicRequest(t *testing.T) {
 	}
 }
 
+func TestConstructionService_ConstructionMetadata_FeeOptions(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	mockClient.SetInspectClausesResult([]*api.CallResult{{GasUsed: 0}})
+	mockClient.SetFeesHistory(&api.FeesHistory{
+		BaseFeePerGas: []*hexutil.Big{(*hexutil.Big)(big.NewInt(900)), (*hexutil.Big)(big.NewInt(1000))},
+		Reward:        [][]*hexutil.Big{{(*hexutil.Big)(big.NewInt(100))}, {(*hexutil.Big)(big.NewInt(300))}},
+	})
+	service := NewConstructionService(mockClient, &meshconfig.Config{
+		Network:      meshcommon.TestNetwork,
+		Mode:         meshcommon.OnlineMode,
+		BaseGasPrice: "255000",
+		GasPriceCoef: 128,
+	}, nil)
+
+	clauses := []any{
+		map[string]any{
+			"to":    meshtests.TestAddress1,
+			"value": "1000000000000000000",
+			"data":  "0x",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		options      map[string]any
+		expected     map[string]any
+		expectedFee  string
+		expectedCode int
+	}{
+		{
+			name:    "dynamic from fee history",
+			options: map[string]any{"transactionType": meshcommon.TransactionTypeDynamic},
+			// Twice the latest base fee plus the average reward
+			expected:    map[string]any{"maxFeePerGas": "2200", "maxPriorityFeePerGas": "200"},
+			expectedFee: "-" + big.NewInt(21000*2200).String(),
+		},
+		{
+			name:        "dynamic with cap",
+			options:     map[string]any{"transactionType": meshcommon.TransactionTypeDynamic, feeOptionMaxFeePerGas: "1100"},
+			expected:    map[string]any{"maxFeePerGas": "1100", "maxPriorityFeePerGas": "200"},
+			expectedFee: "-" + big.NewInt(21000*1100).String(),
+		},
+		{
+			name:         "dynamic with cap below base fee",
+			options:      map[string]any{"transactionType": meshcommon.TransactionTypeDynamic, feeOptionMaxFeePerGas: "999"},
+			expectedCode: meshcommon.ErrGettingBlockchainMetadata,
+		},
+		{
+			name:        "legacy with coefficient",
+			options:     map[string]any{"transactionType": meshcommon.TransactionTypeLegacy, feeOptionGasPriceCoef: float64(255)},
+			expected:    map[string]any{"gasPriceCoef": uint8(255)},
+			expectedFee: "-" + big.NewInt(21000*510000).String(),
+		},
+		{
+			name:        "legacy with configured coefficient",
+			options:     map[string]any{"transactionType": meshcommon.TransactionTypeLegacy},
+			expected:    map[string]any{"gasPriceCoef": uint8(128)},
+			expectedFee: "-" + big.NewInt(21000*383000).String(),
+		},
+		{
+			name:         "invalid option",
+			options:      map[string]any{feeOptionPercentile: "high"},
+			expectedCode: meshcommon.ErrInvalidRequestParameters,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options["clauses"] = clauses
+			response, err := service.ConstructionMetadata(context.Background(), &types.ConstructionMetadataRequest{
+				NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+				Options:           tt.options,
+			})
+			if tt.expectedCode != 0 {
+				if err == nil || err.Code != int32(tt.expectedCode) {
+					t.Errorf("ConstructionMetadata() error = %v, want code %d", err, tt.expectedCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConstructionMetadata() error = %v", err)
+			}
+			for key, value := range tt.expected {
+				if response.Metadata[key] != value {
+					t.Errorf("ConstructionMetadata() %s = %v, want %v", key, response.Metadata[key], value)
+				}
+			}
+			if response.SuggestedFee[0].Value != tt.expectedFee {
+				t.Errorf("ConstructionMetadata() suggested fee = %v, want %v", response.SuggestedFee[0].Value, tt.expectedFee)
+			}
+		})
+	}
+}
+
 func TestConstructionService_ConstructionMetadata_ClientError(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
 	config := &meshconfig.Config{
@@ -483,7 +802,7 @@ func TestConstructionService_ConstructionMetadata_ClientError(t *testing.T) {
 		Mode:         meshcommon.OnlineMode,
 		BaseGasPrice: "1000000000000000000",
 	}
-	service := NewConstructionService(mockClient, config)
+	service := NewConstructionService(mockClient, config, nil)
 
 	// Configure mock to return error for GetBestBlock
 	mockClient.SetMockBlockError(errors.New("failed to get best block"))
@@ -608,7 +927,7 @@ func TestConstructionService_ConstructionMetadata_InvalidDynamicMetadata(t *test
 		Mode:         meshcommon.OnlineMode,
 		BaseGasPrice: "1000000000000000000",
 	}
-	service := NewConstructionService(mockClient, config)
+	service := NewConstructionService(mockClient, config, nil)
 
 	// Set up mock to fail on GetDynamicGasPrice
 	mockClient.SetMockError(errors.New("failed to get dynamic gas price"))
@@ -930,6 +1249,116 @@ func TestConstructionService_ConstructionPayloads_DelegatorAddressMismatch(t *te
 	}
 }
 
+func TestConstructionService_ConstructionParse_MaxFee(t *testing.T) {
+	to, _ := thor.ParseAddress(meshtests.TestAddress1)
+	originAddr, _ := thor.ParseAddress(meshtests.FirstSoloAddress)
+	tests := []struct {
+		name     string
+		build    func(b *thortx.Builder)
+		txType   thortx.Type
+		expected *big.Int
+	}{
+		{
+			name:   "legacy",
+			txType: thortx.TypeLegacy,
+			build:  func(b *thortx.Builder) { b.GasPriceCoef(255) },
+			// gasPriceCoef 255 doubles the base gas price of the test configuration
+			expected: new(big.Int).Mul(big.NewInt(21000*2), big.NewInt(1e18)),
+		},
+		{
+			name:   "dynamic fee",
+			txType: thortx.TypeDynamicFee,
+			build: func(b *thortx.Builder) {
+				b.MaxFeePerGas(big.NewInt(3000))
+				b.MaxPriorityFeePerGas(big.NewInt(100))
+			},
+			expected: big.NewInt(21000 * 3000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := createMockConstructionService()
+			// Parsing must not depend on the current network fees
+			service.vechainClient.(*meshthor.MockVeChainClient).SetMockError(fmt.Errorf("node unavailable"))
+
+			b := thortx.NewBuilder(tt.txType)
+			b.ChainTag(0x27)
+			b.BlockRef(thortx.BlockRef(make([]byte, 8)))
+			b.Expiration(720)
+			b.Gas(21000)
+			b.Clause(thortx.NewClause(&to).WithValue(big.NewInt(1)))
+			tt.build(b)
+
+			meshBytes, err := service.encoder.EncodeTransaction(&meshtx.MeshTransaction{
+				Transaction: b.Build(),
+				Origin:      originAddr.Bytes(),
+			})
+			if err != nil {
+				t.Fatalf("failed to encode mesh tx: %v", err)
+			}
+
+			response, rosettaErr := service.ConstructionParse(context.Background(), &types.ConstructionParseRequest{
+				NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+				Transaction:       "0x" + hex.EncodeToString(meshBytes),
+			})
+			if rosettaErr != nil {
+				t.Fatalf("ConstructionParse() error = %v", rosettaErr)
+			}
+
+			var feeOps []*types.Operation
+			for _, op := range response.Operations {
+				if isFeeOperation(op) {
+					feeOps = append(feeOps, op)
+				}
+			}
+			if len(feeOps) != 1 {
+				t.Fatalf("ConstructionParse() fee operations = %v, want exactly one", feeOps)
+			}
+			if want := "-" + tt.expected.String(); feeOps[0].Amount.Value != want {
+				t.Errorf("ConstructionParse() fee = %s, want %s", feeOps[0].Amount.Value, want)
+			}
+		})
+	}
+}
+
+func TestConstructionService_ConstructionParse_ExpirationAndDependsOn(t *testing.T) {
+	service := createMockConstructionService()
+	to, _ := thor.ParseAddress(meshtests.TestAddress1)
+	originAddr, _ := thor.ParseAddress(meshtests.FirstSoloAddress)
+	dependsOn := thor.MustParseBytes32("0x" + strings.Repeat("cd", 32))
+
+	b := thortx.NewBuilder(thortx.TypeLegacy)
+	b.ChainTag(0x27)
+	b.BlockRef(thortx.BlockRef(make([]byte, 8)))
+	b.Expiration(18)
+	b.DependsOn(&dependsOn)
+	b.Gas(21000)
+	b.Clause(thortx.NewClause(&to).WithValue(big.NewInt(1)))
+
+	meshBytes, err := service.encoder.EncodeTransaction(&meshtx.MeshTransaction{
+		Transaction: b.Build(),
+		Origin:      originAddr.Bytes(),
+	})
+	if err != nil {
+		t.Fatalf("failed to encode mesh tx: %v", err)
+	}
+
+	response, rosettaErr := service.ConstructionParse(context.Background(), &types.ConstructionParseRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Transaction:       "0x" + hex.EncodeToString(meshBytes),
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionParse() error = %v", rosettaErr)
+	}
+	if response.Metadata["expiration"] != uint32(18) {
+		t.Errorf("ConstructionParse() expiration = %v, want 18", response.Metadata["expiration"])
+	}
+	if response.Metadata["dependsOn"] != dependsOn.String() {
+		t.Errorf("ConstructionParse() dependsOn = %v, want %s", response.Metadata["dependsOn"], dependsOn)
+	}
+}
+
 func TestConstructionService_ConstructionParse_ValidRequest(t *testing.T) {
 	service := createMockConstructionService()
 
@@ -950,6 +1379,196 @@ func TestConstructionService_ConstructionParse_ValidRequest(t *testing.T) {
 	}
 }
 
+// testContractOperationRoundTrip builds, signs and parses a transaction made of a single contract operation,
+// checking that preprocess produces the expected clause and that parse recovers the operation
+func testContractOperationRoundTrip(t *testing.T, operation *types.Operation, clause map[string]any) {
+	t.Helper()
+	service := createMockConstructionService()
+	ctx := context.Background()
+
+	privateKey, err := ethcrypto.HexToECDSA(meshtests.TestAddress1PrivateKey)
+	if err != nil {
+		t.Fatalf("HexToECDSA() error = %v", err)
+	}
+	publicKey := &types.PublicKey{
+		Bytes:     ethcrypto.CompressPubkey(&privateKey.PublicKey),
+		CurveType: meshtests.SECP256k1,
+	}
+
+	operations := []*types.Operation{operation}
+
+	preprocess, rosettaErr := service.ConstructionPreprocess(ctx, &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Operations:        operations,
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionPreprocess() error = %v", rosettaErr)
+	}
+	clauses := preprocess.Options["clauses"].([]map[string]any)
+	if len(clauses) != 1 || clauses[0]["to"] != clause["to"] || clauses[0]["value"] != clause["value"] || clauses[0]["data"] != clause["data"] {
+		t.Errorf("ConstructionPreprocess() clauses = %v, want [%v]", clauses, clause)
+	}
+	if len(preprocess.RequiredPublicKeys) != 1 || preprocess.RequiredPublicKeys[0].Address != meshtests.TestAddress1 {
+		t.Errorf("ConstructionPreprocess() required public keys = %v", preprocess.RequiredPublicKeys)
+	}
+
+	payloads, rosettaErr := service.ConstructionPayloads(ctx, &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Operations:        operations,
+		PublicKeys:        []*types.PublicKey{publicKey},
+		Metadata: map[string]any{
+			"transactionType": meshcommon.TransactionTypeLegacy,
+			"blockRef":        "0x0000000000000000",
+			"chainTag":        float64(1),
+			"gas":             float64(50000),
+			"nonce":           "0x1",
+			"gasPriceCoef":    uint8(0),
+		},
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionPayloads() error = %v", rosettaErr)
+	}
+
+	// Constructor arguments given apart are parsed back as part of the bytecode
+	metadata := map[string]any{}
+	for key, value := range operations[0].Metadata {
+		metadata[key] = value
+	}
+	if args, ok := metadata["args"].(string); ok {
+		metadata["bytecode"] = metadata["bytecode"].(string) + strings.TrimPrefix(args, "0x")
+		delete(metadata, "args")
+	}
+
+	checkOperations := func(parsed []*types.Operation) {
+		t.Helper()
+		// The contract operation is followed by the fee operation
+		if len(parsed) != 2 || parsed[1].Type != meshcommon.OperationTypeFee {
+			t.Fatalf("ConstructionParse() operations = %v, want contract and fee operations", parsed)
+		}
+		op := parsed[0]
+		if op.Type != operations[0].Type || op.Account.Address != operations[0].Account.Address {
+			t.Errorf("ConstructionParse() operation = %v, want %v", op, operations[0])
+		}
+		if op.Amount.Value != operations[0].Amount.Value || op.Amount.Currency.Symbol != operations[0].Amount.Currency.Symbol {
+			t.Errorf("ConstructionParse() amount = %v, want %v", op.Amount, operations[0].Amount)
+		}
+		for key, value := range metadata {
+			if op.Metadata[key] != value {
+				t.Errorf("ConstructionParse() metadata %s = %v, want %v", key, op.Metadata[key], value)
+			}
+		}
+		if _, ok := op.Metadata["args"]; ok {
+			t.Errorf("ConstructionParse() metadata args = %v, want the arguments in the bytecode", op.Metadata["args"])
+		}
+	}
+
+	unsigned, rosettaErr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Transaction:       payloads.UnsignedTransaction,
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionParse() unsigned error = %v", rosettaErr)
+	}
+	checkOperations(unsigned.Operations)
+
+	signature, err := meshcrypto.NewSigningHandler(meshtests.TestAddress1PrivateKey).SignPayload(hex.EncodeToString(payloads.Payloads[0].Bytes))
+	if err != nil {
+		t.Fatalf("SignPayload() error = %v", err)
+	}
+	signatureBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		t.Fatalf("failed to decode signature: %v", err)
+	}
+
+	combined, rosettaErr := service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   createTestNetworkIdentifier(meshcommon.TestNetwork),
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures: []*types.Signature{
+			{
+				SigningPayload: payloads.Payloads[0],
+				PublicKey:      publicKey,
+				SignatureType:  types.EcdsaRecovery,
+				Bytes:          signatureBytes,
+			},
+		},
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionCombine() error = %v", rosettaErr)
+	}
+
+	signed, rosettaErr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Signed:            true,
+		Transaction:       combined.SignedTransaction,
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionParse() signed error = %v", rosettaErr)
+	}
+	checkOperations(signed.Operations)
+	if len(signed.AccountIdentifierSigners) != 1 || signed.AccountIdentifierSigners[0].Address != meshtests.TestAddress1 {
+		t.Errorf("ConstructionParse() signers = %v, want %s", signed.AccountIdentifierSigners, meshtests.TestAddress1)
+	}
+}
+
+func TestConstructionService_ContractCallRoundTrip(t *testing.T) {
+	testContractOperationRoundTrip(t, &types.Operation{
+		OperationIdentifier: &types.OperationIdentifier{Index: 0},
+		Type:                meshcommon.OperationTypeContractCall,
+		Account:             &types.AccountIdentifier{Address: meshtests.TestAddress1},
+		Amount:              &types.Amount{Value: "0", Currency: meshcommon.VETCurrency},
+		Metadata: map[string]any{
+			"to":    meshtests.FirstSoloAddress,
+			"data":  "0xd09de08a",
+			"value": "1000",
+		},
+	}, map[string]any{"to": meshtests.FirstSoloAddress, "value": "1000", "data": "0xd09de08a"})
+}
+
+func TestConstructionService_ContractDeployRoundTrip(t *testing.T) {
+	testContractOperationRoundTrip(t, &types.Operation{
+		OperationIdentifier: &types.OperationIdentifier{Index: 0},
+		Type:                meshcommon.OperationTypeContractDeploy,
+		Account:             &types.AccountIdentifier{Address: meshtests.TestAddress1},
+		Amount:              &types.Amount{Value: "0", Currency: meshcommon.VETCurrency},
+		Metadata: map[string]any{
+			"bytecode": "0x6080604052348015600f57600080fd5b50",
+		},
+	}, map[string]any{"to": "", "value": "0", "data": "0x6080604052348015600f57600080fd5b50"})
+}
+
+func TestConstructionService_ContractDeployWithArgsRoundTrip(t *testing.T) {
+	// Constructor arguments can also be appended to the bytecode, as the transaction keeps them
+	bytecode := "0x6080604052348015600f57600080fd5b50" +
+		"000000000000000000000000f077b491b355e64048ce21e3a6fc4751eeea77fa" +
+		"0000000000000000000000000000000000000000000000000de0b6b3a7640000"
+	testContractOperationRoundTrip(t, &types.Operation{
+		OperationIdentifier: &types.OperationIdentifier{Index: 0},
+		Type:                meshcommon.OperationTypeContractDeploy,
+		Account:             &types.AccountIdentifier{Address: meshtests.TestAddress1},
+		Amount:              &types.Amount{Value: "0", Currency: meshcommon.VETCurrency},
+		Metadata: map[string]any{
+			"bytecode": bytecode,
+			"value":    "1000",
+		},
+	}, map[string]any{"to": "", "value": "1000", "data": bytecode})
+}
+
+func TestConstructionService_ContractDeploySeparateArgsRoundTrip(t *testing.T) {
+	// Constructor arguments given apart are appended to the bytecode
+	bytecode := "0x6080604052348015600f57600080fd5b50"
+	args := "0x000000000000000000000000f077b491b355e64048ce21e3a6fc4751eeea77fa"
+	testContractOperationRoundTrip(t, &types.Operation{
+		OperationIdentifier: &types.OperationIdentifier{Index: 0},
+		Type:                meshcommon.OperationTypeContractDeploy,
+		Account:             &types.AccountIdentifier{Address: meshtests.TestAddress1},
+		Amount:              &types.Amount{Value: "0", Currency: meshcommon.VETCurrency},
+		Metadata: map[string]any{
+			"bytecode": bytecode,
+			"args":     args,
+		},
+	}, map[string]any{"to": "", "value": "0", "data": bytecode + args[2:]})
+}
+
 func TestConstructionService_ConstructionCombine_ValidRequest(t *testing.T) {
 	service := createMockConstructionService()
 
@@ -991,6 +1610,143 @@ func TestConstructionService_ConstructionCombine_ValidRequest(t *testing.T) {
 	}
 }
 
+// remoteDelegationPayloads builds the payloads of a VET transfer from TestAddress1 delegated to delegator,
+// given only the origin public key, and returns them with the origin signature
+func remoteDelegationPayloads(t *testing.T, service *ConstructionService, delegator string) (*types.ConstructionPayloadsResponse, *types.Signature, *types.Error) {
+	t.Helper()
+	privateKey, err := ethcrypto.HexToECDSA(meshtests.TestAddress1PrivateKey)
+	if err != nil {
+		t.Fatalf("HexToECDSA() error = %v", err)
+	}
+	publicKey := &types.PublicKey{
+		Bytes:     ethcrypto.CompressPubkey(&privateKey.PublicKey),
+		CurveType: meshtests.SECP256k1,
+	}
+
+	payloads, rosettaErr := service.ConstructionPayloads(context.Background(), &types.ConstructionPayloadsRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Operations: []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                meshcommon.OperationTypeTransfer,
+				Account:             &types.AccountIdentifier{Address: meshtests.TestAddress1},
+				Amount:              &types.Amount{Value: "-1", Currency: meshcommon.VETCurrency},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				Type:                meshcommon.OperationTypeTransfer,
+				Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+				Amount:              &types.Amount{Value: "1", Currency: meshcommon.VETCurrency},
+			},
+		},
+		PublicKeys: []*types.PublicKey{publicKey},
+		Metadata: map[string]any{
+			"transactionType":                      meshcommon.TransactionTypeLegacy,
+			"blockRef":                             "0x0000000000000000",
+			"chainTag":                             float64(0x27),
+			"gas":                                  float64(21000),
+			"nonce":                                "0x1",
+			"gasPriceCoef":                         uint8(0),
+			meshcommon.DelegatorAccountMetadataKey: delegator,
+		},
+	})
+	if rosettaErr != nil {
+		return nil, nil, rosettaErr
+	}
+	if len(payloads.Payloads) != 1 {
+		t.Fatalf("ConstructionPayloads() payloads = %v, want only the origin payload", payloads.Payloads)
+	}
+
+	signature, err := meshcrypto.NewSigningHandler(meshtests.TestAddress1PrivateKey).SignPayload(hex.EncodeToString(payloads.Payloads[0].Bytes))
+	if err != nil {
+		t.Fatalf("SignPayload() error = %v", err)
+	}
+	signatureBytes, err := hex.DecodeString(signature)
+	if err != nil {
+		t.Fatalf("failed to decode signature: %v", err)
+	}
+	return payloads, &types.Signature{
+		SigningPayload: payloads.Payloads[0],
+		PublicKey:      publicKey,
+		SignatureType:  types.EcdsaRecovery,
+		Bytes:          signatureBytes,
+	}, nil
+}
+
+func TestConstructionService_RemoteFeeDelegation(t *testing.T) {
+	stub := meshtests.NewDelegatorStub(meshtests.TestDelegatorPrivateKey)
+	defer stub.Close()
+
+	service := createMockConstructionService()
+	service.config.DelegatorURL = stub.URL
+	service = NewConstructionService(service.vechainClient, service.config, nil)
+	ctx := context.Background()
+
+	payloads, signature, rosettaErr := remoteDelegationPayloads(t, service, meshtests.TestDelegatorAddress)
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionPayloads() error = %v", rosettaErr)
+	}
+	combined, rosettaErr := service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   createTestNetworkIdentifier(meshcommon.TestNetwork),
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures:          []*types.Signature{signature},
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionCombine() error = %v", rosettaErr)
+	}
+	if stub.Requests() != 1 {
+		t.Errorf("Expected 1 request to the delegation service, got %d", stub.Requests())
+	}
+
+	// The signed transaction is paid by the delegation service
+	signed, rosettaErr := service.ConstructionParse(ctx, &types.ConstructionParseRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Signed:            true,
+		Transaction:       combined.SignedTransaction,
+	})
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionParse() error = %v", rosettaErr)
+	}
+	if len(signed.AccountIdentifierSigners) != 2 || signed.AccountIdentifierSigners[1].Address != meshtests.TestDelegatorAddress {
+		t.Errorf("ConstructionParse() signers = %v, want origin and %s", signed.AccountIdentifierSigners, meshtests.TestDelegatorAddress)
+	}
+
+	// The signature of another gas payer is refused
+	payloads, signature, rosettaErr = remoteDelegationPayloads(t, service, meshtests.FirstSoloAddress)
+	if rosettaErr != nil {
+		t.Fatalf("ConstructionPayloads() error = %v", rosettaErr)
+	}
+	_, rosettaErr = service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   createTestNetworkIdentifier(meshcommon.TestNetwork),
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures:          []*types.Signature{signature},
+	})
+	if rosettaErr == nil || rosettaErr.Code != int32(meshcommon.ErrDelegatorAddressMismatch) {
+		t.Errorf("ConstructionCombine() error = %v, want delegator address mismatch", rosettaErr)
+	}
+
+	// A refusal of the delegation service is reported
+	stub.Reject.Store(true)
+	payloads, signature, _ = remoteDelegationPayloads(t, service, meshtests.TestDelegatorAddress)
+	_, rosettaErr = service.ConstructionCombine(ctx, &types.ConstructionCombineRequest{
+		NetworkIdentifier:   createTestNetworkIdentifier(meshcommon.TestNetwork),
+		UnsignedTransaction: payloads.UnsignedTransaction,
+		Signatures:          []*types.Signature{signature},
+	})
+	if rosettaErr == nil || rosettaErr.Code != int32(meshcommon.ErrFeeDelegationFailed) {
+		t.Errorf("ConstructionCombine() error = %v, want fee delegation failed", rosettaErr)
+	}
+}
+
+func TestConstructionService_RemoteFeeDelegation_NotConfigured(t *testing.T) {
+	service := createMockConstructionService()
+
+	_, _, rosettaErr := remoteDelegationPayloads(t, service, meshtests.TestDelegatorAddress)
+	if rosettaErr == nil || rosettaErr.Code != int32(meshcommon.ErrInvalidPublicKeyFormat) {
+		t.Errorf("ConstructionPayloads() error = %v, want the delegator public key to be required", rosettaErr)
+	}
+}
+
 func TestConstructionService_ConstructionCombine_InvalidUnsignedTransaction(t *testing.T) {
 	service := createMockConstructionService()
 
@@ -1184,6 +1940,48 @@ func TestConstructionService_ConstructionDerive_InvalidPublicKey(t *testing.T) {
 	}
 }
 
+func TestConstructionService_ConstructionPreprocess_UnsupportedToken(t *testing.T) {
+	registry, err := meshcommon.NewTokenRegistry([]*types.Currency{{
+		Symbol:   "USDC",
+		Decimals: 6,
+		Metadata: map[string]any{"contractAddress": "0x1234567890123456789012345678901234567890"},
+	}}, true, 0)
+	if err != nil {
+		t.Fatalf("NewTokenRegistry() error = %v", err)
+	}
+	service := NewConstructionService(meshthor.NewMockVeChainClient(), &meshconfig.Config{TokenRegistry: registry}, nil)
+
+	spoofedUSDC := &types.Currency{
+		Symbol:   "USDC",
+		Decimals: 6,
+		Metadata: map[string]any{
+			"contractAddress": "0x0000000000000000000000000000000000000001",
+		},
+	}
+	request := &types.ConstructionPreprocessRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		Operations: []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                meshcommon.OperationTypeTransfer,
+				Account:             &types.AccountIdentifier{Address: meshtests.FirstSoloAddress},
+				Amount:              &types.Amount{Value: "-1000000", Currency: spoofedUSDC},
+			},
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 1},
+				Type:                meshcommon.OperationTypeTransfer,
+				Account:             &types.AccountIdentifier{Address: meshtests.TestAddress1},
+				Amount:              &types.Amount{Value: "1000000", Currency: spoofedUSDC},
+			},
+		},
+	}
+
+	_, errResp := service.ConstructionPreprocess(context.Background(), request)
+	if errResp == nil || errResp.Code != meshcommon.ErrUnsupportedToken {
+		t.Errorf("ConstructionPreprocess() error = %v, want ErrUnsupportedToken", errResp)
+	}
+}
+
 func TestConstructionService_ConstructionPreprocess_MultipleOrigins(t *testing.T) {
 	service := createMockConstructionService()
 
@@ -1577,3 +2375,28 @@ func TestConstructionService_createDelegatorPayload_InvalidOriginPublicKey(t *te
 		t.Error("createDelegatorPayload() expected error for invalid origin public key")
 	}
 }
+
+func TestConstructionService_ConstructionSubmit_Tracked(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	tracker := NewTxTracker(mockClient)
+	service := NewConstructionService(mockClient, &meshconfig.Config{Network: meshcommon.TestNetwork}, tracker)
+
+	trx := createTrackedTransaction(t, 1)
+	txBytes, err := service.encoder.EncodeTransaction(&meshtx.MeshTransaction{
+		Transaction: trx,
+		Origin:      thor.MustParseAddress(meshtests.TestAddress1).Bytes(),
+	})
+	if err != nil {
+		t.Fatalf("EncodeTransaction() error = %v", err)
+	}
+	if _, rosettaErr := service.ConstructionSubmit(context.Background(), &types.ConstructionSubmitRequest{
+		NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork),
+		SignedTransaction: "0x" + hex.EncodeToString(txBytes),
+	}); rosettaErr != nil {
+		t.Fatalf("ConstructionSubmit() error = %v", rosettaErr)
+	}
+
+	if tracked, ok := tracker.Status(trx.ID()); !ok || tracked.Status != TxStatusPending {
+		t.Errorf("Status() = %+v, %v, want the submitted transaction pending", tracked, ok)
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	meshcommon "github.com/vechain/mesh/common"
@@ -11,12 +12,14 @@ import (
 // EventsService handles events API endpoints
 type EventsService struct {
 	vechainClient meshthor.VeChainClientInterface
+	journal       *BlockJournal
 }
 
 // NewEventsService creates a new events service
-func NewEventsService(vechainClient meshthor.VeChainClientInterface) *EventsService {
+func NewEventsService(vechainClient meshthor.VeChainClientInterface, journal *BlockJournal) *EventsService {
 	return &EventsService{
 		vechainClient: vechainClient,
+		journal:       journal,
 	}
 }
 
@@ -42,51 +45,21 @@ func (e *EventsService) EventsBlocks(
 		}
 	}
 
-	// Get the best block number
-	bestBlock, err := e.vechainClient.GetBlock("best")
-	if err != nil {
-		return nil, meshcommon.GetError(meshcommon.ErrFailedToGetBestBlock)
-	}
-
-	bestBlockNum := int64(bestBlock.Number)
-
-	// If offset is beyond the best block, return empty events
-	if bestBlockNum < offset {
-		return &types.EventsBlocksResponse{
-			MaxSequence: bestBlockNum,
-			Events:      []*types.BlockEvent{},
-		}, nil
+	// Sync the journal with the node and read the requested events
+	events, maxSequence, err := e.journal.Events(offset, limit)
+	if errors.Is(err, ErrJournalNetworkMismatch) {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrEventsJournalNetworkMismatch, map[string]any{
+			"error": err.Error(),
+		})
 	}
-
-	// Collect events
-	var events []*types.BlockEvent
-	for index := offset; index < offset+limit; index++ {
-		// Don't go beyond the best block
-		if index > bestBlockNum {
-			break
-		}
-
-		// Get block information
-		block, err := e.vechainClient.GetBlockByNumber(index)
-		if err != nil {
-			// If block doesn't exist, skip it
-			continue
-		}
-
-		// Create event
-		event := types.BlockEvent{
-			Sequence: index,
-			BlockIdentifier: &types.BlockIdentifier{
-				Index: index,
-				Hash:  block.ID.String(),
-			},
-			Type: "block_added",
-		}
-		events = append(events, &event)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrFailedToSyncBlockEvents, map[string]any{
+			"error": err.Error(),
+		})
 	}
 
 	return &types.EventsBlocksResponse{
-		MaxSequence: bestBlockNum,
+		MaxSequence: maxSequence,
 		Events:      events,
 	}, nil
 }
@@ -2,10 +2,12 @@ package services
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	meshcommon "github.com/vechain/mesh/common"
 	meshthor "github.com/vechain/mesh/thor"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/thor"
@@ -13,7 +15,7 @@ import (
 
 func TestEventsService_EventsBlocks_Success(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewEventsService(mockClient)
+	service := newTestEventsService(t, mockClient)
 
 	// Mock best block response (for GetBlock("best"))
 	mockClient.SetMockBlock(&api.JSONExpandedBlock{
@@ -26,16 +28,8 @@ func TestEventsService_EventsBlocks_Success(t *testing.T) {
 		},
 	})
 
-	// Mock block response for specific numbers
-	mockClient.SetBlockByNumber(&api.JSONExpandedBlock{
-		JSONBlockSummary: &api.JSONBlockSummary{
-			Number: 10,
-			ID: func() thor.Bytes32 {
-				hash, _ := thor.ParseBytes32("0x4567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef123")
-				return hash
-			}(),
-		},
-	})
+	// Mock the canonical chain up to the best block
+	mockClient.SetBlocksByNumber(newTestChain(101, nil))
 
 	offset := int64(10)
 	limit := int64(5)
@@ -62,7 +56,7 @@ func TestEventsService_EventsBlocks_Success(t *testing.T) {
 
 func TestEventsService_EventsBlocks_DefaultValues(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewEventsService(mockClient)
+	service := newTestEventsService(t, mockClient)
 
 	// Mock best block response
 	mockClient.SetMockBlock(&api.JSONExpandedBlock{
@@ -75,6 +69,8 @@ func TestEventsService_EventsBlocks_DefaultValues(t *testing.T) {
 		},
 	})
 
+	mockClient.SetBlocksByNumber(newTestChain(101, nil))
+
 	// Create request with nil offset and limit (should use defaults)
 	request := &types.EventsBlocksRequest{
 		Offset: nil,
@@ -95,7 +91,7 @@ func TestEventsService_EventsBlocks_DefaultValues(t *testing.T) {
 
 func TestEventsService_EventsBlocks_OffsetBeyondBestBlock(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewEventsService(mockClient)
+	service := newTestEventsService(t, mockClient)
 
 	// Mock best block response
 	mockClient.SetMockBlock(&api.JSONExpandedBlock{
@@ -108,6 +104,8 @@ func TestEventsService_EventsBlocks_OffsetBeyondBestBlock(t *testing.T) {
 		},
 	})
 
+	mockClient.SetBlocksByNumber(newTestChain(101, nil))
+
 	// Request with offset beyond best block
 	offset := int64(200)
 	limit := int64(5)
@@ -131,7 +129,7 @@ func TestEventsService_EventsBlocks_OffsetBeyondBestBlock(t *testing.T) {
 
 func TestEventsService_EventsBlocks_InvalidOffset(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewEventsService(mockClient)
+	service := newTestEventsService(t, mockClient)
 
 	// Request with negative offset
 	offset := int64(-1)
@@ -149,7 +147,7 @@ func TestEventsService_EventsBlocks_InvalidOffset(t *testing.T) {
 
 func TestEventsService_EventsBlocks_InvalidLimit(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewEventsService(mockClient)
+	service := newTestEventsService(t, mockClient)
 
 	// Request with negative limit
 	limit := int64(-1)
@@ -167,7 +165,7 @@ func TestEventsService_EventsBlocks_InvalidLimit(t *testing.T) {
 
 func TestEventsService_EventsBlocks_InvalidLimitTooHigh(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewEventsService(mockClient)
+	service := newTestEventsService(t, mockClient)
 
 	// Request with limit > 1000
 	limit := int64(2000)
@@ -185,7 +183,7 @@ func TestEventsService_EventsBlocks_InvalidLimitTooHigh(t *testing.T) {
 
 func TestEventsService_EventsBlocks_ThorClientError(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewEventsService(mockClient)
+	service := newTestEventsService(t, mockClient)
 
 	// Set error on mock client
 	mockClient.SetMockError(errors.New("thor client error"))
@@ -199,3 +197,126 @@ func TestEventsService_EventsBlocks_ThorClientError(t *testing.T) {
 		t.Error("EventsBlocks() expected error when thor client returns error")
 	}
 }
+
+func TestEventsService_EventsBlocks_Reorg(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	service := newTestEventsService(t, mockClient)
+
+	chain := newTestChain(5, nil)
+	mockClient.SetMockBlock(chain[4])
+	mockClient.SetBlocksByNumber(chain)
+
+	offset := int64(0)
+	response, err := service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{Offset: &offset})
+	if err != nil {
+		t.Fatalf("EventsBlocks() error = %v", err)
+	}
+	if len(response.Events) != 5 || response.MaxSequence != 4 {
+		t.Fatalf("Expected 5 events up to sequence 4, got %d up to %d", len(response.Events), response.MaxSequence)
+	}
+
+	// Blocks 3 and 4 are replaced by a longer fork
+	fork := newTestChain(6, chain[:3])
+	mockClient.SetMockBlock(fork[5])
+	mockClient.SetBlocksByNumber(fork)
+
+	offset = 5
+	response, err = service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{Offset: &offset})
+	if err != nil {
+		t.Fatalf("EventsBlocks() error = %v", err)
+	}
+
+	expected := []struct {
+		eventType types.BlockEventType
+		block     *api.JSONExpandedBlock
+	}{
+		{types.REMOVED, chain[4]},
+		{types.REMOVED, chain[3]},
+		{types.ADDED, fork[3]},
+		{types.ADDED, fork[4]},
+		{types.ADDED, fork[5]},
+	}
+	if len(response.Events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(response.Events))
+	}
+	for i, want := range expected {
+		event := response.Events[i]
+		if event.Sequence != offset+int64(i) {
+			t.Errorf("Event %d: expected sequence %d, got %d", i, offset+int64(i), event.Sequence)
+		}
+		if event.Type != want.eventType {
+			t.Errorf("Event %d: expected type %s, got %s", i, want.eventType, event.Type)
+		}
+		if event.BlockIdentifier.Index != int64(want.block.Number) || event.BlockIdentifier.Hash != want.block.ID.String() {
+			t.Errorf("Event %d: expected block %d %s, got %v", i, want.block.Number, want.block.ID, event.BlockIdentifier)
+		}
+	}
+	if response.MaxSequence != 9 {
+		t.Errorf("Expected max_sequence 9, got %d", response.MaxSequence)
+	}
+}
+
+func TestEventsService_EventsBlocks_MissingBlock(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	service := newTestEventsService(t, mockClient)
+
+	chain := newTestChain(101, nil)
+	mockClient.SetMockBlock(chain[100])
+	mockClient.SetBlocksByNumber(chain)
+	delete(mockClient.MockBlocksByNumber, 50)
+
+	// A block the node cannot serve fails the request instead of leaving a gap
+	offset := int64(0)
+	if _, rosettaErr := service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{Offset: &offset}); rosettaErr == nil ||
+		rosettaErr.Code != meshcommon.ErrFailedToSyncBlockEvents || !rosettaErr.Retriable {
+		t.Fatalf("EventsBlocks() error = %v, want retriable sync error", rosettaErr)
+	}
+
+	mockClient.SetBlocksByNumber(chain)
+	response, rosettaErr := service.EventsBlocks(context.Background(), &types.EventsBlocksRequest{Offset: &offset})
+	if rosettaErr != nil {
+		t.Fatalf("EventsBlocks() error = %v", rosettaErr)
+	}
+	if len(response.Events) != 100 {
+		t.Fatalf("Expected 100 events, got %d", len(response.Events))
+	}
+	for i, event := range response.Events {
+		if event.Type != types.ADDED || event.BlockIdentifier.Hash != chain[i].ID.String() {
+			t.Errorf("Event %d: expected block %d added, got %s of %v", i, i, event.Type, event.BlockIdentifier)
+		}
+	}
+}
+
+// newTestEventsService creates an events service backed by an in-memory journal
+func newTestEventsService(t *testing.T, client meshthor.VeChainClientInterface) *EventsService {
+	journal, err := NewBlockJournal(client, "", "0")
+	if err != nil {
+		t.Fatalf("NewBlockJournal() error = %v", err)
+	}
+	return NewEventsService(client, journal)
+}
+
+// newTestChain creates a chain of length blocks extending prefix, blocks after the prefix get
+// IDs derived from their number and the prefix length so that forks have distinct IDs
+func newTestChain(length int, prefix []*api.JSONExpandedBlock) []*api.JSONExpandedBlock {
+	chain := append([]*api.JSONExpandedBlock(nil), prefix...)
+	for number := len(prefix); number < length; number++ {
+		var parentID thor.Bytes32
+		if number > 0 {
+			parentID = chain[number-1].ID
+		}
+		var id thor.Bytes32
+		id[0] = byte(len(prefix))
+		// #nosec G115
+		binary.BigEndian.PutUint32(id[28:], uint32(number))
+		chain = append(chain, &api.JSONExpandedBlock{
+			JSONBlockSummary: &api.JSONBlockSummary{
+				// #nosec G115
+				Number:   uint32(number),
+				ID:       id,
+				ParentID: parentID,
+			},
+		})
+	}
+	return chain
+}
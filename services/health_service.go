@@ -0,0 +1,203 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	meshcommon "github.com/vechain/mesh/common"
+	meshconfig "github.com/vechain/mesh/config"
+	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
+)
+
+// Statuses of a readiness check and of the readiness report
+const (
+	HealthCheckPass = "pass"
+	HealthCheckFail = "fail"
+
+	ReadinessReady    = "ready"
+	ReadinessNotReady = "not ready"
+)
+
+// Names of the readiness checks
+const (
+	HealthCheckNode          = "node"
+	HealthCheckHeadFreshness = "headFreshness"
+	HealthCheckPeers         = "peers"
+	HealthCheckThorProcess   = "thorProcess"
+	HealthCheckConfig        = "config"
+)
+
+// ThorProcess reports the state of the Thor child process run next to the server
+type ThorProcess interface {
+	Status() meshthor.ProcessStatus
+}
+
+// HealthCheck is the result of one readiness check
+type HealthCheck struct {
+	Name    string         `json:"name"`
+	Status  string         `json:"status"`
+	Message string         `json:"message,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// ReadinessReport is the result of every readiness check, the server is ready when all of them pass
+type ReadinessReport struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// Ready reports whether every check passed
+func (r ReadinessReport) Ready() bool {
+	return r.Status == ReadinessReady
+}
+
+// HealthService checks whether the server can serve requests.
+// Online, it needs a reachable node whose head is not stalled and connected peers, offline only a valid configuration.
+// When the server runs the Thor node itself, the Thor process must also be running.
+type HealthService struct {
+	vechainClient meshthor.VeChainClientInterface
+	config        *meshconfig.Config
+	// syncMonitor is shared with the network status so that both report the same stage
+	syncMonitor *SyncMonitor
+	thorProcess ThorProcess
+}
+
+// NewHealthService creates a new health service deriving the head freshness from syncMonitor
+func NewHealthService(vechainClient meshthor.VeChainClientInterface, config *meshconfig.Config, syncMonitor *SyncMonitor) *HealthService {
+	return &HealthService{
+		vechainClient: vechainClient,
+		config:        config,
+		syncMonitor:   syncMonitor,
+	}
+}
+
+// SetThorProcess makes the readiness depend on the Thor child process, it must be called before serving requests
+func (h *HealthService) SetThorProcess(process ThorProcess) {
+	h.thorProcess = process
+}
+
+// Readiness runs every readiness check that applies to the mode of the server
+func (h *HealthService) Readiness() ReadinessReport {
+	var checks []HealthCheck
+	if h.config.Mode == meshcommon.OfflineMode {
+		checks = append(checks, h.checkConfig())
+	} else {
+		head, headErr := h.vechainClient.GetBlockHeader("best")
+		var peers []meshthor.Peer
+		var peersErr error
+		if h.config.Network != meshcommon.SoloNetwork {
+			peers, peersErr = h.vechainClient.GetPeers()
+		}
+		checks = append(checks, h.checkNode(headErr), h.checkHeadFreshness(head, headErr, peers), h.checkPeers(peers, peersErr))
+	}
+	if h.thorProcess != nil {
+		checks = append(checks, h.checkThorProcess())
+	}
+
+	report := ReadinessReport{Status: ReadinessReady, Checks: checks}
+	for _, check := range checks {
+		if check.Status != HealthCheckPass {
+			report.Status = ReadinessNotReady
+		}
+	}
+	return report
+}
+
+// checkNode checks that the node API answers
+func (h *HealthService) checkNode(headErr error) HealthCheck {
+	check := HealthCheck{Name: HealthCheckNode, Status: HealthCheckPass}
+	if headErr != nil {
+		check.Status, check.Message = HealthCheckFail, fmt.Sprintf("node API unreachable: %v", headErr)
+		return check
+	}
+	check.Details = map[string]any{"nodeApi": h.config.NodeAPI}
+	return check
+}
+
+// checkHeadFreshness checks that the head of the node is not stalled, as reported by the network status
+func (h *HealthService) checkHeadFreshness(head *api.JSONBlockSummary, headErr error, peers []meshthor.Peer) HealthCheck {
+	check := HealthCheck{Name: HealthCheckHeadFreshness, Status: HealthCheckPass}
+	if headErr != nil {
+		check.Status, check.Message = HealthCheckFail, "head unknown"
+		return check
+	}
+
+	state := h.syncMonitor.Observe(int64(head.Number), head.ID, head.Timestamp, toPeers(peers))
+	check.Details = map[string]any{
+		"blockIdentifier": map[string]any{"index": head.Number, "hash": head.ID.String()},
+		"stage":           state.Stage,
+	}
+	if state.Stage == meshcommon.SyncStageStalled {
+		check.Status = HealthCheckFail
+		check.Message = fmt.Sprintf("head did not advance for more than %s", h.config.GetSyncStallThreshold())
+	}
+	return check
+}
+
+// checkPeers checks that the node is connected to at least one peer, a solo node has none
+func (h *HealthService) checkPeers(peers []meshthor.Peer, peersErr error) HealthCheck {
+	check := HealthCheck{Name: HealthCheckPeers, Status: HealthCheckPass}
+	if h.config.Network == meshcommon.SoloNetwork {
+		check.Message = "solo network has no peers"
+		return check
+	}
+
+	if peersErr != nil {
+		check.Status, check.Message = HealthCheckFail, fmt.Sprintf("failed to get peers: %v", peersErr)
+		return check
+	}
+	check.Details = map[string]any{"count": len(peers)}
+	if len(peers) == 0 {
+		check.Status, check.Message = HealthCheckFail, "no connected peers"
+	}
+	return check
+}
+
+// checkThorProcess checks that the Thor child process is running
+func (h *HealthService) checkThorProcess() HealthCheck {
+	status := h.thorProcess.Status()
+	check := HealthCheck{
+		Name:    HealthCheckThorProcess,
+		Status:  HealthCheckPass,
+		Details: map[string]any{"pid": status.PID, "exits": status.Exits},
+	}
+	if !status.Running {
+		check.Status, check.Message = HealthCheckFail, "Thor process is not running"
+	}
+	return check
+}
+
+// checkConfig checks that the configuration is valid
+func (h *HealthService) checkConfig() HealthCheck {
+	check := HealthCheck{Name: HealthCheckConfig, Status: HealthCheckPass}
+	if err := h.config.Validate(); err != nil {
+		check.Status, check.Message = HealthCheckFail, err.Error()
+	}
+	return check
+}
+
+// LivenessHandler reports that the server is up and answering, whatever the state of the node
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	})
+}
+
+// ReadinessHandler serves the readiness report, with a 503 status when the server is not ready
+func ReadinessHandler(healthService *HealthService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := healthService.Readiness()
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	meshcommon "github.com/vechain/mesh/common"
+	meshconfig "github.com/vechain/mesh/config"
+	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
+)
+
+// mockThorProcess is a Thor child process with a fixed status
+type mockThorProcess struct {
+	status meshthor.ProcessStatus
+}
+
+func (p *mockThorProcess) Status() meshthor.ProcessStatus {
+	return p.status
+}
+
+// createHealthService returns a health service sharing the sync monitor of a network service
+func createHealthService(config *meshconfig.Config) (*HealthService, *meshthor.MockVeChainClient) {
+	now := time.Unix(1700000000, 0)
+	mockClient := meshthor.NewMockVeChainClient()
+	mockClient.SetMockBlock(&api.JSONExpandedBlock{JSONBlockSummary: &api.JSONBlockSummary{
+		Number:    100,
+		Timestamp: uint64(now.Unix()),
+	}})
+	syncMonitor := NewNetworkService(mockClient, config).SyncMonitor()
+	syncMonitor.now = func() time.Time { return now }
+	return NewHealthService(mockClient, config, syncMonitor), mockClient
+}
+
+// stallHead observes the head, then moves the clock of the sync monitor past the stall threshold
+func stallHead(service *HealthService) {
+	service.Readiness()
+	stalledAt := service.syncMonitor.now().Add(service.config.GetSyncStallThreshold() + time.Second)
+	service.syncMonitor.now = func() time.Time { return stalledAt }
+}
+
+// checkStatuses returns the status of every check of a report by name
+func checkStatuses(report ReadinessReport) map[string]string {
+	statuses := make(map[string]string)
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func TestHealthService_Readiness(t *testing.T) {
+	onlineConfig := &meshconfig.Config{Mode: meshcommon.OnlineMode, Network: meshcommon.TestNetwork}
+	soloConfig := &meshconfig.Config{Mode: meshcommon.OnlineMode, Network: meshcommon.SoloNetwork, SoloOnDemand: true}
+
+	tests := []struct {
+		name   string
+		config *meshconfig.Config
+		setup  func(service *HealthService, mockClient *meshthor.MockVeChainClient)
+		want   map[string]string
+	}{
+		{
+			name:   "ready",
+			config: onlineConfig,
+			want:   map[string]string{HealthCheckNode: HealthCheckPass, HealthCheckHeadFreshness: HealthCheckPass, HealthCheckPeers: HealthCheckPass},
+		},
+		{
+			name:   "node down",
+			config: onlineConfig,
+			setup: func(service *HealthService, mockClient *meshthor.MockVeChainClient) {
+				mockClient.SetMockError(errors.New("connection refused"))
+			},
+			want: map[string]string{HealthCheckNode: HealthCheckFail, HealthCheckHeadFreshness: HealthCheckFail, HealthCheckPeers: HealthCheckFail},
+		},
+		{
+			name:   "head stalled",
+			config: onlineConfig,
+			setup: func(service *HealthService, mockClient *meshthor.MockVeChainClient) {
+				stallHead(service)
+			},
+			want: map[string]string{HealthCheckNode: HealthCheckPass, HealthCheckHeadFreshness: HealthCheckFail, HealthCheckPeers: HealthCheckPass},
+		},
+		{
+			name:   "no peers",
+			config: onlineConfig,
+			setup: func(service *HealthService, mockClient *meshthor.MockVeChainClient) {
+				mockClient.MockPeers = nil
+			},
+			want: map[string]string{HealthCheckNode: HealthCheckPass, HealthCheckHeadFreshness: HealthCheckPass, HealthCheckPeers: HealthCheckFail},
+		},
+		{
+			name:   "idle solo node",
+			config: soloConfig,
+			setup: func(service *HealthService, mockClient *meshthor.MockVeChainClient) {
+				mockClient.MockPeers = nil
+				stallHead(service)
+				service.SetThorProcess(&mockThorProcess{status: meshthor.ProcessStatus{Running: true, PID: 42}})
+			},
+			want: map[string]string{
+				HealthCheckNode:          HealthCheckPass,
+				HealthCheckHeadFreshness: HealthCheckPass,
+				HealthCheckPeers:         HealthCheckPass,
+				HealthCheckThorProcess:   HealthCheckPass,
+			},
+		},
+		{
+			name:   "thor process exited",
+			config: soloConfig,
+			setup: func(service *HealthService, mockClient *meshthor.MockVeChainClient) {
+				service.SetThorProcess(&mockThorProcess{status: meshthor.ProcessStatus{Exits: 1}})
+			},
+			want: map[string]string{
+				HealthCheckNode:          HealthCheckPass,
+				HealthCheckHeadFreshness: HealthCheckPass,
+				HealthCheckPeers:         HealthCheckPass,
+				HealthCheckThorProcess:   HealthCheckFail,
+			},
+		},
+		{
+			name:   "custom network offline config",
+			config: &meshconfig.Config{Mode: meshcommon.OfflineMode, Network: "custom", Port: 8080},
+			want:   map[string]string{HealthCheckConfig: HealthCheckPass},
+		},
+		{
+			name:   "valid offline config",
+			config: &meshconfig.Config{Mode: meshcommon.OfflineMode, Network: meshcommon.TestNetwork, Port: 8080},
+			want:   map[string]string{HealthCheckConfig: HealthCheckPass},
+		},
+		{
+			name:   "invalid offline config",
+			config: &meshconfig.Config{Mode: meshcommon.OfflineMode, Network: meshcommon.TestNetwork, Port: 0},
+			want:   map[string]string{HealthCheckConfig: HealthCheckFail},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockClient := createHealthService(tt.config)
+			if tt.setup != nil {
+				tt.setup(service, mockClient)
+			}
+
+			report := service.Readiness()
+			statuses := checkStatuses(report)
+			if len(statuses) != len(tt.want) {
+				t.Errorf("Readiness() checks = %v, want %v", statuses, tt.want)
+			}
+			ready := true
+			for name, want := range tt.want {
+				if statuses[name] != want {
+					t.Errorf("Readiness() %s check = %s, want %s", name, statuses[name], want)
+				}
+				ready = ready && want == HealthCheckPass
+			}
+			if report.Ready() != ready {
+				t.Errorf("Readiness() status = %s, want ready = %v", report.Status, ready)
+			}
+		})
+	}
+}
+
+func TestHealthService_SharesSyncMonitor(t *testing.T) {
+	config := &meshconfig.Config{Mode: meshcommon.OnlineMode, Network: meshcommon.TestNetwork}
+	mockClient := meshthor.NewMockVeChainClient()
+	networkService := NewNetworkService(mockClient, config)
+	service := NewHealthService(mockClient, config, networkService.SyncMonitor())
+	now := time.Unix(1700000000, 0)
+	networkService.syncMonitor.now = func() time.Time { return now }
+
+	// The head observed by the network status stalls for the readiness check
+	request := &types.NetworkRequest{NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork)}
+	if _, err := networkService.NetworkStatus(context.Background(), request); err != nil {
+		t.Fatalf("NetworkStatus() error = %v", err)
+	}
+	networkService.syncMonitor.now = func() time.Time { return now.Add(config.GetSyncStallThreshold() + time.Second) }
+
+	if statuses := checkStatuses(service.Readiness()); statuses[HealthCheckHeadFreshness] != HealthCheckFail {
+		t.Errorf("Readiness() %s check = %s, want %s", HealthCheckHeadFreshness, statuses[HealthCheckHeadFreshness], HealthCheckFail)
+	}
+}
+
+func TestReadinessHandler(t *testing.T) {
+	service, mockClient := createHealthService(&meshconfig.Config{Mode: meshcommon.OnlineMode, Network: meshcommon.TestNetwork})
+	handler := ReadinessHandler(service)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, meshcommon.ReadinessEndpoint, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("ReadinessHandler() status code = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	mockClient.SetMockError(errors.New("connection refused"))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, meshcommon.ReadinessEndpoint, nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("ReadinessHandler() status code = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+
+	var report ReadinessReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("Failed to unmarshal readiness report: %v", err)
+	}
+	if report.Status != ReadinessNotReady || len(report.Checks) == 0 || report.Checks[0].Message == "" {
+		t.Errorf("ReadinessHandler() report = %+v, want the failed checks explained", report)
+	}
+}
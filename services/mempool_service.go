@@ -7,8 +7,12 @@ import (
 	meshcommon "github.com/vechain/mesh/common"
 	meshoperations "github.com/vechain/mesh/common/operations"
 	meshtx "github.com/vechain/mesh/common/tx"
+	meshconfig "github.com/vechain/mesh/config"
 	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/transactions"
 	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient"
 )
 
 // MempoolService handles mempool API endpoints
@@ -17,15 +21,18 @@ type MempoolService struct {
 	encoder       *meshtx.MeshTransactionEncoder
 	builder       *meshtx.TransactionBuilder
 	clauseParser  *meshoperations.ClauseParser
+	// preview simulates pending transactions at the best block to predict their outcome
+	preview bool
 }
 
 // NewMempoolService creates a new mempool service
-func NewMempoolService(vechainClient meshthor.VeChainClientInterface) *MempoolService {
+func NewMempoolService(vechainClient meshthor.VeChainClientInterface, config *meshconfig.Config) *MempoolService {
 	return &MempoolService{
 		vechainClient: vechainClient,
-		encoder:       meshtx.NewMeshTransactionEncoder(vechainClient),
+		encoder:       meshtx.NewMeshTransactionEncoder(vechainClient, config.TokenRegistry),
 		builder:       meshtx.NewTransactionBuilder(),
-		clauseParser:  meshoperations.NewClauseParser(vechainClient, meshoperations.NewOperationsExtractor()),
+		clauseParser:  meshoperations.NewClauseParser(vechainClient, meshoperations.NewOperationsExtractor(config.TokenRegistry)),
+		preview:       config.MempoolPreview,
 	}
 }
 
@@ -97,7 +104,13 @@ func (m *MempoolService) MempoolTransaction(
 		delegatorAddr = tx.Delegator.String()
 	}
 
-	operations, err := m.clauseParser.ParseOperationsFromAPIClauses(tx.Clauses, tx.Origin.String(), delegatorAddr, tx.Gas, &status)
+	var operations []*types.Operation
+	var metadata map[string]any
+	if m.preview {
+		operations, metadata, err = m.previewOperations(tx, delegatorAddr, &status)
+	} else {
+		operations, err = m.clauseParser.ParseOperationsFromAPIClauses(tx.Clauses, tx.Origin.String(), delegatorAddr, tx.Gas, &status)
+	}
 	if err != nil {
 		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInternalServerError, map[string]any{
 			"error": err.Error(),
@@ -108,5 +121,51 @@ func (m *MempoolService) MempoolTransaction(
 	// Build the response
 	return &types.MempoolTransactionResponse{
 		Transaction: meshTx,
+		Metadata:    metadata,
 	}, nil
 }
+
+// previewOperations simulates a pending transaction at the best block and parses its operations from the
+// simulation outputs, including the transfers made by contracts. Transactions expected to revert keep the
+// operations of their clauses, and the metadata tells the outcome of the simulation.
+func (m *MempoolService) previewOperations(tx *transactions.Transaction, delegatorAddr string, status *string) ([]*types.Operation, map[string]any, error) {
+	clauseData := meshoperations.APIClausesToClauseData(tx.Clauses)
+	origin := tx.Origin
+	results, err := m.vechainClient.InspectClauses(&api.BatchCallData{
+		Clauses:    tx.Clauses,
+		Gas:        tx.Gas,
+		Caller:     &origin,
+		GasPayer:   tx.Delegator,
+		BlockRef:   tx.BlockRef,
+		Expiration: tx.Expiration,
+	}, thorclient.Revision("best"))
+	if err != nil {
+		// The preview is best effort, the operations of the clauses are still reported
+		operations, parseErr := m.clauseParser.ParseTransactionOperationsFromClauseData(clauseData, tx.Origin.String(), delegatorAddr, tx.Gas, status)
+		return operations, map[string]any{"simulationError": err.Error()}, parseErr
+	}
+
+	gasUsed := uint64(0)
+	for i, result := range results {
+		gasUsed += result.GasUsed
+		if result.Reverted || result.VMError != "" {
+			metadata := map[string]any{
+				"expectedReverted": true,
+				"clauseIndex":      i,
+				"vmError":          result.VMError,
+			}
+			if reason := decodeRevertReason(result.Data); reason != "" {
+				metadata["revertReason"] = reason
+			}
+			operations, err := m.clauseParser.ParseTransactionOperationsFromClauseData(clauseData, tx.Origin.String(), delegatorAddr, tx.Gas, status)
+			return operations, metadata, err
+		}
+	}
+
+	outputs := meshoperations.CallResultsToClauseOutputs(results)
+	operations, err := m.clauseParser.ParseSimulatedTransactionOperations(clauseData, outputs, tx.Origin.String(), delegatorAddr, tx.Gas, status)
+	return operations, map[string]any{
+		"expectedReverted": false,
+		"gasUsed":          gasUsed,
+	}, err
+}
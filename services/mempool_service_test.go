@@ -3,18 +3,25 @@ package services
 import (
 	"context"
 	"errors"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	"github.com/ethereum/go-ethereum/common/math"
 	meshcommon "github.com/vechain/mesh/common"
+	meshconfig "github.com/vechain/mesh/config"
 	meshtests "github.com/vechain/mesh/tests"
 	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/transactions"
+	"github.com/vechain/thor/v2/thor"
 )
 
 func TestNewMempoolService(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
 
-	service := NewMempoolService(mockClient)
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
 
 	if service == nil {
 		t.Fatal("NewMempoolService() returned nil")
@@ -27,7 +34,7 @@ func TestNewMempoolService(t *testing.T) {
 
 func TestMempoolService_Mempool_ValidRequest(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewMempoolService(mockClient)
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
 
 	// Create request
 	request := &types.NetworkRequest{
@@ -51,7 +58,7 @@ func TestMempoolService_Mempool_ValidRequest(t *testing.T) {
 
 func TestMempoolService_Mempool_WithOriginFilter(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewMempoolService(mockClient)
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
 
 	// Create request with origin filter
 	request := &types.NetworkRequest{
@@ -78,7 +85,7 @@ func TestMempoolService_Mempool_WithOriginFilter(t *testing.T) {
 
 func TestMempoolService_MempoolTransaction_ValidRequest(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewMempoolService(mockClient)
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
 
 	// Create request
 	request := &types.MempoolTransactionRequest{
@@ -106,7 +113,7 @@ func TestMempoolService_MempoolTransaction_ValidRequest(t *testing.T) {
 
 func TestMempoolService_Mempool_ClientError(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewMempoolService(mockClient)
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
 
 	// Configure mock to return error
 	mockClient.SetMockError(errors.New("failed to get mempool"))
@@ -132,7 +139,7 @@ func TestMempoolService_Mempool_ClientError(t *testing.T) {
 
 func TestMempoolService_MempoolTransaction_NilTransactionIdentifier(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewMempoolService(mockClient)
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
 
 	request := &types.MempoolTransactionRequest{
 		NetworkIdentifier: &types.NetworkIdentifier{
@@ -156,7 +163,7 @@ func TestMempoolService_MempoolTransaction_NilTransactionIdentifier(t *testing.T
 
 func TestMempoolService_MempoolTransaction_EmptyHash(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewMempoolService(mockClient)
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
 
 	request := &types.MempoolTransactionRequest{
 		NetworkIdentifier: &types.NetworkIdentifier{
@@ -182,7 +189,7 @@ func TestMempoolService_MempoolTransaction_EmptyHash(t *testing.T) {
 
 func TestMempoolService_MempoolTransaction_InvalidHash(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewMempoolService(mockClient)
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
 
 	request := &types.MempoolTransactionRequest{
 		NetworkIdentifier: &types.NetworkIdentifier{
@@ -208,7 +215,7 @@ func TestMempoolService_MempoolTransaction_InvalidHash(t *testing.T) {
 
 func TestMempoolService_MempoolTransaction_TransactionNotFound(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
-	service := NewMempoolService(mockClient)
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
 
 	// Configure mock to return error for transaction not found
 	mockClient.SetMockError(errors.New("transaction not found in mempool"))
@@ -234,3 +241,111 @@ func TestMempoolService_MempoolTransaction_TransactionNotFound(t *testing.T) {
 		t.Errorf("MempoolTransaction() error code = %d, want %d", err.Code, meshcommon.ErrTransactionNotFoundInMempool)
 	}
 }
+
+func TestMempoolService_MempoolTransaction_Preview(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	service := NewMempoolService(mockClient, &meshconfig.Config{MempoolPreview: true})
+	origin := thor.MustParseAddress(meshtests.FirstSoloAddress)
+	recipient := thor.MustParseAddress(meshtests.TestAddress1)
+	forwarded := thor.MustParseAddress(meshtests.TestDelegatorAddress)
+
+	// The recipient forwards part of the deposit during execution
+	mockClient.SetInspectClausesResult([]*api.CallResult{{
+		GasUsed: 30000,
+		Transfers: []*api.Transfer{
+			{Sender: origin, Recipient: recipient, Amount: (*math.HexOrDecimal256)(big.NewInt(1000000000000000000))},
+			{Sender: recipient, Recipient: forwarded, Amount: (*math.HexOrDecimal256)(big.NewInt(5))},
+		},
+	}})
+	request := &types.MempoolTransactionRequest{
+		NetworkIdentifier:     createTestNetworkIdentifier(meshcommon.TestNetwork),
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: "0x1111111111111111111111111111111111111111111111111111111111111111"},
+	}
+
+	response, err := service.MempoolTransaction(context.Background(), request)
+	if err != nil {
+		t.Fatalf("MempoolTransaction() error = %v", err)
+	}
+	if response.Metadata["expectedReverted"] != false || response.Metadata["gasUsed"] != uint64(30000) {
+		t.Errorf("MempoolTransaction() metadata = %v", response.Metadata)
+	}
+	if batchCallData := mockClient.LastBatchCallData; batchCallData == nil || batchCallData.Caller == nil || *batchCallData.Caller != origin {
+		t.Errorf("InspectClauses() call data = %+v, want the origin as caller", batchCallData)
+	}
+
+	var internal []*types.Operation
+	for _, op := range response.Transaction.Operations {
+		if op.Metadata[meshcommon.OperationSourceMetadataKey] == meshcommon.OperationSourceInternal {
+			internal = append(internal, op)
+		}
+		if op.Status == nil || *op.Status != meshcommon.OperationStatusPending {
+			t.Errorf("MempoolTransaction() operation %d status = %v, want pending", op.OperationIdentifier.Index, op.Status)
+		}
+	}
+	if len(internal) != 2 || internal[1].Account.Address != meshtests.TestDelegatorAddress || internal[1].Amount.Value != "5" {
+		t.Errorf("MempoolTransaction() internal operations = %v, want the forwarded transfer", internal)
+	}
+
+	// Transactions expected to revert are flagged with the reason
+	mockClient.SetInspectClausesResult([]*api.CallResult{{Reverted: true, VMError: "execution reverted"}})
+	response, err = service.MempoolTransaction(context.Background(), request)
+	if err != nil {
+		t.Fatalf("MempoolTransaction() error = %v", err)
+	}
+	if response.Metadata["expectedReverted"] != true || response.Metadata["vmError"] != "execution reverted" {
+		t.Errorf("MempoolTransaction() metadata = %v, want expected revert", response.Metadata)
+	}
+}
+
+func TestMempoolService_MempoolTransaction_NoPreview(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
+
+	response, err := service.MempoolTransaction(context.Background(), &types.MempoolTransactionRequest{
+		NetworkIdentifier:     createTestNetworkIdentifier(meshcommon.TestNetwork),
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: "0x1111111111111111111111111111111111111111111111111111111111111111"},
+	})
+	if err != nil {
+		t.Fatalf("MempoolTransaction() error = %v", err)
+	}
+	if response.Metadata != nil || mockClient.LastBatchCallData != nil {
+		t.Errorf("MempoolTransaction() simulated the transaction without preview: %v", response.Metadata)
+	}
+}
+
+func TestMempoolService_MempoolTransaction_ContractCallWithValue(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	service := NewMempoolService(mockClient, &meshconfig.Config{})
+	origin := thor.MustParseAddress(meshtests.FirstSoloAddress)
+	contract := thor.MustParseAddress(meshtests.SimulatedContractAddress)
+	mockClient.MockMempoolTx = &transactions.Transaction{
+		Origin:  origin,
+		Gas:     50000,
+		Clauses: api.Clauses{{To: &contract, Value: (*math.HexOrDecimal256)(big.NewInt(1000)), Data: "0xd0e30db0"}},
+	}
+
+	response, err := service.MempoolTransaction(context.Background(), &types.MempoolTransactionRequest{
+		NetworkIdentifier:     createTestNetworkIdentifier(meshcommon.TestNetwork),
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: "0x1111111111111111111111111111111111111111111111111111111111111111"},
+	})
+	if err != nil {
+		t.Fatalf("MempoolTransaction() error = %v", err)
+	}
+
+	// The VET sent to the contract is debited and credited next to the call, then the fee
+	var opTypes []string
+	balances := map[string]string{}
+	for _, op := range response.Transaction.Operations {
+		opTypes = append(opTypes, op.Type)
+		if op.Type == meshcommon.OperationTypeTransfer {
+			balances[op.Account.Address] = op.Amount.Value
+		}
+	}
+	want := []string{meshcommon.OperationTypeTransfer, meshcommon.OperationTypeTransfer, meshcommon.OperationTypeContractCall, meshcommon.OperationTypeFee}
+	if strings.Join(opTypes, ",") != strings.Join(want, ",") {
+		t.Errorf("MempoolTransaction() operation types = %v, want %v", opTypes, want)
+	}
+	if balances[origin.String()] != "-1000" || balances[contract.String()] != "1000" {
+		t.Errorf("MempoolTransaction() transfers = %v, want 1000 from the origin to the contract", balances)
+	}
+}
@@ -3,18 +3,20 @@ package services
 import (
 	"context"
 	"math"
-	"strconv"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	meshcommon "github.com/vechain/mesh/common"
 	meshconfig "github.com/vechain/mesh/config"
 	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/block"
+	"github.com/vechain/thor/v2/thor"
 )
 
 // NetworkService handles network-related endpoints
 type NetworkService struct {
 	vechainClient meshthor.VeChainClientInterface
 	config        *meshconfig.Config
+	syncMonitor   *SyncMonitor
 }
 
 // Peer represents a connected peer
@@ -28,9 +30,20 @@ func NewNetworkService(vechainClient meshthor.VeChainClientInterface, config *me
 	return &NetworkService{
 		vechainClient: vechainClient,
 		config:        config,
+		// A solo node has no peers, and with on demand blocks it keeps its head while idle
+		syncMonitor: NewSyncMonitor(
+			config.GetSyncStallThreshold(),
+			config.Network == meshcommon.SoloNetwork,
+			config.Network == meshcommon.SoloNetwork && config.SoloOnDemand,
+		),
 	}
 }
 
+// SyncMonitor returns the monitor deriving the sync stage of the node, to share it with the readiness checks
+func (n *NetworkService) SyncMonitor() *SyncMonitor {
+	return n.syncMonitor
+}
+
 // NetworkList returns the list of supported networks
 func (n *NetworkService) NetworkList(
 	ctx context.Context,
@@ -63,29 +76,14 @@ func (n *NetworkService) NetworkStatus(
 		return nil, meshcommon.GetError(meshcommon.ErrFailedToGetGenesisBlock)
 	}
 
-	// Get sync progress
-	progress, err := n.vechainClient.GetSyncProgress()
-	if err != nil {
-		return nil, meshcommon.GetError(meshcommon.ErrFailedToGetSyncProgress)
-	}
-
 	// Get peers
 	peers, err := n.vechainClient.GetPeers()
 	if err != nil {
 		return nil, meshcommon.GetError(meshcommon.ErrFailedToGetPeers)
 	}
 
-	// Convert peers to utils.Peer type
-	utilsPeers := make([]Peer, len(peers))
-	for i, peer := range peers {
-		utilsPeers[i] = Peer{
-			PeerID:      peer.PeerID,
-			BestBlockID: peer.BestBlockID,
-		}
-	}
-
-	// Calculate target index
-	targetIndex := getTargetIndex(int64(bestBlock.Number), utilsPeers)
+	// Derive the sync stage from the peers and the advancement of the head
+	syncState := n.syncMonitor.Observe(int64(bestBlock.Number), bestBlock.ID, bestBlock.Timestamp, toPeers(peers))
 
 	// Convert peers to types.Peer
 	meshPeers := make([]*types.Peer, len(peers))
@@ -95,9 +93,10 @@ func (n *NetworkService) NetworkStatus(
 		}
 	}
 
-	currentIndex := int64(bestBlock.Number)
-	stage := "block sync"
-	synced := progress == 1.0
+	currentIndex := syncState.CurrentIndex
+	targetIndex := syncState.TargetIndex
+	stage := syncState.Stage
+	synced := syncState.Synced()
 
 	bestBlockTimestamp := bestBlock.Timestamp * 1000 // Convert to milliseconds
 	if bestBlockTimestamp > math.MaxInt64 {
@@ -127,6 +126,47 @@ func (n *NetworkService) NetworkStatus(
 	}, nil
 }
 
+// NetworkStatusResponse is the /network/status response together with metadata,
+// which the Mesh specification does not define for this endpoint
+type NetworkStatusResponse struct {
+	*types.NetworkStatusResponse
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// NetworkStatusWithFinality returns the current network status along with the justified and
+// finalized checkpoints in metadata, so that clients can wait for finality instead of confirmations
+func (n *NetworkService) NetworkStatusWithFinality(
+	ctx context.Context,
+	req *types.NetworkRequest,
+) (*NetworkStatusResponse, *types.Error) {
+	status, rosettaErr := n.NetworkStatus(ctx, req)
+	if rosettaErr != nil {
+		return nil, rosettaErr
+	}
+
+	metadata := map[string]any{}
+	for key, revision := range map[string]string{
+		"justifiedBlockIdentifier": meshcommon.RevisionJustified,
+		"finalizedBlockIdentifier": meshcommon.RevisionFinalized,
+	} {
+		checkpoint, err := n.vechainClient.GetBlockHeader(revision)
+		if err != nil {
+			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrFailedToGetFinalityCheckpoints, map[string]any{
+				"error": err.Error(),
+			})
+		}
+		metadata[key] = &types.BlockIdentifier{
+			Index: int64(checkpoint.Number),
+			Hash:  checkpoint.ID.String(),
+		}
+	}
+
+	return &NetworkStatusResponse{
+		NetworkStatusResponse: status,
+		Metadata:              metadata,
+	}, nil
+}
+
 // NetworkOptions returns network options and capabilities
 func (n *NetworkService) NetworkOptions(
 	ctx context.Context,
@@ -150,13 +190,16 @@ func (n *NetworkService) NetworkOptions(
 		meshcommon.OperationTypeFee,
 		meshcommon.OperationTypeFeeDelegation,
 		meshcommon.OperationTypeContractCall,
+		meshcommon.OperationTypeContractDeploy,
 	}
 
-	// Define balance exemptions for VTHO (dynamic exemption)
+	// Fee operations debit the VTHO actually paid, so VTHO no longer needs a dynamic exemption.
+	// VTHO is still generated over time by holding VET, and credited to block beneficiaries as reward,
+	// without any operation: the live balance of an account can only be greater than its operations.
 	balanceExemptions := []*types.BalanceExemption{
 		{
 			Currency:      meshcommon.VTHOCurrency,
-			ExemptionType: types.BalanceDynamic,
+			ExemptionType: types.BalanceGreaterOrEqual,
 		},
 	}
 
@@ -166,16 +209,21 @@ func (n *NetworkService) NetworkOptions(
 		OperationTypes:          operationTypes,
 		Errors:                  meshcommon.GetAllErrors(),
 		HistoricalBalanceLookup: true,
-		CallMethods:             []string{meshcommon.CallMethodInspectClauses},
+		CallMethods:             []string{meshcommon.CallMethodInspectClauses, meshcommon.CallMethodSponsorTransaction, meshcommon.CallMethodTransactionStatus},
 		BalanceExemptions:       balanceExemptions,
 		MempoolCoins:            false,
 	}
 
-	// Create version object
+	// Create version object, listing the supported tokens
 	version := &types.Version{
 		RosettaVersion: n.config.MeshVersion,
 		NodeVersion:    n.config.NodeVersion,
 	}
+	if tokens := n.config.TokenRegistry.Currencies(); len(tokens) > 0 {
+		version.Metadata = map[string]any{
+			"tokens": tokens,
+		}
+	}
 
 	// Create response
 	return &types.NetworkOptionsResponse{
@@ -184,18 +232,25 @@ func (n *NetworkService) NetworkOptions(
 	}, nil
 }
 
+// toPeers converts the peers of the node client
+func toPeers(peers []meshthor.Peer) []Peer {
+	result := make([]Peer, len(peers))
+	for i, peer := range peers {
+		result[i] = Peer{
+			PeerID:      peer.PeerID,
+			BestBlockID: peer.BestBlockID,
+		}
+	}
+	return result
+}
+
 // getTargetIndex calculates the target index based on local index and peers
 func getTargetIndex(localIndex int64, peers []Peer) int64 {
 	result := localIndex
 	for _, peer := range peers {
-		// Extract block number from bestBlockID (first 8 bytes = 16 hex characters)
-		if len(peer.BestBlockID) >= 16 {
-			blockNumHex := peer.BestBlockID[:16]
-			if blockNum, err := strconv.ParseInt(blockNumHex, 16, 64); err == nil {
-				if result < blockNum {
-					result = blockNum
-				}
-			}
+		// The block number is encoded in the first 4 bytes of the block ID
+		if bestBlockID, err := thor.ParseBytes32(peer.BestBlockID); err == nil {
+			result = max(result, int64(block.Number(bestBlockID)))
 		}
 	}
 	return result
@@ -2,14 +2,18 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	meshcommon "github.com/vechain/mesh/common"
 	meshconfig "github.com/vechain/mesh/config"
 	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/thor"
 )
 
 func TestNewNetworkService(t *testing.T) {
@@ -95,6 +99,46 @@ func TestNetworkService_NetworkOptions(t *testing.T) {
 	}
 }
 
+func TestNetworkService_NetworkOptions_BalanceExemptions(t *testing.T) {
+	service := NewNetworkService(meshthor.NewMockVeChainClient(), &meshconfig.Config{})
+
+	response, errResp := service.NetworkOptions(context.Background(), &types.NetworkRequest{})
+	if errResp != nil {
+		t.Fatalf("NetworkOptions() error = %v", errResp)
+	}
+
+	// Only the VTHO generated by holding VET is not reported by operations, VET balances are exact
+	exemptions := response.Allow.BalanceExemptions
+	if len(exemptions) != 1 {
+		t.Fatalf("NetworkOptions() balance exemptions = %v, want a single VTHO exemption", exemptions)
+	}
+	if exemptions[0].Currency != meshcommon.VTHOCurrency || exemptions[0].ExemptionType != types.BalanceGreaterOrEqual {
+		t.Errorf("NetworkOptions() balance exemption = %+v, want VTHO greater or equal", exemptions[0])
+	}
+}
+
+func TestNetworkService_NetworkOptions_Tokens(t *testing.T) {
+	registry, err := meshcommon.NewTokenRegistry([]*types.Currency{{
+		Symbol:   "USDC",
+		Decimals: 6,
+		Metadata: map[string]any{"contractAddress": "0x1234567890123456789012345678901234567890"},
+	}}, true, 0)
+	if err != nil {
+		t.Fatalf("NewTokenRegistry() error = %v", err)
+	}
+	service := NewNetworkService(meshthor.NewMockVeChainClient(), &meshconfig.Config{TokenRegistry: registry})
+
+	response, errResp := service.NetworkOptions(context.Background(), &types.NetworkRequest{})
+	if errResp != nil {
+		t.Fatalf("NetworkOptions() error = %v", errResp)
+	}
+
+	tokens, ok := response.Version.Metadata["tokens"].([]*types.Currency)
+	if !ok || len(tokens) != 1 || tokens[0].Symbol != "USDC" {
+		t.Errorf("NetworkOptions() tokens = %v", response.Version.Metadata["tokens"])
+	}
+}
+
 func TestNetworkService_NetworkStatus_ValidRequest(t *testing.T) {
 	config := &meshconfig.Config{}
 	mockClient := meshthor.NewMockVeChainClient()
@@ -168,10 +212,13 @@ func TestNetworkService_NetworkStatus_ClientErrors(t *testing.T) {
 func TestGetTargetIndex(t *testing.T) {
 	peers := []Peer{
 		{
-			BestBlockID: "0000000000000001",
+			BestBlockID: "0x00000001abcdef1234567890abcdef1234567890abcdef1234567890abcdef12",
+		},
+		{
+			BestBlockID: "0x00000002abcdef1234567890abcdef1234567890abcdef1234567890abcdef12",
 		},
 		{
-			BestBlockID: "0000000000000002",
+			BestBlockID: "invalid",
 		},
 	}
 
@@ -187,3 +234,65 @@ func TestGetTargetIndex(t *testing.T) {
 		t.Errorf("Expected index 5, got %d", index)
 	}
 }
+
+// checkpointClient serves the finality checkpoints of a chain whose best block is 100
+type checkpointClient struct {
+	*meshthor.MockVeChainClient
+	checkpointErr error
+}
+
+func (c *checkpointClient) GetBlockHeader(revision string) (*api.JSONBlockSummary, error) {
+	if c.checkpointErr != nil {
+		return nil, c.checkpointErr
+	}
+	number := map[string]uint32{meshcommon.RevisionJustified: 90, meshcommon.RevisionFinalized: 80}[revision]
+	return &api.JSONBlockSummary{Number: number, ID: thor.BytesToBytes32([]byte{byte(number)})}, nil
+}
+
+func TestNetworkService_NetworkStatusWithFinality(t *testing.T) {
+	client := &checkpointClient{MockVeChainClient: meshthor.NewMockVeChainClient()}
+	service := NewNetworkService(client, &meshconfig.Config{})
+	request := &types.NetworkRequest{NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork)}
+
+	response, rosettaErr := service.NetworkStatusWithFinality(context.Background(), request)
+	if rosettaErr != nil {
+		t.Fatalf("NetworkStatusWithFinality() error = %v", rosettaErr)
+	}
+	if response.CurrentBlockIdentifier.Index != 100 {
+		t.Errorf("NetworkStatusWithFinality() current block = %v, want 100", response.CurrentBlockIdentifier)
+	}
+	justified, _ := response.Metadata["justifiedBlockIdentifier"].(*types.BlockIdentifier)
+	finalized, _ := response.Metadata["finalizedBlockIdentifier"].(*types.BlockIdentifier)
+	if justified == nil || justified.Index != 90 || finalized == nil || finalized.Index != 80 ||
+		finalized.Hash != thor.BytesToBytes32([]byte{80}).String() {
+		t.Errorf("NetworkStatusWithFinality() metadata = %v", response.Metadata)
+	}
+
+	client.checkpointErr = errors.New("node unavailable")
+	if _, rosettaErr := service.NetworkStatusWithFinality(context.Background(), request); rosettaErr == nil ||
+		rosettaErr.Code != meshcommon.ErrFailedToGetFinalityCheckpoints {
+		t.Errorf("NetworkStatusWithFinality() error = %v, want finality checkpoints error", rosettaErr)
+	}
+}
+
+func TestNetworkService_NetworkStatus_Stalled(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+	service := NewNetworkService(mockClient, &meshconfig.Config{SyncStallThreshold: 30})
+	now := time.Now()
+	service.syncMonitor.now = func() time.Time { return now }
+	request := &types.NetworkRequest{NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork)}
+
+	if _, err := service.NetworkStatus(context.Background(), request); err != nil {
+		t.Fatalf("NetworkStatus() error = %v", err)
+	}
+
+	// The head did not change for longer than the configured threshold
+	service.syncMonitor.now = func() time.Time { return now.Add(31 * time.Second) }
+	response, err := service.NetworkStatus(context.Background(), request)
+	if err != nil {
+		t.Fatalf("NetworkStatus() error = %v", err)
+	}
+	if *response.SyncStatus.Stage != meshcommon.SyncStageStalled || *response.SyncStatus.Synced {
+		t.Errorf("NetworkStatus() stage = %s, synced = %v, want stalled", *response.SyncStatus.Stage, *response.SyncStatus.Synced)
+	}
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+)
+
+// NetworkStatusHandler serves /network/status with the finality checkpoints in metadata.
+// It replaces the SDK controller, whose response type has no metadata, and validates requests the same way.
+func NetworkStatusHandler(networkService *NetworkService, asrt *asserter.Asserter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		networkRequest := &types.NetworkRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&networkRequest); err != nil {
+			server.EncodeJSONResponse(&types.Error{Message: err.Error()}, http.StatusInternalServerError, w)
+			return
+		}
+
+		if err := asrt.NetworkRequest(networkRequest); err != nil {
+			server.EncodeJSONResponse(&types.Error{Message: err.Error()}, http.StatusInternalServerError, w)
+			return
+		}
+
+		response, rosettaErr := networkService.NetworkStatusWithFinality(r.Context(), networkRequest)
+		if rosettaErr != nil {
+			server.EncodeJSONResponse(rosettaErr, http.StatusInternalServerError, w)
+			return
+		}
+
+		server.EncodeJSONResponse(response, http.StatusOK, w)
+	})
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/asserter"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	meshcommon "github.com/vechain/mesh/common"
+	meshconfig "github.com/vechain/mesh/config"
+	meshthor "github.com/vechain/mesh/thor"
+)
+
+func TestNetworkStatusHandler(t *testing.T) {
+	asrt, err := asserter.NewServer(
+		[]string{meshcommon.OperationTypeTransfer},
+		true,
+		[]*types.NetworkIdentifier{createTestNetworkIdentifier(meshcommon.TestNetwork)},
+		nil,
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatalf("asserter.NewServer() error = %v", err)
+	}
+	client := &checkpointClient{MockVeChainClient: meshthor.NewMockVeChainClient()}
+	handler := NetworkStatusHandler(NewNetworkService(client, &meshconfig.Config{}), asrt)
+
+	body, _ := json.Marshal(types.NetworkRequest{NetworkIdentifier: createTestNetworkIdentifier(meshcommon.TestNetwork)})
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, meshcommon.NetworkStatusEndpoint, bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status code = %d, body: %s", w.Code, w.Body.String())
+	}
+
+	// The standard fields are kept at the top level next to the metadata
+	var response struct {
+		CurrentBlockIdentifier *types.BlockIdentifier `json:"current_block_identifier"`
+		Metadata               struct {
+			FinalizedBlockIdentifier *types.BlockIdentifier `json:"finalizedBlockIdentifier"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if response.CurrentBlockIdentifier == nil || response.Metadata.FinalizedBlockIdentifier == nil ||
+		response.Metadata.FinalizedBlockIdentifier.Index != 80 {
+		t.Errorf("response = %s", w.Body.String())
+	}
+
+	// Requests for another network are rejected by the asserter
+	body, _ = json.Marshal(types.NetworkRequest{NetworkIdentifier: createTestNetworkIdentifier(meshcommon.MainNetwork)})
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, meshcommon.NetworkStatusEndpoint, bytes.NewReader(body)))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status code = %d for an unsupported network, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
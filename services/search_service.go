@@ -2,46 +2,432 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	meshcommon "github.com/vechain/mesh/common"
 	meshoperations "github.com/vechain/mesh/common/operations"
 	meshtx "github.com/vechain/mesh/common/tx"
+	"github.com/vechain/mesh/common/vip180"
+	meshconfig "github.com/vechain/mesh/config"
 	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/logdb"
+	"github.com/vechain/thor/v2/thor"
+)
+
+const (
+	// searchDefaultLimit is the number of transactions returned when the request has no limit
+	searchDefaultLimit = 100
+	// searchMaxLimit is the maximum number of transactions returned by a single request
+	searchMaxLimit = 100
+	// searchCandidatesPerResult bounds the number of transactions examined by a page, most recent first,
+	// for each transaction of the page
+	searchCandidatesPerResult = 5
+	// searchLogsPageSize is the number of logs requested per page, the default limit of Thor's logs API
+	searchLogsPageSize = 1000
+	// searchCursorTxIndexBits is the number of bits of the offset holding the transaction index of the cursor
+	searchCursorTxIndexBits = 24
 )
 
 // SearchService handles search API endpoints
 type SearchService struct {
 	vechainClient meshthor.VeChainClientInterface
+	config        *meshconfig.Config
 	encoder       *meshtx.MeshTransactionEncoder
 	clauseParser  *meshoperations.ClauseParser
+	vip180Encoder *vip180.VIP180Encoder
 }
 
 // NewSearchService creates a new search service
-func NewSearchService(vechainClient meshthor.VeChainClientInterface) *SearchService {
+func NewSearchService(vechainClient meshthor.VeChainClientInterface, config *meshconfig.Config) *SearchService {
 	return &SearchService{
 		vechainClient: vechainClient,
-		encoder:       meshtx.NewMeshTransactionEncoder(vechainClient),
-		clauseParser:  meshoperations.NewClauseParser(vechainClient, meshoperations.NewOperationsExtractor()),
+		config:        config,
+		encoder:       meshtx.NewMeshTransactionEncoder(vechainClient, config.TokenRegistry),
+		clauseParser:  meshoperations.NewClauseParser(vechainClient, meshoperations.NewOperationsExtractor(config.TokenRegistry)),
+		vip180Encoder: vip180.NewVIP180Encoder(),
 	}
 }
 
+// searchCriteria holds the conditions of a transaction search, all of them must be met by a single operation
+type searchCriteria struct {
+	txID     string
+	account  *thor.Address
+	currency *types.Currency
+	// token is the contract of the currency, nil for VET
+	token    *thor.Address
+	status   string
+	opType   string
+	success  *bool
+	maxBlock *int64
+}
+
 // SearchTransactions handles the /search/transactions endpoint.
-// This implementation only supports searching by transaction hash.
-// It requires a TransactionIdentifier with a valid hash and returns
-// exactly one transaction with its operations and block information.
-// Searching by other criteria is not currently supported.
+// Transactions are looked up by hash, or found through the VET transfer and VIP180 Transfer event logs
+// of the requested account and/or currency, most recent first. A transaction matches when one of its
+// operations meets every condition of the request. Reverted transactions and fees leave no logs, so
+// searching them without a hash is rejected rather than answered with an empty result.
+//
+// A page examines at most searchCandidatesPerResult transactions for each requested result. The offset is
+// a cursor rather than a number of results: next_offset holds the log position of the last examined
+// transaction, and is set as long as older logs remain, even when the page found no match. The next page
+// resumes from that position, so it neither examines earlier transactions again nor shifts with new
+// blocks. The total count is the number of transactions of the page, as the total number of matches is
+// only known once every log was examined.
 func (s *SearchService) SearchTransactions(
 	ctx context.Context,
 	req *types.SearchTransactionsRequest,
 ) (*types.SearchTransactionsResponse, *types.Error) {
-	// Validate transaction identifier
-	if req.TransactionIdentifier == nil || req.TransactionIdentifier.Hash == "" {
-		return nil, meshcommon.GetError(meshcommon.ErrInvalidRequestParameters)
+	criteria, searchErr := s.parseSearchCriteria(req)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	var cursor *searchCursor
+	if req.Offset != nil {
+		if *req.Offset < 0 {
+			return nil, meshcommon.GetError(meshcommon.ErrInvalidRequestParameters)
+		}
+		if *req.Offset > 0 {
+			cursor = decodeSearchCursor(*req.Offset)
+		}
+	}
+
+	limit := int64(searchDefaultLimit)
+	if req.Limit != nil {
+		limit = *req.Limit
+		if limit < 1 || limit > searchMaxLimit {
+			return nil, meshcommon.GetError(meshcommon.ErrInvalidRequestParameters)
+		}
+	}
+
+	candidates, err := s.newCandidateIterator(criteria, cursor)
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrFailedToSearchTransactions, map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	budget := limit * searchCandidatesPerResult
+	transactions := []*types.BlockTransaction{}
+	for examined := int64(0); int64(len(transactions)) < limit && examined < budget; examined++ {
+		txID, err := candidates.next()
+		if err != nil {
+			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrFailedToSearchTransactions, map[string]any{
+				"error": err.Error(),
+			})
+		}
+		if txID == "" {
+			break
+		}
+
+		blockTransaction, txErr := s.getBlockTransaction(txID)
+		if txErr != nil {
+			return nil, txErr
+		}
+		if criteria.matches(blockTransaction) {
+			transactions = append(transactions, blockTransaction)
+		}
+	}
+
+	response := &types.SearchTransactionsResponse{
+		Transactions: transactions,
+		TotalCount:   int64(len(transactions)),
+	}
+	more, err := candidates.hasMore()
+	if err != nil {
+		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrFailedToSearchTransactions, map[string]any{
+			"error": err.Error(),
+		})
+	}
+	if more {
+		nextOffset := candidates.position.encode()
+		response.NextOffset = &nextOffset
+	}
+	return response, nil
+}
+
+// parseSearchCriteria validates the search conditions of a request
+func (s *SearchService) parseSearchCriteria(req *types.SearchTransactionsRequest) (*searchCriteria, *types.Error) {
+	invalid := func(reason string) *types.Error {
+		return meshcommon.GetErrorWithMetadata(meshcommon.ErrInvalidRequestParameters, map[string]any{
+			"error": reason,
+		})
+	}
+
+	if req.Operator != nil && *req.Operator != types.AND {
+		return nil, invalid("only the and operator is supported")
+	}
+	if req.CoinIdentifier != nil {
+		return nil, invalid("coin identifiers are not supported by an account-based chain")
 	}
 
-	txID := req.TransactionIdentifier.Hash
+	criteria := &searchCriteria{}
+	if req.TransactionIdentifier != nil {
+		if req.TransactionIdentifier.Hash == "" {
+			return nil, meshcommon.GetError(meshcommon.ErrInvalidRequestParameters)
+		}
+		criteria.txID = req.TransactionIdentifier.Hash
+	}
+
+	address := ""
+	if req.AccountIdentifier != nil {
+		address = req.AccountIdentifier.Address
+	}
+	if req.Address != nil {
+		if address != "" && !strings.EqualFold(address, *req.Address) {
+			return nil, invalid("address does not match account identifier")
+		}
+		address = *req.Address
+	}
+	if address != "" {
+		account, err := thor.ParseAddress(address)
+		if err != nil {
+			return nil, invalid(fmt.Sprintf("invalid address: %v", err))
+		}
+		criteria.account = &account
+	}
+
+	if req.Currency != nil {
+		if err := s.config.TokenRegistry.ValidateCurrency(req.Currency); err != nil {
+			return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrUnsupportedToken, map[string]any{
+				"error": err.Error(),
+			})
+		}
+		criteria.currency = req.Currency
+		if req.Currency.Symbol == meshcommon.VTHOCurrency.Symbol {
+			criteria.currency = meshcommon.VTHOCurrency
+		}
+		if contractAddress, ok := criteria.currency.Metadata["contractAddress"].(string); ok {
+			token, err := thor.ParseAddress(contractAddress)
+			if err != nil {
+				return nil, invalid(fmt.Sprintf("invalid contract address: %v", err))
+			}
+			criteria.token = &token
+		}
+	}
+
+	if req.Status != nil {
+		criteria.status = *req.Status
+	}
+	if req.Type != nil {
+		criteria.opType = *req.Type
+	}
+	criteria.success = req.Success
+
+	if req.MaxBlock != nil {
+		if *req.MaxBlock < 0 {
+			return nil, invalid("max_block must be non-negative")
+		}
+		criteria.maxBlock = req.MaxBlock
+	}
+
+	if criteria.txID != "" {
+		return criteria, nil
+	}
+	if criteria.account == nil && criteria.currency == nil {
+		return nil, invalid("a transaction identifier, account or currency is required")
+	}
+	// Without a hash, transactions are found through their logs
+	if criteria.status == meshcommon.OperationStatusReverted || (criteria.success != nil && !*criteria.success) {
+		return nil, invalid("reverted transactions leave no logs and can only be searched by transaction identifier")
+	}
+	if criteria.opType == meshcommon.OperationTypeFee || criteria.opType == meshcommon.OperationTypeFeeDelegation {
+		return nil, invalid("fees leave no logs and can only be searched by transaction identifier")
+	}
+	if criteria.currency == meshcommon.VTHOCurrency && criteria.opType == "" {
+		return nil, invalid("VTHO paid as fee leaves no logs, search VTHO with an operation type or by transaction identifier")
+	}
+	return criteria, nil
+}
+
+// searchCursor is the log position of a transaction, pages resume with the transactions before it
+type searchCursor struct {
+	blockNumber uint32
+	txIndex     uint32
+}
+
+// decodeSearchCursor decodes the cursor held by a search offset
+func decodeSearchCursor(offset int64) *searchCursor {
+	return &searchCursor{
+		blockNumber: uint32(offset >> searchCursorTxIndexBits),
+		txIndex:     uint32(offset & (1<<searchCursorTxIndexBits - 1)),
+	}
+}
+
+// encode returns the search offset holding the cursor
+func (c *searchCursor) encode() int64 {
+	return int64(c.blockNumber)<<searchCursorTxIndexBits | int64(c.txIndex)
+}
+
+// isAfter checks whether a log was emitted by the transaction of the cursor or a later one
+func (c *searchCursor) isAfter(meta *api.LogMeta) bool {
+	if meta.BlockNumber != c.blockNumber {
+		return meta.BlockNumber > c.blockNumber
+	}
+	return logTxIndex(meta) >= c.txIndex
+}
 
+// candidateIterator yields the IDs of the transactions that may match the criteria, most recent first
+type candidateIterator struct {
+	pending []string
+	cursors []*logCursor
+	// resume skips the logs of the transactions returned by previous pages, nil on the first page
+	resume *searchCursor
+	// position is the log position of the last yielded transaction
+	position searchCursor
+}
+
+// newCandidateIterator merges the transfer and event logs of the account and/or currency from the
+// resume cursor, or yields the searched transaction alone when the criteria have a hash
+func (s *SearchService) newCandidateIterator(criteria *searchCriteria, resume *searchCursor) (*candidateIterator, error) {
+	if criteria.txID != "" {
+		if resume != nil {
+			return &candidateIterator{}, nil
+		}
+		return &candidateIterator{pending: []string{criteria.txID}}, nil
+	}
+
+	// Pin the range so that the logs of new blocks do not come before the cursor
+	to := uint64(0)
+	switch {
+	case resume != nil:
+		to = uint64(resume.blockNumber)
+		if criteria.maxBlock != nil {
+			to = min(to, uint64(*criteria.maxBlock))
+		}
+	case criteria.maxBlock != nil:
+		to = uint64(*criteria.maxBlock)
+	default:
+		best, err := s.vechainClient.GetBlockHeader("best")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get best block: %w", err)
+		}
+		to = uint64(best.Number)
+	}
+	from := uint64(0)
+	logRange := &api.Range{Unit: api.BlockRangeType, From: &from, To: &to}
+
+	var cursors []*logCursor
+	if criteria.currency == nil || criteria.token == nil {
+		cursors = append(cursors, s.transferCursor(criteria.account, logRange))
+	}
+	if criteria.currency == nil || criteria.token != nil {
+		cursors = append(cursors, s.eventCursor(criteria.account, criteria.token, logRange))
+	}
+	return &candidateIterator{cursors: cursors, resume: resume}, nil
+}
+
+// peekNewest returns the cursor holding the most recent log not yet consumed, nil when there are no more logs
+func (it *candidateIterator) peekNewest() (*logCursor, error) {
+	var newest *logCursor
+	for _, cursor := range it.cursors {
+		meta, err := cursor.peek()
+		// Skip the logs of the transactions returned by previous pages
+		for err == nil && meta != nil && it.resume != nil && it.resume.isAfter(meta) {
+			cursor.page = cursor.page[1:]
+			meta, err = cursor.peek()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if meta != nil && (newest == nil || isNewerLog(meta, newest.page[0])) {
+			newest = cursor
+		}
+	}
+	return newest, nil
+}
+
+// next returns the next candidate transaction ID, or an empty string when there are no more logs.
+// Every log of the transaction is consumed, so that the position is past the whole transaction.
+func (it *candidateIterator) next() (string, error) {
+	if len(it.pending) > 0 {
+		txID := it.pending[0]
+		it.pending = it.pending[1:]
+		return txID, nil
+	}
+
+	newest, err := it.peekNewest()
+	if err != nil || newest == nil {
+		return "", err
+	}
+	meta := newest.page[0]
+	it.position = searchCursor{blockNumber: meta.BlockNumber, txIndex: logTxIndex(&meta)}
+	// The logs of a transaction follow each other in every cursor, the resume cursor skips the rest
+	it.resume = &it.position
+	if _, err := it.peekNewest(); err != nil {
+		return "", err
+	}
+	return meta.TxID.String(), nil
+}
+
+// hasMore checks whether logs remain after the last yielded transaction
+func (it *candidateIterator) hasMore() (bool, error) {
+	if len(it.pending) > 0 {
+		return true, nil
+	}
+	newest, err := it.peekNewest()
+	return newest != nil, err
+}
+
+// transferCursor iterates over the VET transfer logs sent or received by account, or all transfers without account
+func (s *SearchService) transferCursor(account *thor.Address, logRange *api.Range) *logCursor {
+	var criteriaSet []*logdb.TransferCriteria
+	if account != nil {
+		criteriaSet = []*logdb.TransferCriteria{{Sender: account}, {Recipient: account}}
+	}
+	return &logCursor{fetch: func(options *api.Options) ([]api.LogMeta, error) {
+		transfers, err := s.vechainClient.FilterTransfers(&api.TransferFilter{
+			CriteriaSet: criteriaSet,
+			Range:       logRange,
+			Options:     options,
+			Order:       logdb.DESC,
+		})
+		if err != nil {
+			return nil, err
+		}
+		metas := make([]api.LogMeta, 0, len(transfers))
+		for _, transfer := range transfers {
+			metas = append(metas, transfer.Meta)
+		}
+		return metas, nil
+	}}
+}
+
+// eventCursor iterates over the Transfer event logs of token, or of any contract without token,
+// sent or received by account
+func (s *SearchService) eventCursor(account, token *thor.Address, logRange *api.Range) *logCursor {
+	transferEventID := s.vip180Encoder.TransferEventID()
+	criteriaSet := []*api.EventCriteria{{Address: token, TopicSet: api.TopicSet{Topic0: &transferEventID}}}
+	if account != nil {
+		accountTopic := thor.BytesToBytes32(account.Bytes())
+		criteriaSet = []*api.EventCriteria{
+			{Address: token, TopicSet: api.TopicSet{Topic0: &transferEventID, Topic1: &accountTopic}},
+			{Address: token, TopicSet: api.TopicSet{Topic0: &transferEventID, Topic2: &accountTopic}},
+		}
+	}
+	return &logCursor{fetch: func(options *api.Options) ([]api.LogMeta, error) {
+		events, err := s.vechainClient.FilterEvents(&api.EventFilter{
+			CriteriaSet: criteriaSet,
+			Range:       logRange,
+			Options:     options,
+			Order:       logdb.DESC,
+		})
+		if err != nil {
+			return nil, err
+		}
+		metas := make([]api.LogMeta, 0, len(events))
+		for _, event := range events {
+			metas = append(metas, event.Meta)
+		}
+		return metas, nil
+	}}
+}
+
+// getBlockTransaction fetches a transaction with its receipt and parses its operations
+func (s *SearchService) getBlockTransaction(txID string) (*types.BlockTransaction, *types.Error) {
 	// Get transaction to get the clauses
 	tx, err := s.vechainClient.GetTransaction(txID)
 	if err != nil {
@@ -71,33 +457,112 @@ func (s *SearchService) SearchTransactions(
 		delegatorAddr = tx.Delegator.String()
 	}
 
-	operations, err := s.clauseParser.ParseOperationsFromAPIClauses(tx.Clauses, tx.Origin.String(), delegatorAddr, txReceipt.GasUsed, &status)
+	fee := meshoperations.NewTransactionFee(txReceipt.GasUsed, txReceipt.GasPayer, txReceipt.Paid, txReceipt.Reward)
+	operations, err := s.clauseParser.ParseExecutedTransactionOperations(meshoperations.APIClausesToClauseData(tx.Clauses), meshoperations.APIOutputsToClauseOutputs(txReceipt.Outputs), tx.Origin.String(), delegatorAddr, fee, &status)
 	if err != nil {
 		return nil, meshcommon.GetErrorWithMetadata(meshcommon.ErrInternalServerError, map[string]any{
 			"error": err.Error(),
 		})
 	}
 
-	// Create transaction identifier
-	transactionIdentifier := &types.TransactionIdentifier{
-		Hash: txID,
+	// Create block transaction
+	return &types.BlockTransaction{
+		BlockIdentifier: blockIdentifier,
+		Transaction: &types.Transaction{
+			TransactionIdentifier: &types.TransactionIdentifier{
+				Hash: txID,
+			},
+			Operations: operations,
+		},
+	}, nil
+}
+
+// matches checks whether a transaction is within the block range and has an operation meeting every condition
+func (c *searchCriteria) matches(blockTransaction *types.BlockTransaction) bool {
+	if c.maxBlock != nil && blockTransaction.BlockIdentifier.Index > *c.maxBlock {
+		return false
+	}
+	if c.account == nil && c.currency == nil && c.status == "" && c.opType == "" && c.success == nil {
+		return true
+	}
+	for _, op := range blockTransaction.Transaction.Operations {
+		if c.matchesOperation(op) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Create transaction with operations
-	transaction := &types.Transaction{
-		TransactionIdentifier: transactionIdentifier,
-		Operations:            operations,
+// matchesOperation checks whether an operation meets every condition
+func (c *searchCriteria) matchesOperation(op *types.Operation) bool {
+	if c.account != nil && (op.Account == nil || !strings.EqualFold(op.Account.Address, c.account.String())) {
+		return false
+	}
+	if c.currency != nil && (op.Amount == nil || !c.matchesCurrency(op.Amount.Currency)) {
+		return false
+	}
+	if c.opType != "" && op.Type != c.opType {
+		return false
+	}
+	if c.status != "" && (op.Status == nil || *op.Status != c.status) {
+		return false
 	}
+	if c.success != nil && (op.Status == nil || (*op.Status == meshcommon.OperationStatusSucceeded) != *c.success) {
+		return false
+	}
+	return true
+}
 
-	// Create block transaction
-	blockTransaction := &types.BlockTransaction{
-		BlockIdentifier: blockIdentifier,
-		Transaction:     transaction,
+// matchesCurrency checks whether a currency is the searched currency, tokens are compared by contract
+func (c *searchCriteria) matchesCurrency(currency *types.Currency) bool {
+	if currency == nil {
+		return false
+	}
+	contractAddress, ok := currency.Metadata["contractAddress"].(string)
+	if c.token == nil {
+		return !ok && currency.Symbol == c.currency.Symbol
 	}
+	return ok && strings.EqualFold(contractAddress, c.token.String())
+}
 
-	// Create response
-	return &types.SearchTransactionsResponse{
-		Transactions: []*types.BlockTransaction{blockTransaction},
-		TotalCount:   1,
-	}, nil
+// logCursor pages through logs returned in descending order
+type logCursor struct {
+	fetch  func(options *api.Options) ([]api.LogMeta, error)
+	page   []api.LogMeta
+	offset uint64
+	done   bool
+}
+
+// peek returns the next log without consuming it, or nil when there are no more logs
+func (c *logCursor) peek() (*api.LogMeta, error) {
+	if len(c.page) == 0 && !c.done {
+		limit := uint64(searchLogsPageSize)
+		metas, err := c.fetch(&api.Options{Offset: c.offset, Limit: &limit, IncludeIndexes: true})
+		if err != nil {
+			return nil, err
+		}
+		c.page = metas
+		c.offset += uint64(len(metas))
+		c.done = len(metas) < searchLogsPageSize
+	}
+	if len(c.page) == 0 {
+		return nil, nil
+	}
+	return &c.page[0], nil
+}
+
+// isNewerLog checks whether log a comes after log b in the chain
+func isNewerLog(a *api.LogMeta, b api.LogMeta) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber > b.BlockNumber
+	}
+	return logTxIndex(a) > logTxIndex(&b)
+}
+
+// logTxIndex returns the index of the transaction of a log in its block
+func logTxIndex(meta *api.LogMeta) uint32 {
+	if meta.TxIndex == nil {
+		return 0
+	}
+	return *meta.TxIndex
 }
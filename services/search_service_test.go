@@ -3,12 +3,17 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/stretchr/testify/assert"
 	meshcommon "github.com/vechain/mesh/common"
+	"github.com/vechain/mesh/common/vip180"
+	meshconfig "github.com/vechain/mesh/config"
+	meshtests "github.com/vechain/mesh/tests"
 	meshthor "github.com/vechain/mesh/thor"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/transactions"
@@ -61,7 +66,7 @@ func TestSearchService_SearchTransactions_Success(t *testing.T) {
 	mockClient.SetReceipt(mockReceipt)
 
 	// Create search service
-	searchService := NewSearchService(mockClient)
+	searchService := NewSearchService(mockClient, &meshconfig.Config{})
 
 	// Create request
 	request := &types.SearchTransactionsRequest{
@@ -100,7 +105,7 @@ func TestSearchService_SearchTransactions_MissingTransactionIdentifier(t *testin
 	mockClient := meshthor.NewMockVeChainClient()
 
 	// Create search service
-	searchService := NewSearchService(mockClient)
+	searchService := NewSearchService(mockClient, &meshconfig.Config{})
 
 	// Create request without transaction identifier
 	request := &types.SearchTransactionsRequest{
@@ -120,7 +125,7 @@ func TestSearchService_SearchTransactions_EmptyTransactionHash(t *testing.T) {
 	mockClient := meshthor.NewMockVeChainClient()
 
 	// Create search service
-	searchService := NewSearchService(mockClient)
+	searchService := NewSearchService(mockClient, &meshconfig.Config{})
 
 	// Create request with empty transaction hash
 	request := &types.SearchTransactionsRequest{
@@ -143,7 +148,7 @@ func TestSearchService_SearchTransactions_TransactionNotFound(t *testing.T) {
 	mockClient.SetMockError(errors.New("transaction not found"))
 
 	// Create search service
-	searchService := NewSearchService(mockClient)
+	searchService := NewSearchService(mockClient, &meshconfig.Config{})
 
 	// Create request
 	request := &types.SearchTransactionsRequest{
@@ -164,3 +169,434 @@ func TestSearchService_SearchTransactions_TransactionNotFound(t *testing.T) {
 
 	assert.Equal(t, meshcommon.GetError(meshcommon.ErrTransactionNotFound), err)
 }
+
+func TestSearchService_SearchTransactions_FeeFromReceipt(t *testing.T) {
+	mockClient := meshthor.NewMockVeChainClient()
+
+	txHash, _ := thor.ParseBytes32("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	origin, _ := thor.ParseAddress(meshtests.FirstSoloAddress)
+	delegator, _ := thor.ParseAddress(meshtests.TestAddress1)
+	mockClient.SetTransaction(&transactions.Transaction{
+		ID:        txHash,
+		Origin:    origin,
+		Delegator: &delegator,
+		Gas:       50000,
+		Clauses: api.Clauses{
+			{
+				To:    &thor.Address{},
+				Value: &math.HexOrDecimal256{},
+				Data:  "",
+			},
+		},
+	})
+
+	paid := math.HexOrDecimal256(*big.NewInt(210000000000000000))
+	reward := math.HexOrDecimal256(*big.NewInt(63000000000000000))
+	mockClient.SetReceipt(&api.Receipt{
+		Meta: api.ReceiptMeta{
+			BlockNumber: 100,
+			BlockID:     txHash,
+			TxID:        txHash,
+			TxOrigin:    origin,
+		},
+		GasUsed:  21000,
+		GasPayer: delegator,
+		Paid:     &paid,
+		Reward:   &reward,
+	})
+
+	searchService := NewSearchService(mockClient, &meshconfig.Config{})
+	response, err := searchService.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: txHash.String()},
+	})
+	if err != nil {
+		t.Fatalf("SearchTransactions() error = %v", err)
+	}
+
+	operations := response.Transactions[0].Transaction.Operations
+	if len(operations) != 1 {
+		t.Fatalf("Expected 1 operation, got %d", len(operations))
+	}
+	assert.Equal(t, meshcommon.OperationTypeFeeDelegation, operations[0].Type)
+	assert.Equal(t, delegator.String(), operations[0].Account.Address)
+	assert.Equal(t, "-210000000000000000", operations[0].Amount.Value)
+	assert.Equal(t, meshcommon.VTHOCurrency, operations[0].Amount.Currency)
+}
+
+// newSearchFixture configures three transactions of account A: a VET transfer to B in block 10,
+// a VTHO transfer to C in block 20 and a VET transfer from C in block 30, paid by C
+func newSearchFixture(t *testing.T) (*meshthor.MockVeChainClient, thor.Address, []thor.Bytes32) {
+	accountA, _ := thor.ParseAddress(meshtests.FirstSoloAddress)
+	accountB, _ := thor.ParseAddress(meshtests.TestAddress1)
+	accountC, _ := thor.ParseAddress("0x0000000000000000000000000000000000000c0c")
+	vtho, _ := thor.ParseAddress(meshcommon.VTHOContractAddress)
+	encoder := vip180.NewVIP180Encoder()
+	transferEventID := encoder.TransferEventID()
+
+	mockClient := meshthor.NewMockVeChainClient()
+	amount := math.HexOrDecimal256(*big.NewInt(1000))
+	paid := math.HexOrDecimal256(*big.NewInt(21000))
+	txIDs := []thor.Bytes32{{0x01}, {0x02}, {0x03}}
+	var transfers []*api.FilteredTransfer
+	var events []api.FilteredEvent
+
+	addTransaction := func(txID thor.Bytes32, block uint32, origin, gasPayer thor.Address, clause *api.Clause, output *api.Output) {
+		blockID := thor.Bytes32{0xb0, byte(block)}
+		mockClient.AddTransaction(&transactions.Transaction{
+			ID:      txID,
+			Origin:  origin,
+			Clauses: api.Clauses{clause},
+			Meta:    &api.TxMeta{BlockID: blockID, BlockNumber: block},
+		}, &api.Receipt{
+			GasUsed:  21000,
+			GasPayer: gasPayer,
+			Paid:     &paid,
+			Reward:   &paid,
+			Outputs:  []*api.Output{output},
+			Meta:     api.ReceiptMeta{BlockID: blockID, BlockNumber: block, TxID: txID, TxOrigin: origin},
+		})
+	}
+
+	// VET transfer from A to B
+	addTransaction(txIDs[0], 10, accountA, accountA,
+		&api.Clause{To: &accountB, Value: &amount, Data: "0x"},
+		&api.Output{Transfers: []*api.Transfer{{Sender: accountA, Recipient: accountB, Amount: &amount}}})
+	transfers = append(transfers, &api.FilteredTransfer{Sender: accountA, Recipient: accountB, Amount: &amount,
+		Meta: api.LogMeta{BlockNumber: 10, TxID: txIDs[0], TxOrigin: accountA}})
+
+	// VTHO transfer from A to C
+	callData, err := encoder.EncodeVIP180TransferCallData(accountC.String(), "1000")
+	if err != nil {
+		t.Fatalf("EncodeVIP180TransferCallData() error = %v", err)
+	}
+	zero := math.HexOrDecimal256(*big.NewInt(0))
+	fromTopic := thor.BytesToBytes32(accountA.Bytes())
+	toTopic := thor.BytesToBytes32(accountC.Bytes())
+	eventData := "0x" + fmt.Sprintf("%064x", 1000)
+	addTransaction(txIDs[1], 20, accountA, accountA,
+		&api.Clause{To: &vtho, Value: &zero, Data: callData},
+		&api.Output{Events: []*api.Event{{Address: vtho, Topics: []thor.Bytes32{transferEventID, fromTopic, toTopic}, Data: eventData}}})
+	events = append(events, api.FilteredEvent{Address: vtho, Topics: []*thor.Bytes32{&transferEventID, &fromTopic, &toTopic}, Data: eventData,
+		Meta: api.LogMeta{BlockNumber: 20, TxID: txIDs[1], TxOrigin: accountA}})
+
+	// VET transfer from C to A
+	addTransaction(txIDs[2], 30, accountC, accountC,
+		&api.Clause{To: &accountA, Value: &amount, Data: "0x"},
+		&api.Output{Transfers: []*api.Transfer{{Sender: accountC, Recipient: accountA, Amount: &amount}}})
+	transfers = append(transfers, &api.FilteredTransfer{Sender: accountC, Recipient: accountA, Amount: &amount,
+		Meta: api.LogMeta{BlockNumber: 30, TxID: txIDs[2], TxOrigin: accountC}})
+
+	mockClient.SetTransfers(transfers)
+	mockClient.SetEvents(events)
+	return mockClient, accountA, txIDs
+}
+
+func TestSearchService_SearchTransactions_Filters(t *testing.T) {
+	mockClient, account, txIDs := newSearchFixture(t)
+	searchService := NewSearchService(mockClient, &meshconfig.Config{})
+	accountIdentifier := &types.AccountIdentifier{Address: account.String()}
+	feeType := meshcommon.OperationTypeFee
+	transferType := meshcommon.OperationTypeTransfer
+	succeeded := meshcommon.OperationStatusSucceeded
+	failed := false
+	maxBlock := int64(20)
+
+	tests := []struct {
+		name     string
+		request  *types.SearchTransactionsRequest
+		expected []thor.Bytes32
+	}{
+		{
+			name:     "account",
+			request:  &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier},
+			expected: []thor.Bytes32{txIDs[2], txIDs[1], txIDs[0]},
+		},
+		{
+			name:     "address",
+			request:  &types.SearchTransactionsRequest{Address: &accountIdentifier.Address},
+			expected: []thor.Bytes32{txIDs[2], txIDs[1], txIDs[0]},
+		},
+		{
+			name:     "account and VET",
+			request:  &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Currency: meshcommon.VETCurrency},
+			expected: []thor.Bytes32{txIDs[2], txIDs[0]},
+		},
+		{
+			name:     "account and VTHO",
+			request:  &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Currency: meshcommon.VTHOCurrency, Type: &transferType},
+			expected: []thor.Bytes32{txIDs[1]},
+		},
+		{
+			name:     "currency only",
+			request:  &types.SearchTransactionsRequest{Currency: meshcommon.VETCurrency},
+			expected: []thor.Bytes32{txIDs[2], txIDs[0]},
+		},
+		{
+			name:     "account and type",
+			request:  &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Type: &transferType},
+			expected: []thor.Bytes32{txIDs[2], txIDs[1], txIDs[0]},
+		},
+		{
+			name:     "account and status",
+			request:  &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Status: &succeeded},
+			expected: []thor.Bytes32{txIDs[2], txIDs[1], txIDs[0]},
+		},
+
+		{
+			name:     "account and max block",
+			request:  &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, MaxBlock: &maxBlock},
+			expected: []thor.Bytes32{txIDs[1], txIDs[0]},
+		},
+		{
+			name:     "hash and account",
+			request:  &types.SearchTransactionsRequest{TransactionIdentifier: &types.TransactionIdentifier{Hash: txIDs[0].String()}, AccountIdentifier: accountIdentifier},
+			expected: []thor.Bytes32{txIDs[0]},
+		},
+		{
+			name:     "hash and fee",
+			request:  &types.SearchTransactionsRequest{TransactionIdentifier: &types.TransactionIdentifier{Hash: txIDs[1].String()}, Type: &feeType},
+			expected: []thor.Bytes32{txIDs[1]},
+		},
+		{
+			name:     "hash and unsuccessful",
+			request:  &types.SearchTransactionsRequest{TransactionIdentifier: &types.TransactionIdentifier{Hash: txIDs[1].String()}, Success: &failed},
+			expected: []thor.Bytes32{},
+		},
+		{
+			name:     "hash and max block",
+			request:  &types.SearchTransactionsRequest{TransactionIdentifier: &types.TransactionIdentifier{Hash: txIDs[2].String()}, MaxBlock: &maxBlock},
+			expected: []thor.Bytes32{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := searchService.SearchTransactions(context.Background(), tt.request)
+			if err != nil {
+				t.Fatalf("SearchTransactions() error = %v", err)
+			}
+			if response.TotalCount != int64(len(tt.expected)) {
+				t.Errorf("Expected TotalCount %d, got %d", len(tt.expected), response.TotalCount)
+			}
+			if len(response.Transactions) != len(tt.expected) {
+				t.Fatalf("Expected %d transactions, got %d", len(tt.expected), len(response.Transactions))
+			}
+			for i, txID := range tt.expected {
+				assert.Equal(t, txID.String(), response.Transactions[i].Transaction.TransactionIdentifier.Hash)
+			}
+			assert.Nil(t, response.NextOffset)
+		})
+	}
+}
+
+func TestSearchService_SearchTransactions_Pagination(t *testing.T) {
+	mockClient, account, txIDs := newSearchFixture(t)
+	searchService := NewSearchService(mockClient, &meshconfig.Config{})
+	accountIdentifier := &types.AccountIdentifier{Address: account.String()}
+
+	limit := int64(2)
+	response, err := searchService.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+		AccountIdentifier: accountIdentifier,
+		Limit:             &limit,
+	})
+	if err != nil {
+		t.Fatalf("SearchTransactions() error = %v", err)
+	}
+	assert.Equal(t, int64(2), response.TotalCount)
+	assert.Len(t, response.Transactions, 2)
+	if !assert.NotNil(t, response.NextOffset) {
+		return
+	}
+
+	// A transaction of a new block does not shift the next page
+	amount := math.HexOrDecimal256(*big.NewInt(1))
+	newTxID := thor.Bytes32{0x04}
+	mockClient.SetTransfers(append(mockClient.MockTransfers, &api.FilteredTransfer{Sender: account, Recipient: account, Amount: &amount,
+		Meta: api.LogMeta{BlockNumber: 40, TxID: newTxID, TxOrigin: account}}))
+
+	response, err = searchService.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+		AccountIdentifier: accountIdentifier,
+		Offset:            response.NextOffset,
+		Limit:             &limit,
+	})
+	if err != nil {
+		t.Fatalf("SearchTransactions() error = %v", err)
+	}
+	assert.Equal(t, int64(1), response.TotalCount)
+	if assert.Len(t, response.Transactions, 1) {
+		assert.Equal(t, txIDs[0].String(), response.Transactions[0].Transaction.TransactionIdentifier.Hash)
+	}
+	assert.Nil(t, response.NextOffset)
+}
+
+// countingClient counts the transactions fetched by a search
+type countingClient struct {
+	*meshthor.MockVeChainClient
+	fetched int
+}
+
+func (c *countingClient) GetTransaction(txID string) (*transactions.Transaction, error) {
+	c.fetched++
+	return c.MockVeChainClient.GetTransaction(txID)
+}
+
+// searchAllPages follows next_offset until the last page, returning the transactions of every page and the number of pages
+func searchAllPages(t *testing.T, searchService *SearchService, request *types.SearchTransactionsRequest) ([]string, int) {
+	var hashes []string
+	pages := 0
+	for {
+		response, err := searchService.SearchTransactions(context.Background(), request)
+		if err != nil {
+			t.Fatalf("SearchTransactions() error = %v", err)
+		}
+		pages++
+		for _, transaction := range response.Transactions {
+			hashes = append(hashes, transaction.Transaction.TransactionIdentifier.Hash)
+		}
+		if response.NextOffset == nil {
+			return hashes, pages
+		}
+		request.Offset = response.NextOffset
+	}
+}
+
+func TestSearchService_SearchTransactions_CandidateBudget(t *testing.T) {
+	sender, _ := thor.ParseAddress(meshtests.FirstSoloAddress)
+	recipient, _ := thor.ParseAddress(meshtests.TestAddress1)
+	amount := math.HexOrDecimal256(*big.NewInt(1000))
+	paid := math.HexOrDecimal256(*big.NewInt(21000))
+
+	// Two transactions per block, each emitting two transfers
+	mockClient := meshthor.NewMockVeChainClient()
+	var transfers []*api.FilteredTransfer
+	for block := uint32(1); block <= 10; block++ {
+		for txIndex := uint32(0); txIndex < 2; txIndex++ {
+			txID := thor.Bytes32{0x01, byte(block), byte(txIndex)}
+			blockID := thor.Bytes32{0xb0, byte(block)}
+			mockClient.AddTransaction(&transactions.Transaction{
+				ID:      txID,
+				Origin:  sender,
+				Clauses: api.Clauses{{To: &recipient, Value: &amount, Data: "0x"}},
+				Meta:    &api.TxMeta{BlockID: blockID, BlockNumber: block},
+			}, &api.Receipt{
+				GasUsed:  21000,
+				GasPayer: sender,
+				Paid:     &paid,
+				Reward:   &paid,
+				Outputs:  []*api.Output{{Transfers: []*api.Transfer{{Sender: sender, Recipient: recipient, Amount: &amount}}}},
+				Meta:     api.ReceiptMeta{BlockID: blockID, BlockNumber: block, TxID: txID, TxOrigin: sender},
+			})
+			for range 2 {
+				index := txIndex
+				transfers = append(transfers, &api.FilteredTransfer{Sender: sender, Recipient: recipient, Amount: &amount,
+					Meta: api.LogMeta{BlockNumber: block, TxID: txID, TxOrigin: sender, TxIndex: &index}})
+			}
+		}
+	}
+	mockClient.SetTransfers(transfers)
+
+	client := &countingClient{MockVeChainClient: mockClient}
+	searchService := NewSearchService(client, &meshconfig.Config{})
+	accountIdentifier := &types.AccountIdentifier{Address: recipient.String()}
+	limit := int64(3)
+
+	// Every page resumes after the previous one, each transaction is examined once
+	hashes, pages := searchAllPages(t, searchService, &types.SearchTransactionsRequest{
+		AccountIdentifier: accountIdentifier,
+		Limit:             &limit,
+	})
+	assert.Equal(t, 20, client.fetched)
+	assert.Equal(t, 7, pages)
+	if assert.Len(t, hashes, 20) {
+		assert.Equal(t, thor.Bytes32{0x01, 10, 1}.String(), hashes[0])
+		assert.Equal(t, thor.Bytes32{0x01, 1, 0}.String(), hashes[19])
+	}
+
+	// Pages without matches examine searchCandidatesPerResult transactions per result, and still lead to the next page
+	client.fetched = 0
+	contractCall := meshcommon.OperationTypeContractCall
+	request := &types.SearchTransactionsRequest{
+		AccountIdentifier: accountIdentifier,
+		Type:              &contractCall,
+		Limit:             &limit,
+	}
+	response, err := searchService.SearchTransactions(context.Background(), request)
+	if err != nil {
+		t.Fatalf("SearchTransactions() error = %v", err)
+	}
+	assert.Equal(t, int(limit*searchCandidatesPerResult), client.fetched)
+	assert.Empty(t, response.Transactions)
+	assert.NotNil(t, response.NextOffset)
+
+	client.fetched = 0
+	hashes, _ = searchAllPages(t, searchService, request)
+	assert.Empty(t, hashes)
+	assert.Equal(t, 20, client.fetched)
+}
+
+func TestSearchCursor_Encode(t *testing.T) {
+	cursor := searchCursor{blockNumber: 23456789, txIndex: 301}
+	offset := cursor.encode()
+	assert.Positive(t, offset)
+	assert.Equal(t, cursor, *decodeSearchCursor(offset))
+}
+
+func TestSearchService_SearchTransactions_InvalidRequests(t *testing.T) {
+	mockClient, account, _ := newSearchFixture(t)
+	searchService := NewSearchService(mockClient, &meshconfig.Config{})
+	accountIdentifier := &types.AccountIdentifier{Address: account.String()}
+	or := types.OR
+	otherAddress := meshtests.TestAddress1
+	invalidAddress := "0x1234"
+	negative := int64(-1)
+	tooHigh := int64(searchMaxLimit + 1)
+	reverted := meshcommon.OperationStatusReverted
+	failed := false
+	feeType := meshcommon.OperationTypeFee
+	feeDelegationType := meshcommon.OperationTypeFeeDelegation
+
+	tests := []struct {
+		name    string
+		request *types.SearchTransactionsRequest
+		code    int32
+	}{
+		{"no criteria", &types.SearchTransactionsRequest{}, meshcommon.ErrInvalidRequestParameters},
+		{"or operator", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Operator: &or}, meshcommon.ErrInvalidRequestParameters},
+		{"coin identifier", &types.SearchTransactionsRequest{CoinIdentifier: &types.CoinIdentifier{Identifier: "coin"}}, meshcommon.ErrInvalidRequestParameters},
+		{"mismatched address", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Address: &otherAddress}, meshcommon.ErrInvalidRequestParameters},
+		{"invalid address", &types.SearchTransactionsRequest{Address: &invalidAddress}, meshcommon.ErrInvalidRequestParameters},
+		{"negative max block", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, MaxBlock: &negative}, meshcommon.ErrInvalidRequestParameters},
+		{"negative offset", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Offset: &negative}, meshcommon.ErrInvalidRequestParameters},
+		{"limit too high", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Limit: &tooHigh}, meshcommon.ErrInvalidRequestParameters},
+		{"reverted without hash", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Status: &reverted}, meshcommon.ErrInvalidRequestParameters},
+		{"unsuccessful without hash", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Success: &failed}, meshcommon.ErrInvalidRequestParameters},
+		{"fee without hash", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Type: &feeType}, meshcommon.ErrInvalidRequestParameters},
+		{"fee delegation without hash", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Type: &feeDelegationType}, meshcommon.ErrInvalidRequestParameters},
+		{"VTHO without type", &types.SearchTransactionsRequest{AccountIdentifier: accountIdentifier, Currency: meshcommon.VTHOCurrency}, meshcommon.ErrInvalidRequestParameters},
+		{"unsupported currency", &types.SearchTransactionsRequest{Currency: &types.Currency{Symbol: "ABC", Decimals: 18}}, meshcommon.ErrUnsupportedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := searchService.SearchTransactions(context.Background(), tt.request)
+			if err == nil {
+				t.Fatal("SearchTransactions() expected error")
+			}
+			assert.Equal(t, tt.code, err.Code)
+		})
+	}
+}
+
+func TestSearchService_SearchTransactions_LogsError(t *testing.T) {
+	mockClient, account, _ := newSearchFixture(t)
+	searchService := NewSearchService(mockClient, &meshconfig.Config{})
+	mockClient.SetMockError(errors.New("logs unavailable"))
+
+	_, err := searchService.SearchTransactions(context.Background(), &types.SearchTransactionsRequest{
+		AccountIdentifier: &types.AccountIdentifier{Address: account.String()},
+	})
+	if err == nil {
+		t.Fatal("SearchTransactions() expected error")
+	}
+	assert.Equal(t, int32(meshcommon.ErrFailedToSearchTransactions), err.Code)
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	meshcrypto "github.com/vechain/mesh/common/crypto"
+	meshtx "github.com/vechain/mesh/common/tx"
+	meshconfig "github.com/vechain/mesh/config"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// Sponsor signs as fee delegator the transactions allowed by the configured policy
+type Sponsor struct {
+	signer           *meshcrypto.SigningHandler
+	address          string
+	allowedOrigins   map[thor.Address]bool
+	allowedContracts map[thor.Address]bool
+	maxGas           uint64
+	dailyBudget      *big.Int
+	baseGasPrice     *big.Int
+	now              func() time.Time
+
+	mu    sync.Mutex
+	day   string
+	spent map[thor.Address]*big.Int
+	// charged holds the transactions charged today, signing one of them again is free
+	charged map[thor.Bytes32]bool
+}
+
+// NewSponsor creates a sponsor from its configuration, the base gas price is used to price legacy transactions
+func NewSponsor(config *meshconfig.SponsorConfig, baseGasPrice *big.Int) (*Sponsor, error) {
+	signer := meshcrypto.NewSigningHandler(config.PrivateKey)
+	address, err := signer.GetAddressFromPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	sponsor := &Sponsor{
+		signer:           signer,
+		address:          address,
+		allowedOrigins:   make(map[thor.Address]bool, len(config.AllowedOrigins)),
+		allowedContracts: make(map[thor.Address]bool, len(config.AllowedContracts)),
+		maxGas:           config.MaxGas,
+		dailyBudget:      config.GetDailyBudget(),
+		baseGasPrice:     baseGasPrice,
+		now:              time.Now,
+		spent:            make(map[thor.Address]*big.Int),
+		charged:          make(map[thor.Bytes32]bool),
+	}
+	for _, origin := range config.AllowedOrigins {
+		address, err := thor.ParseAddress(origin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed origin: %w", err)
+		}
+		sponsor.allowedOrigins[address] = true
+	}
+	for _, contract := range config.AllowedContracts {
+		address, err := thor.ParseAddress(contract)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed contract: %w", err)
+		}
+		sponsor.allowedContracts[address] = true
+	}
+	return sponsor, nil
+}
+
+// Address returns the address of the gas payer
+func (s *Sponsor) Address() string {
+	return s.address
+}
+
+// Sign checks the transaction against the policy and returns the gas payer signature.
+// As in VIP-191, the origin signs the transaction first: the origin is recovered from its signature,
+// so that only the owner of an allowed origin can spend its budget.
+// The maximum fee of the transaction is charged to the daily budget of its origin once per transaction ID:
+// the transaction can only be included once, so signing it again costs nothing.
+func (s *Sponsor) Sign(meshTx *meshtx.MeshTransaction, originSignature []byte) ([]byte, error) {
+	if !meshTx.Features().IsDelegated() {
+		return nil, fmt.Errorf("transaction is not delegated")
+	}
+	if len(meshTx.Delegator) > 0 && !strings.EqualFold(thor.BytesToAddress(meshTx.Delegator).String(), s.address) {
+		return nil, fmt.Errorf("transaction is delegated to %s", thor.BytesToAddress(meshTx.Delegator))
+	}
+
+	origin, err := recoverOrigin(meshTx, originSignature)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.allowedOrigins) > 0 && !s.allowedOrigins[origin] {
+		return nil, fmt.Errorf("origin %s is not allowed", origin)
+	}
+	if len(s.allowedContracts) > 0 {
+		for i, clause := range meshTx.Clauses() {
+			if clause.To() == nil || !s.allowedContracts[*clause.To()] {
+				return nil, fmt.Errorf("clause %d does not target an allowed contract", i)
+			}
+		}
+	}
+	if s.maxGas > 0 && meshTx.Gas() > s.maxGas {
+		return nil, fmt.Errorf("gas %d exceeds the limit of %d", meshTx.Gas(), s.maxGas)
+	}
+
+	// The signature is only released once the fee fits in the budget
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(meshTx.Gas()), meshTx.MaxGasPrice(s.baseGasPrice))
+	txID := thor.Blake2b(meshTx.SigningHash().Bytes(), origin.Bytes())
+	charged, err := s.charge(origin, txID, fee)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := meshTx.DelegatorSigningHash(origin)
+	signatureHex, err := s.signer.SignPayload(hex.EncodeToString(hash[:]))
+	if err != nil {
+		if charged {
+			s.refund(origin, txID, fee)
+		}
+		return nil, err
+	}
+	return hex.DecodeString(signatureHex)
+}
+
+// recoverOrigin returns the signer of the origin signature of a transaction,
+// which must match the origin declared with the unsigned transaction
+func recoverOrigin(meshTx *meshtx.MeshTransaction, originSignature []byte) (thor.Address, error) {
+	if len(originSignature) != 65 {
+		return thor.Address{}, fmt.Errorf("origin signature must be 65 bytes, got %d", len(originSignature))
+	}
+	hash := meshTx.SigningHash()
+	publicKey, err := ethcrypto.SigToPub(hash[:], originSignature)
+	if err != nil {
+		return thor.Address{}, fmt.Errorf("invalid origin signature: %w", err)
+	}
+
+	origin := thor.Address(ethcrypto.PubkeyToAddress(*publicKey))
+	if declared := thor.BytesToAddress(meshTx.Origin); len(meshTx.Origin) > 0 && declared != origin {
+		return thor.Address{}, fmt.Errorf("origin signature is from %s, not %s", origin, declared)
+	}
+	return origin, nil
+}
+
+// charge adds the fee of a transaction to what the origin spent today, failing when it exceeds the daily budget.
+// It reports whether the fee was charged, a transaction already charged today is not charged again.
+func (s *Sponsor) charge(origin thor.Address, txID thor.Bytes32, fee *big.Int) (bool, error) {
+	if s.dailyBudget == nil {
+		return false, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if day := s.now().UTC().Format(time.DateOnly); day != s.day {
+		s.day = day
+		s.spent = make(map[thor.Address]*big.Int)
+		s.charged = make(map[thor.Bytes32]bool)
+	}
+	if s.charged[txID] {
+		return false, nil
+	}
+	spent, ok := s.spent[origin]
+	if !ok {
+		spent = new(big.Int)
+	}
+	total := new(big.Int).Add(spent, fee)
+	if total.Cmp(s.dailyBudget) > 0 {
+		return false, fmt.Errorf("fee %s exceeds the remaining daily budget %s", fee, new(big.Int).Sub(s.dailyBudget, spent))
+	}
+	s.spent[origin] = total
+	s.charged[txID] = true
+	return true, nil
+}
+
+// refund removes a fee charged for a transaction that was not signed
+func (s *Sponsor) refund(origin thor.Address, txID thor.Bytes32, fee *big.Int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.charged[txID] {
+		return
+	}
+	delete(s.charged, txID)
+	if spent, ok := s.spent[origin]; ok {
+		spent.Sub(spent, fee)
+	}
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	meshtx "github.com/vechain/mesh/common/tx"
+	meshconfig "github.com/vechain/mesh/config"
+	meshtests "github.com/vechain/mesh/tests"
+	"github.com/vechain/thor/v2/thor"
+	thortx "github.com/vechain/thor/v2/tx"
+)
+
+// createSponsoredTransaction builds a delegated transaction from TestAddress1 calling the given contract
+func createSponsoredTransaction(contract string, gas, nonce uint64) *meshtx.MeshTransaction {
+	to, _ := thor.ParseAddress(contract)
+	origin, _ := thor.ParseAddress(meshtests.TestAddress1)
+	trx := thortx.NewBuilder(thortx.TypeDynamicFee).
+		ChainTag(0x27).
+		Expiration(720).
+		Gas(gas).
+		Nonce(nonce).
+		MaxFeePerGas(big.NewInt(10)).
+		Features(thortx.DelegationFeature).
+		Clause(thortx.NewClause(&to).WithData([]byte{0x01})).
+		Build()
+	return &meshtx.MeshTransaction{Transaction: trx, Origin: origin.Bytes()}
+}
+
+// originSignature signs a transaction as its origin with the given private key
+func originSignature(meshTx *meshtx.MeshTransaction, privateKeyHex string) []byte {
+	privateKey, _ := ethcrypto.HexToECDSA(privateKeyHex)
+	hash := meshTx.SigningHash()
+	signature, _ := ethcrypto.Sign(hash[:], privateKey)
+	return signature
+}
+
+// signSponsored requests the gas payer signature of a transaction signed by its origin, TestAddress1
+func signSponsored(sponsor *Sponsor, meshTx *meshtx.MeshTransaction) ([]byte, error) {
+	return sponsor.Sign(meshTx, originSignature(meshTx, meshtests.TestAddress1PrivateKey))
+}
+
+func createTestSponsor(t *testing.T, config *meshconfig.SponsorConfig) *Sponsor {
+	t.Helper()
+	config.PrivateKey = meshtests.TestDelegatorPrivateKey
+	sponsor, err := NewSponsor(config, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("NewSponsor() error = %v", err)
+	}
+	return sponsor
+}
+
+func TestSponsor_Sign(t *testing.T) {
+	sponsor := createTestSponsor(t, &meshconfig.SponsorConfig{})
+	if sponsor.Address() != meshtests.TestDelegatorAddress {
+		t.Errorf("Address() = %s, want %s", sponsor.Address(), meshtests.TestDelegatorAddress)
+	}
+
+	meshTx := createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0)
+	signature, err := signSponsored(sponsor, meshTx)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	// The signature is the one of the gas payer over the delegator signing hash
+	hash := meshTx.DelegatorSigningHash(thor.BytesToAddress(meshTx.Origin))
+	publicKey, err := ethcrypto.SigToPub(hash[:], signature)
+	if err != nil {
+		t.Fatalf("SigToPub() error = %v", err)
+	}
+	if address := thor.Address(ethcrypto.PubkeyToAddress(*publicKey)); address.String() != meshtests.TestDelegatorAddress {
+		t.Errorf("Sign() signed by %s, want %s", address, meshtests.TestDelegatorAddress)
+	}
+}
+
+func TestSponsor_Policy(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *meshconfig.SponsorConfig
+		meshTx *meshtx.MeshTransaction
+	}{
+		{
+			name:   "origin not allowed",
+			config: &meshconfig.SponsorConfig{AllowedOrigins: []string{meshtests.FirstSoloAddress}},
+			meshTx: createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0),
+		},
+		{
+			name:   "contract not allowed",
+			config: &meshconfig.SponsorConfig{AllowedContracts: []string{meshtests.TestAddress1}},
+			meshTx: createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0),
+		},
+		{
+			name:   "gas above limit",
+			config: &meshconfig.SponsorConfig{MaxGas: 40000},
+			meshTx: createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0),
+		},
+		{
+			name:   "fee above budget",
+			config: &meshconfig.SponsorConfig{DailyBudget: "499999"},
+			meshTx: createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0),
+		},
+		{
+			name:   "not delegated",
+			config: &meshconfig.SponsorConfig{},
+			meshTx: func() *meshtx.MeshTransaction {
+				meshTx := createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0)
+				meshTx.Transaction = thortx.NewBuilder(thortx.TypeLegacy).Gas(50000).Build()
+				return meshTx
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signSponsored(createTestSponsor(t, tt.config), tt.meshTx); err == nil {
+				t.Error("Sign() expected the transaction to be rejected")
+			}
+		})
+	}
+
+	// The same transaction within the limits is signed
+	sponsor := createTestSponsor(t, &meshconfig.SponsorConfig{
+		AllowedOrigins:   []string{meshtests.TestAddress1},
+		AllowedContracts: []string{meshtests.FirstSoloAddress},
+		MaxGas:           50000,
+		DailyBudget:      "500000",
+	})
+	if _, err := signSponsored(sponsor, createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0)); err != nil {
+		t.Errorf("Sign() error = %v", err)
+	}
+}
+
+func TestSponsor_DailyBudget(t *testing.T) {
+	// Each transaction costs 50000 gas at 10 wei
+	sponsor := createTestSponsor(t, &meshconfig.SponsorConfig{DailyBudget: "1000000"})
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	sponsor.now = func() time.Time { return now }
+
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		if _, err := signSponsored(sponsor, createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, nonce)); err != nil {
+			t.Fatalf("Sign() %d error = %v", nonce, err)
+		}
+	}
+	if _, err := signSponsored(sponsor, createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 2)); err == nil {
+		t.Fatal("Sign() expected the daily budget to be exhausted")
+	}
+
+	// The budget is renewed the next UTC day
+	now = now.Add(12 * time.Hour)
+	if _, err := signSponsored(sponsor, createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 2)); err != nil {
+		t.Errorf("Sign() error = %v after the budget renewal", err)
+	}
+}
+
+func TestSponsor_SignSameTransactionTwice(t *testing.T) {
+	// The budget covers a single transaction of 50000 gas at 10 wei
+	sponsor := createTestSponsor(t, &meshconfig.SponsorConfig{DailyBudget: "500000"})
+
+	for i := 0; i < 3; i++ {
+		if _, err := signSponsored(sponsor, createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0)); err != nil {
+			t.Fatalf("Sign() %d error = %v, want the same transaction charged once", i, err)
+		}
+	}
+	origin, _ := thor.ParseAddress(meshtests.TestAddress1)
+	if spent := sponsor.spent[origin]; spent == nil || spent.Cmp(big.NewInt(500000)) != 0 {
+		t.Errorf("Sign() spent = %v, want 500000", spent)
+	}
+
+	// Another transaction of the origin is still charged
+	if _, err := signSponsored(sponsor, createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 1)); err == nil {
+		t.Error("Sign() expected the daily budget to be exhausted by another transaction")
+	}
+}
+
+func TestSponsor_ForgedOrigin(t *testing.T) {
+	// The budget covers a single transaction of 50000 gas at 10 wei
+	sponsor := createTestSponsor(t, &meshconfig.SponsorConfig{
+		AllowedOrigins: []string{meshtests.TestAddress1},
+		DailyBudget:    "500000",
+	})
+
+	// Declaring an allowed origin is not enough, the transaction must be signed by it
+	meshTx := createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0)
+	if _, err := sponsor.Sign(meshTx, originSignature(meshTx, meshtests.TestDelegatorPrivateKey)); err == nil {
+		t.Error("Sign() expected a transaction signed by another account to be rejected")
+	}
+	undeclared := createSponsoredTransaction(meshtests.FirstSoloAddress, 50000, 0)
+	undeclared.Origin = nil
+	if _, err := sponsor.Sign(undeclared, originSignature(undeclared, meshtests.TestDelegatorPrivateKey)); err == nil {
+		t.Error("Sign() expected a transaction signed by an origin that is not allowed to be rejected")
+	}
+	if _, err := sponsor.Sign(meshTx, nil); err == nil {
+		t.Error("Sign() expected a transaction without origin signature to be rejected")
+	}
+
+	// Rejected requests are not charged to the budget of the allowed origin
+	if _, err := signSponsored(sponsor, meshTx); err != nil {
+		t.Errorf("Sign() error = %v", err)
+	}
+}
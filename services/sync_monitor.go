@@ -0,0 +1,95 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	meshcommon "github.com/vechain/mesh/common"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// syncTolerance is the number of blocks a node can lag behind its best peer and still be synced,
+// a peer may announce a block that the node is still importing
+const syncTolerance = 1
+
+// SyncState is the sync status of the node at one observation
+type SyncState struct {
+	Stage        string
+	CurrentIndex int64
+	TargetIndex  int64
+}
+
+// Synced reports whether the node is at the tip of the chain
+func (s SyncState) Synced() bool {
+	return s.Stage == meshcommon.SyncStageSynced
+}
+
+// SyncMonitor derives the sync stage of the node from its peers and from the advancement of its head.
+// The head is observed on every status request, a head that did not change for longer than the stall
+// threshold marks the node as stalled, unless the chain only produces blocks on demand.
+// Until the head is seen changing, a node at the best block of its peers is taken to have advanced at the
+// timestamp of its head, so that a node already stuck when the server starts is reported as stalled from the
+// first observation. A node behind its peers, or without peers yet, is taken to have advanced when first
+// observed, as its head is expected to be old while it catches up.
+type SyncMonitor struct {
+	mu             sync.Mutex
+	stallThreshold time.Duration
+	// standalone chains have no peers to connect to
+	standalone bool
+	// onDemand chains can keep the same head for any time while synced
+	onDemand   bool
+	headID     thor.Bytes32
+	advancedAt time.Time
+	now        func() time.Time
+}
+
+// NewSyncMonitor creates a monitor reporting the node as stalled once its head is older than stallThreshold
+func NewSyncMonitor(stallThreshold time.Duration, standalone, onDemand bool) *SyncMonitor {
+	return &SyncMonitor{
+		stallThreshold: stallThreshold,
+		standalone:     standalone,
+		onDemand:       onDemand,
+		now:            time.Now,
+	}
+}
+
+// Observe records the current head of the node and returns its sync state given its peers.
+// headTimestamp is the block timestamp of the head in seconds.
+func (m *SyncMonitor) Observe(headNumber int64, headID thor.Bytes32, headTimestamp uint64, peers []Peer) SyncState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := SyncState{
+		CurrentIndex: headNumber,
+		TargetIndex:  getTargetIndex(headNumber, peers),
+	}
+	behind := state.TargetIndex-headNumber > syncTolerance
+
+	now := m.now()
+	switch {
+	case m.advancedAt.IsZero():
+		m.headID = headID
+		m.advancedAt = now
+		if (len(peers) > 0 || m.standalone) && !behind {
+			// A clock behind the node must not place the advancement in the future
+			if headTime := time.Unix(int64(headTimestamp), 0); headTime.Before(now) {
+				m.advancedAt = headTime
+			}
+		}
+	case headID != m.headID:
+		m.headID = headID
+		m.advancedAt = now
+	}
+
+	switch {
+	case !m.onDemand && now.Sub(m.advancedAt) > m.stallThreshold:
+		state.Stage = meshcommon.SyncStageStalled
+	case len(peers) == 0 && !m.standalone:
+		state.Stage = meshcommon.SyncStageConnecting
+	case behind:
+		state.Stage = meshcommon.SyncStageBlockSync
+	default:
+		state.Stage = meshcommon.SyncStageSynced
+	}
+	return state
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	meshcommon "github.com/vechain/mesh/common"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// peerAt returns a peer whose best block is at the given number
+func peerAt(number uint32) Peer {
+	var id thor.Bytes32
+	id[3] = byte(number)
+	id[2] = byte(number >> 8)
+	return Peer{PeerID: "peer", BestBlockID: id.String()}
+}
+
+func TestSyncMonitor_Stages(t *testing.T) {
+	monitor := NewSyncMonitor(time.Minute, false, false)
+	now := time.Now()
+	monitor.now = func() time.Time { return now }
+	head := thor.BytesToBytes32([]byte{100})
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		headID  thor.Bytes32
+		peers   []Peer
+		want    string
+	}{
+		{"no peers", 0, head, nil, meshcommon.SyncStageConnecting},
+		{"behind peers", 0, head, []Peer{peerAt(101), peerAt(500)}, meshcommon.SyncStageBlockSync},
+		{"one block behind", 0, head, []Peer{peerAt(101)}, meshcommon.SyncStageSynced},
+		{"quiet within threshold", 50 * time.Second, head, []Peer{peerAt(100)}, meshcommon.SyncStageSynced},
+		{"head stuck", 70 * time.Second, head, []Peer{peerAt(100)}, meshcommon.SyncStageStalled},
+		{"head advanced", 80 * time.Second, thor.BytesToBytes32([]byte{101}), []Peer{peerAt(101)}, meshcommon.SyncStageSynced},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitor.now = func() time.Time { return now.Add(tt.elapsed) }
+			state := monitor.Observe(100, tt.headID, uint64(now.Unix()), tt.peers)
+			if state.Stage != tt.want {
+				t.Errorf("Observe() stage = %s, want %s", state.Stage, tt.want)
+			}
+			if state.Synced() != (tt.want == meshcommon.SyncStageSynced) {
+				t.Errorf("Synced() = %v for stage %s", state.Synced(), state.Stage)
+			}
+		})
+	}
+}
+
+func TestSyncMonitor_Solo(t *testing.T) {
+	now := time.Now()
+	head := thor.BytesToBytes32([]byte{1})
+
+	// An idle on demand chain keeps its head without stalling
+	onDemand := NewSyncMonitor(time.Minute, true, true)
+	onDemand.now = func() time.Time { return now }
+	onDemand.Observe(1, head, uint64(now.Unix()), nil)
+	onDemand.now = func() time.Time { return now.Add(time.Hour) }
+	if state := onDemand.Observe(1, head, uint64(now.Unix()), nil); state.Stage != meshcommon.SyncStageSynced {
+		t.Errorf("Observe() stage = %s, want synced without peers on an on demand chain", state.Stage)
+	}
+
+	// A solo chain producing blocks on its own is expected to advance
+	scheduled := NewSyncMonitor(time.Minute, true, false)
+	scheduled.now = func() time.Time { return now }
+	scheduled.Observe(1, head, uint64(now.Unix()), nil)
+	scheduled.now = func() time.Time { return now.Add(time.Hour) }
+	if state := scheduled.Observe(1, head, uint64(now.Unix()), nil); state.Stage != meshcommon.SyncStageStalled {
+		t.Errorf("Observe() stage = %s, want stalled", state.Stage)
+	}
+}
+
+func TestSyncMonitor_FirstObservation(t *testing.T) {
+	now := time.Now()
+	head := thor.BytesToBytes32([]byte{100})
+	peers := []Peer{peerAt(100)}
+
+	// A head already older than the threshold is stalled from the first observation
+	stuck := NewSyncMonitor(time.Minute, false, false)
+	stuck.now = func() time.Time { return now }
+	if state := stuck.Observe(100, head, uint64(now.Add(-2*time.Minute).Unix()), peers); state.Stage != meshcommon.SyncStageStalled {
+		t.Errorf("Observe() stage = %s, want stalled", state.Stage)
+	}
+	// Seeing the head advance resets the stall
+	stuck.now = func() time.Time { return now.Add(time.Second) }
+	if state := stuck.Observe(101, thor.BytesToBytes32([]byte{101}), uint64(now.Unix()), []Peer{peerAt(101)}); state.Stage != meshcommon.SyncStageSynced {
+		t.Errorf("Observe() stage = %s, want synced once the head advanced", state.Stage)
+	}
+
+	// A node catching up has an old head, it is syncing blocks and only stalls if its head stops advancing
+	catchingUp := NewSyncMonitor(time.Minute, false, false)
+	catchingUp.now = func() time.Time { return now }
+	if state := catchingUp.Observe(100, head, uint64(now.Add(-time.Hour).Unix()), []Peer{peerAt(500)}); state.Stage != meshcommon.SyncStageBlockSync {
+		t.Errorf("Observe() stage = %s, want block sync for a node behind its peers", state.Stage)
+	}
+	catchingUp.now = func() time.Time { return now.Add(2 * time.Minute) }
+	if state := catchingUp.Observe(100, head, uint64(now.Add(-time.Hour).Unix()), []Peer{peerAt(500)}); state.Stage != meshcommon.SyncStageStalled {
+		t.Errorf("Observe() stage = %s, want stalled once the head stopped advancing", state.Stage)
+	}
+
+	// A head timestamp ahead of the local clock counts from the first observation
+	ahead := NewSyncMonitor(time.Minute, false, false)
+	ahead.now = func() time.Time { return now }
+	ahead.Observe(100, head, uint64(now.Add(time.Hour).Unix()), peers)
+	ahead.now = func() time.Time { return now.Add(2 * time.Minute) }
+	if state := ahead.Observe(100, head, uint64(now.Add(time.Hour).Unix()), peers); state.Stage != meshcommon.SyncStageStalled {
+		t.Errorf("Observe() stage = %s, want stalled after the threshold", state.Stage)
+	}
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// Statuses of a tracked transaction
+const (
+	TxStatusPending  = "pending"
+	TxStatusIncluded = "included"
+	TxStatusReverted = "reverted"
+	TxStatusExpired  = "expired"
+	TxStatusDropped  = "dropped"
+)
+
+const (
+	// TxTrackerInterval is the time between two checks of the tracked transactions, about one block
+	TxTrackerInterval = 10 * time.Second
+
+	// txTrackerRetention is how long a transaction is kept once it reached a final status
+	txTrackerRetention = time.Hour
+)
+
+// TrackedTransaction is the last known status of a submitted transaction
+type TrackedTransaction struct {
+	ID          thor.Bytes32
+	Status      string
+	SubmittedAt time.Time
+	UpdatedAt   time.Time
+	// BlockRef and Expiration bound the blocks the transaction can be included in
+	BlockRef   uint32
+	Expiration uint32
+	// Block is the block including the transaction, nil until it is included
+	Block *types.BlockIdentifier
+	// Finalized reports whether Block is finalized, an inclusion in a block that is not finalized can be reorganized
+	Finalized bool
+}
+
+// IsFinal reports whether the transaction can no longer change status
+func (t *TrackedTransaction) IsFinal() bool {
+	switch t.Status {
+	case TxStatusIncluded, TxStatusReverted:
+		return t.Finalized
+	case TxStatusExpired:
+		return true
+	}
+	return false
+}
+
+// trackedEntry is a tracked transaction together with the transaction itself
+type trackedEntry struct {
+	TrackedTransaction
+	trx *tx.Transaction
+}
+
+// TxTracker watches the submitted transactions until they are included or reverted in a finalized block, or expired.
+// A transaction the node reports missing from both the chain and the pool before its expiration is reported as dropped,
+// and is still watched in case it is submitted again by someone else.
+// A lookup failing for any other reason keeps the last known status until the next check.
+type TxTracker struct {
+	mu            sync.Mutex
+	vechainClient meshthor.VeChainClientInterface
+	entries       map[thor.Bytes32]*trackedEntry
+	now           func() time.Time
+}
+
+// NewTxTracker creates a tracker checking transactions against the given node
+func NewTxTracker(vechainClient meshthor.VeChainClientInterface) *TxTracker {
+	return &TxTracker{
+		vechainClient: vechainClient,
+		entries:       make(map[thor.Bytes32]*trackedEntry),
+		now:           time.Now,
+	}
+}
+
+// Track starts watching a submitted transaction
+func (t *TxTracker) Track(trx *tx.Transaction) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.entries[trx.ID()]; ok {
+		return
+	}
+	now := t.now()
+	t.entries[trx.ID()] = &trackedEntry{
+		TrackedTransaction: TrackedTransaction{
+			ID:          trx.ID(),
+			Status:      TxStatusPending,
+			SubmittedAt: now,
+			UpdatedAt:   now,
+			BlockRef:    trx.BlockRef().Number(),
+			Expiration:  trx.Expiration(),
+		},
+		trx: trx,
+	}
+}
+
+// Status returns the last known status of a transaction, false when it is not tracked
+func (t *TxTracker) Status(txID thor.Bytes32) (TrackedTransaction, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[txID]
+	if !ok {
+		return TrackedTransaction{}, false
+	}
+	return entry.TrackedTransaction, true
+}
+
+// Refresh checks every watched transaction once against the node and forgets the old final ones
+func (t *TxTracker) Refresh() error {
+	t.mu.Lock()
+	var watched []*trackedEntry
+	for id, entry := range t.entries {
+		if !entry.IsFinal() {
+			watched = append(watched, entry)
+		} else if t.now().Sub(entry.UpdatedAt) > txTrackerRetention {
+			delete(t.entries, id)
+		}
+	}
+	t.mu.Unlock()
+
+	if len(watched) == 0 {
+		return nil
+	}
+
+	// Statuses are only updated when the node is reachable, so that an outage is not reported as drops
+	best, err := t.vechainClient.GetBlockHeader("best")
+	if err != nil {
+		return err
+	}
+	finalized, err := t.vechainClient.GetBlockHeader("finalized")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range watched {
+		status, block := t.check(entry, best.Number)
+		isFinalized := block != nil && block.Index <= int64(finalized.Number)
+
+		t.mu.Lock()
+		if status != entry.Status || !sameBlock(block, entry.Block) || isFinalized != entry.Finalized {
+			entry.Status = status
+			entry.Block = block
+			entry.Finalized = isFinalized
+			entry.UpdatedAt = t.now()
+		}
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// check returns the current status of a transaction given the number of the best block.
+// The receipt is looked up again on every check, as an inclusion can be undone by a reorganization
+// until its block is finalized. A failed lookup returns the last known status.
+func (t *TxTracker) check(entry *trackedEntry, bestNumber uint32) (string, *types.BlockIdentifier) {
+	receipt, err := t.vechainClient.GetTransactionReceipt(entry.ID.String())
+	if err != nil && !errors.Is(err, meshthor.ErrTransactionNotFound) {
+		return entry.Status, entry.Block
+	}
+	if receipt != nil {
+		block := &types.BlockIdentifier{
+			Index: int64(receipt.Meta.BlockNumber),
+			Hash:  receipt.Meta.BlockID.String(),
+		}
+		if receipt.Reverted {
+			return TxStatusReverted, block
+		}
+		return TxStatusIncluded, block
+	}
+
+	if entry.trx.IsExpired(bestNumber) {
+		return TxStatusExpired, nil
+	}
+
+	pending, err := t.vechainClient.GetMempoolTransaction(&entry.ID)
+	switch {
+	case err == nil && pending != nil:
+		return TxStatusPending, nil
+	case err == nil || errors.Is(err, meshthor.ErrTransactionNotFound):
+		return TxStatusDropped, nil
+	default:
+		return entry.Status, entry.Block
+	}
+}
+
+// sameBlock reports whether two block identifiers, possibly nil, designate the same block
+func sameBlock(a, b *types.BlockIdentifier) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Hash == b.Hash
+}
+
+// Run refreshes the tracked transactions every interval until the context is done
+func (t *TxTracker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.Refresh(); err != nil {
+				log.Printf("Failed to refresh tracked transactions: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	meshtests "github.com/vechain/mesh/tests"
+	meshthor "github.com/vechain/mesh/thor"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/transactions"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// createTrackedTransaction builds a transaction of TestAddress1 valid from block 90 to block 110
+func createTrackedTransaction(t *testing.T, nonce uint64) *tx.Transaction {
+	t.Helper()
+	trx := tx.NewBuilder(tx.TypeLegacy).
+		ChainTag(0x27).
+		BlockRef(tx.NewBlockRef(90)).
+		Expiration(20).
+		Gas(21000).
+		Nonce(nonce).
+		Build()
+
+	privateKey, err := ethcrypto.HexToECDSA(meshtests.TestAddress1PrivateKey)
+	if err != nil {
+		t.Fatalf("HexToECDSA() error = %v", err)
+	}
+	hash := trx.SigningHash()
+	signature, err := ethcrypto.Sign(hash[:], privateKey)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	return trx.WithSignature(signature)
+}
+
+// trackerClient is a mock client with a finalized block of its own and failing transaction lookups
+type trackerClient struct {
+	*meshthor.MockVeChainClient
+	finalized  uint32
+	receiptErr error
+	mempoolErr error
+}
+
+func (c *trackerClient) GetBlockHeader(revision string) (*api.JSONBlockSummary, error) {
+	if revision == "finalized" && c.MockBlockError == nil {
+		return &api.JSONBlockSummary{Number: c.finalized}, nil
+	}
+	return c.MockVeChainClient.GetBlockHeader(revision)
+}
+
+func (c *trackerClient) GetTransactionReceipt(txID string) (*api.Receipt, error) {
+	if c.receiptErr != nil {
+		return nil, c.receiptErr
+	}
+	return c.MockVeChainClient.GetTransactionReceipt(txID)
+}
+
+func (c *trackerClient) GetMempoolTransaction(txID *thor.Bytes32) (*transactions.Transaction, error) {
+	if c.mempoolErr != nil {
+		return nil, c.mempoolErr
+	}
+	return c.MockVeChainClient.GetMempoolTransaction(txID)
+}
+
+// createTrackerClient returns a client whose best and finalized blocks are at the given number and which knows no receipt
+func createTrackerClient(bestNumber uint32) *trackerClient {
+	mockClient := meshthor.NewMockVeChainClient()
+	mockClient.MockReceipts = map[thor.Bytes32]*api.Receipt{}
+	mockClient.SetMockBlock(&api.JSONExpandedBlock{JSONBlockSummary: &api.JSONBlockSummary{Number: bestNumber}})
+	return &trackerClient{MockVeChainClient: mockClient, finalized: bestNumber}
+}
+
+func assertTrackedStatus(t *testing.T, tracker *TxTracker, txID thor.Bytes32, want string) TrackedTransaction {
+	t.Helper()
+	tracked, ok := tracker.Status(txID)
+	if !ok {
+		t.Fatalf("Status() transaction %s is not tracked", txID)
+	}
+	if tracked.Status != want {
+		t.Fatalf("Status() = %s, want %s", tracked.Status, want)
+	}
+	return tracked
+}
+
+func TestTxTracker_Lifecycle(t *testing.T) {
+	mockClient := createTrackerClient(100)
+	mockClient.SetMockMempoolTx(&transactions.Transaction{})
+	tracker := NewTxTracker(mockClient)
+
+	trx := createTrackedTransaction(t, 1)
+	tracker.Track(trx)
+	tracked := assertTrackedStatus(t, tracker, trx.ID(), TxStatusPending)
+	if tracked.BlockRef != 90 || tracked.Expiration != 20 {
+		t.Errorf("Status() block ref = %d and expiration = %d, want 90 and 20", tracked.BlockRef, tracked.Expiration)
+	}
+
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	assertTrackedStatus(t, tracker, trx.ID(), TxStatusPending)
+
+	// Missing from the pool before its expiration
+	mockClient.SetMockMempoolTx(nil)
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	assertTrackedStatus(t, tracker, trx.ID(), TxStatusDropped)
+
+	// A dropped transaction is still watched and can be included later
+	blockID := thor.BytesToBytes32([]byte{0x65})
+	mockClient.MockReceipts[trx.ID()] = &api.Receipt{Meta: api.ReceiptMeta{BlockID: blockID, BlockNumber: 101}}
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	tracked = assertTrackedStatus(t, tracker, trx.ID(), TxStatusIncluded)
+	if tracked.Block == nil || tracked.Block.Index != 101 || tracked.Block.Hash != blockID.String() {
+		t.Errorf("Status() block = %v, want block 101", tracked.Block)
+	}
+	if tracked.IsFinal() {
+		t.Error("IsFinal() = true before the block including the transaction is finalized")
+	}
+
+	// The inclusion is final once its block is finalized
+	mockClient.finalized = 101
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if tracked = assertTrackedStatus(t, tracker, trx.ID(), TxStatusIncluded); !tracked.Finalized || !tracked.IsFinal() {
+		t.Errorf("Status() = %+v, want a final inclusion", tracked)
+	}
+
+	// Final transactions are forgotten after the retention period
+	now := time.Now().Add(2 * txTrackerRetention)
+	tracker.now = func() time.Time { return now }
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if _, ok := tracker.Status(trx.ID()); ok {
+		t.Error("Status() expected the transaction to be forgotten")
+	}
+}
+
+func TestTxTracker_RevertedAndExpired(t *testing.T) {
+	mockClient := createTrackerClient(111)
+	tracker := NewTxTracker(mockClient)
+
+	reverted := createTrackedTransaction(t, 1)
+	expired := createTrackedTransaction(t, 2)
+	tracker.Track(reverted)
+	tracker.Track(expired)
+	mockClient.MockReceipts[reverted.ID()] = &api.Receipt{Reverted: true, Meta: api.ReceiptMeta{BlockNumber: 105}}
+
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	assertTrackedStatus(t, tracker, reverted.ID(), TxStatusReverted)
+	assertTrackedStatus(t, tracker, expired.ID(), TxStatusExpired)
+}
+
+func TestTxTracker_NodeUnavailable(t *testing.T) {
+	mockClient := createTrackerClient(100)
+	tracker := NewTxTracker(mockClient)
+	trx := createTrackedTransaction(t, 1)
+	tracker.Track(trx)
+
+	// An outage is not reported as a drop
+	mockClient.SetMockBlockError(errors.New("node unavailable"))
+	if err := tracker.Refresh(); err == nil {
+		t.Fatal("Refresh() expected error when the node is unavailable")
+	}
+	assertTrackedStatus(t, tracker, trx.ID(), TxStatusPending)
+}
+
+func TestTxTracker_Reorganization(t *testing.T) {
+	mockClient := createTrackerClient(100)
+	mockClient.finalized = 95
+	mockClient.SetMockMempoolTx(&transactions.Transaction{})
+	tracker := NewTxTracker(mockClient)
+	trx := createTrackedTransaction(t, 1)
+	tracker.Track(trx)
+
+	mockClient.MockReceipts[trx.ID()] = &api.Receipt{Meta: api.ReceiptMeta{BlockID: thor.BytesToBytes32([]byte{0x63}), BlockNumber: 99}}
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	assertTrackedStatus(t, tracker, trx.ID(), TxStatusIncluded)
+
+	// The block including the transaction is reorganized, the transaction is back in the pool
+	delete(mockClient.MockReceipts, trx.ID())
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if tracked := assertTrackedStatus(t, tracker, trx.ID(), TxStatusPending); tracked.Block != nil {
+		t.Errorf("Status() block = %v, want nil once reorganized", tracked.Block)
+	}
+
+	// It is then included in another block
+	blockID := thor.BytesToBytes32([]byte{0x64})
+	mockClient.MockReceipts[trx.ID()] = &api.Receipt{Meta: api.ReceiptMeta{BlockID: blockID, BlockNumber: 100}}
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if tracked := assertTrackedStatus(t, tracker, trx.ID(), TxStatusIncluded); tracked.Block == nil || tracked.Block.Hash != blockID.String() {
+		t.Errorf("Status() block = %v, want block %s", tracked.Block, blockID)
+	}
+}
+
+func TestTxTracker_LookupErrors(t *testing.T) {
+	mockClient := createTrackerClient(100)
+	mockClient.SetMockMempoolTx(nil)
+	tracker := NewTxTracker(mockClient)
+	trx := createTrackedTransaction(t, 1)
+	tracker.Track(trx)
+
+	// Only a transaction the node does not know is dropped, failed lookups keep the status
+	mockClient.receiptErr = errors.New("connection reset")
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	assertTrackedStatus(t, tracker, trx.ID(), TxStatusPending)
+
+	mockClient.receiptErr = nil
+	mockClient.mempoolErr = errors.New("connection reset")
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	assertTrackedStatus(t, tracker, trx.ID(), TxStatusPending)
+
+	mockClient.mempoolErr = fmt.Errorf("%w in mempool", meshthor.ErrTransactionNotFound)
+	if err := tracker.Refresh(); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	assertTrackedStatus(t, tracker, trx.ID(), TxStatusDropped)
+}
@@ -8,6 +8,10 @@ const (
 	TestAddress1PrivateKey = "5959365666b6165dbbbe6c406d1f07f5f430bab3064c5ea89ff0a0edd8ff5e19"
 	TestAddress1           = "0x16277a1ff38678291c41d1820957c78bb5da59ce"
 
+	// Gas payer key used by the delegation service stub
+	TestDelegatorPrivateKey = "7582be841ca040aa940fff6c05773129e135623e41acce3e0b8ba520dc1ae26a"
+	TestDelegatorAddress    = "0xd989829d88b0ed1b06edf5c50174ecfa64f14a64"
+
 	// This is the address used when simulating contract deployment in Thor
 	SimulatedContractAddress = "0x841a6556c524d47030762eb14dc4af897e605d9b"
 )
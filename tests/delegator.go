@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// DelegatorStub is a local VIP-201 delegation service signing every delegated transaction it receives
+type DelegatorStub struct {
+	*httptest.Server
+	Address    string
+	privateKey *ecdsa.PrivateKey
+	requests   atomic.Int32
+	// Reject makes the stub refuse to sign
+	Reject atomic.Bool
+}
+
+// NewDelegatorStub starts a delegation service signing with the given private key
+func NewDelegatorStub(privateKeyHex string) *DelegatorStub {
+	privateKey, err := ethcrypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		panic(err)
+	}
+	stub := &DelegatorStub{
+		Address:    strings.ToLower(ethcrypto.PubkeyToAddress(privateKey.PublicKey).Hex()),
+		privateKey: privateKey,
+	}
+	stub.Server = httptest.NewServer(http.HandlerFunc(stub.handle))
+	return stub
+}
+
+// Requests returns the number of signature requests received
+func (s *DelegatorStub) Requests() int {
+	return int(s.requests.Load())
+}
+
+func (s *DelegatorStub) handle(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	if s.Reject.Load() {
+		http.Error(w, "transaction not sponsored", http.StatusForbidden)
+		return
+	}
+
+	var request struct {
+		Origin string `json:"origin"`
+		Raw    string `json:"raw"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	origin, err := thor.ParseAddress(request.Origin)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	raw, err := hexutil.Decode(request.Raw)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var trx tx.Transaction
+	if err := trx.UnmarshalBinary(raw); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hash := trx.DelegatorSigningHash(origin)
+	signature, err := ethcrypto.Sign(hash[:], s.privateKey)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"signature": hexutil.Encode(signature)})
+}
@@ -262,9 +262,9 @@ func testDelegationFlow(
 		if op.Type == meshcommon.OperationTypeFeeDelegation {
 			hasFeeDelegationInBlock = true
 
-			// Verify account is the origin (sender)
-			if op.Account.Address != originAddress {
-				t.Fatalf("Fee delegation operation account should be origin %s, got %s", originAddress, op.Account.Address)
+			// Verify account is the delegator, who is charged the paid VTHO
+			if op.Account.Address != delegatorAddress {
+				t.Fatalf("Fee delegation operation account should be delegator %s, got %s", delegatorAddress, op.Account.Address)
 			}
 
 			// Verify metadata contains fee_delegator_account
@@ -280,8 +280,7 @@ func testDelegationFlow(
 			}
 
 			t.Logf("✅ Confirmed: Fee delegation operation in block")
-			t.Logf("   - Origin (sender): %s", op.Account.Address)
-			t.Logf("   - Delegator (payer): %s", delegatorInMetadata)
+			t.Logf("   - Delegator (payer): %s", op.Account.Address)
 			t.Logf("   - Amount: %s %s", op.Amount.Value, op.Amount.Currency.Symbol)
 			break
 		}
@@ -0,0 +1,90 @@
+package thor
+
+import (
+	"encoding/json"
+	"strconv"
+
+	meshcommon "github.com/vechain/mesh/common"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// CachingVeChainClient serves finalized blocks from memory and forwards every other call to the wrapped client.
+// Blocks above the finalized checkpoint, as well as named revisions such as "best", always reach the node.
+type CachingVeChainClient struct {
+	VeChainClientInterface
+	blocks *meshcommon.BlockCache[*api.JSONExpandedBlock]
+}
+
+// NewCachingVeChainClient wraps a client with a cache of finalized blocks holding up to maxBytes,
+// the client is returned as is when maxBytes is not positive
+func NewCachingVeChainClient(client VeChainClientInterface, maxBytes int64) VeChainClientInterface {
+	if maxBytes <= 0 {
+		return client
+	}
+	return &CachingVeChainClient{
+		VeChainClientInterface: client,
+		blocks:                 meshcommon.NewBlockCache[*api.JSONExpandedBlock](maxBytes),
+	}
+}
+
+// GetBlock fetches a block by its revision, from the cache when it is a finalized block ID or number
+func (c *CachingVeChainClient) GetBlock(revision string) (*api.JSONExpandedBlock, error) {
+	if block, ok := c.cached(revision); ok {
+		return block, nil
+	}
+
+	block, err := c.VeChainClientInterface.GetBlock(revision)
+	if err != nil {
+		return nil, err
+	}
+	c.store(block)
+	return block, nil
+}
+
+// GetBlockByNumber fetches a block by its number, from the cache when it is finalized
+func (c *CachingVeChainClient) GetBlockByNumber(blockNumber int64) (*api.JSONExpandedBlock, error) {
+	if blockNumber >= 0 && blockNumber <= int64(^uint32(0)) {
+		if block, ok := c.blocks.GetByNumber(uint32(blockNumber)); ok {
+			return block, nil
+		}
+	}
+
+	block, err := c.VeChainClientInterface.GetBlockByNumber(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	c.store(block)
+	return block, nil
+}
+
+// GetBlockHeader fetches the summary of a block by its revision, from the cache when it is a finalized block ID or number
+func (c *CachingVeChainClient) GetBlockHeader(revision string) (*api.JSONBlockSummary, error) {
+	if block, ok := c.cached(revision); ok {
+		return block.JSONBlockSummary, nil
+	}
+	return c.VeChainClientInterface.GetBlockHeader(revision)
+}
+
+// cached returns the cached block of a revision when it is a block ID or number
+func (c *CachingVeChainClient) cached(revision string) (*api.JSONExpandedBlock, bool) {
+	if id, err := thor.ParseBytes32(revision); err == nil {
+		return c.blocks.Get(id.String())
+	}
+	if number, err := strconv.ParseUint(revision, 0, 32); err == nil {
+		return c.blocks.GetByNumber(uint32(number))
+	}
+	return nil, false
+}
+
+// store caches a block once it is finalized, its size is estimated from its JSON encoding
+func (c *CachingVeChainClient) store(block *api.JSONExpandedBlock) {
+	if block == nil || block.JSONBlockSummary == nil || !block.IsFinalized {
+		return
+	}
+	encoded, err := json.Marshal(block)
+	if err != nil {
+		return
+	}
+	c.blocks.Put(block.ID.String(), block.Number, block, int64(len(encoded)))
+}
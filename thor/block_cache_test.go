@@ -0,0 +1,80 @@
+package thor
+
+import (
+	"testing"
+
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// countingClient counts the blocks fetched from the wrapped client
+type countingClient struct {
+	*MockVeChainClient
+	calls int
+}
+
+func (c *countingClient) GetBlock(revision string) (*api.JSONExpandedBlock, error) {
+	c.calls++
+	return c.MockVeChainClient.GetBlock(revision)
+}
+
+func (c *countingClient) GetBlockByNumber(blockNumber int64) (*api.JSONExpandedBlock, error) {
+	c.calls++
+	return c.MockVeChainClient.GetBlockByNumber(blockNumber)
+}
+
+func TestCachingVeChainClient(t *testing.T) {
+	finalized := &api.JSONExpandedBlock{JSONBlockSummary: &api.JSONBlockSummary{
+		Number:      7,
+		ID:          thor.BytesToBytes32([]byte{0x07}),
+		IsFinalized: true,
+	}}
+	recent := &api.JSONExpandedBlock{JSONBlockSummary: &api.JSONBlockSummary{
+		Number: 8,
+		ID:     thor.BytesToBytes32([]byte{0x08}),
+	}}
+	mock := NewMockVeChainClient()
+	mock.MockBlocksByNumber = map[int64]*api.JSONExpandedBlock{7: finalized, 8: recent}
+	counting := &countingClient{MockVeChainClient: mock}
+	client := NewCachingVeChainClient(counting, 1<<20)
+
+	for range 3 {
+		if block, err := client.GetBlockByNumber(7); err != nil || block != finalized {
+			t.Fatalf("GetBlockByNumber() = %v, %v", block, err)
+		}
+	}
+	if counting.calls != 1 {
+		t.Errorf("GetBlockByNumber() reached the node %d times for a finalized block, want 1", counting.calls)
+	}
+
+	// A finalized block is served by ID and by number revision
+	for _, revision := range []string{finalized.ID.String(), "7", "0x7"} {
+		if block, err := client.GetBlock(revision); err != nil || block != finalized {
+			t.Errorf("GetBlock(%s) = %v, %v", revision, block, err)
+		}
+	}
+	if counting.calls != 1 {
+		t.Errorf("GetBlock() reached the node %d times, want 1", counting.calls)
+	}
+
+	// Blocks above the finalized checkpoint and named revisions are never cached
+	for range 2 {
+		if _, err := client.GetBlockByNumber(8); err != nil {
+			t.Fatalf("GetBlockByNumber() error = %v", err)
+		}
+	}
+	mock.MockBlock = finalized
+	if _, err := client.GetBlock("best"); err != nil {
+		t.Fatalf("GetBlock() error = %v", err)
+	}
+	if counting.calls != 4 {
+		t.Errorf("node calls = %d, want 4", counting.calls)
+	}
+}
+
+func TestNewCachingVeChainClient_Disabled(t *testing.T) {
+	mock := NewMockVeChainClient()
+	if client := NewCachingVeChainClient(mock, 0); client != mock {
+		t.Error("NewCachingVeChainClient() expected the client itself without memory")
+	}
+}
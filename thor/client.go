@@ -1,20 +1,25 @@
 package thor
 
 import (
+	"errors"
 	"fmt"
-	"math"
 	"math/big"
-	"time"
 
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/transactions"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/thorclient/httpclient"
 	"github.com/vechain/thor/v2/tx"
 )
 
+// ErrTransactionNotFound is returned by transaction lookups when the node answers that it does not know the transaction
+var ErrTransactionNotFound = errors.New("transaction not found")
+
 type VeChainClient struct {
 	client ThorClientInterface
+	// pool is the snapshot of the mempool used when a transaction cannot be queried by ID
+	pool txPoolSnapshot
 }
 
 // NewVeChainClient creates a new VeChain client
@@ -42,6 +47,18 @@ func (c *VeChainClient) GetBlockByNumber(blockNumber int64) (*api.JSONExpandedBl
 	return c.GetBlock(fmt.Sprintf("%d", blockNumber))
 }
 
+// GetBlockHeader fetches the summary of a block by its revision, without its transactions
+func (c *VeChainClient) GetBlockHeader(revision string) (*api.JSONBlockSummary, error) {
+	block, err := c.client.Block(revision)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", revision)
+	}
+	return block.JSONBlockSummary, nil
+}
+
 // GetAccount fetches account details by address at the latest block
 func (c *VeChainClient) GetAccount(address string) (*api.Account, error) {
 	return c.GetAccountAtRevision(address, "")
@@ -96,6 +113,15 @@ type DynamicGasPrice struct {
 	Reward  *big.Int
 }
 
+// GetFeesHistory gets the base fees and the given reward percentile of the latest blocks, oldest first
+func (c *VeChainClient) GetFeesHistory(blockCount uint32, rewardPercentile float64) (*api.FeesHistory, error) {
+	feesHistory, err := c.client.FeesHistory(blockCount, "best", []float64{rewardPercentile})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fees history: %w", err)
+	}
+	return feesHistory, nil
+}
+
 // GetDynamicGasPrice gets the current dynamic gas price from the network
 func (c *VeChainClient) GetDynamicGasPrice() (*DynamicGasPrice, error) {
 	feesHistory, err := c.client.FeesHistory(1, "best", []float64{50})
@@ -126,40 +152,6 @@ func (c *VeChainClient) GetDynamicGasPrice() (*DynamicGasPrice, error) {
 	}, nil
 }
 
-// GetSyncProgress returns the current sync progress (0.0 to 1.0)
-func (c *VeChainClient) GetSyncProgress() (float64, error) {
-	// Get best block (head)
-	bestBlock, err := c.GetBlock("best")
-	if err != nil {
-		return 0, err
-	}
-
-	// Get genesis block
-	genesisBlock, err := c.GetBlock("0")
-	if err != nil {
-		return 0, err
-	}
-
-	nowTsMs := float64(time.Now().UnixMilli())
-	headTsMs := float64(bestBlock.Timestamp * 1000)
-	genesisTsMs := float64(genesisBlock.Timestamp * 1000)
-
-	// If the head block is recent (within 30 seconds), consider it fully synced
-	if nowTsMs-headTsMs < 30*1000 {
-		return 1.0, nil
-	}
-
-	// Calculate sync progress based on time difference
-	progress := (headTsMs - genesisTsMs) / (nowTsMs - genesisTsMs)
-
-	// Return NaN if progress is negative (shouldn't happen in normal conditions)
-	if progress < 0 {
-		return math.NaN(), nil
-	}
-
-	return progress, nil
-}
-
 // GetPeers returns the list of connected peers
 func (c *VeChainClient) GetPeers() ([]Peer, error) {
 	peers, err := c.client.Peers()
@@ -207,31 +199,44 @@ func (c *VeChainClient) GetMempoolTransactions(origin *thor.Address) ([]*thor.By
 	return []*thor.Bytes32{}, nil
 }
 
-// GetMempoolTransaction returns a specific transaction from the mempool
+// GetMempoolTransaction returns a specific transaction from the mempool.
+// The transaction is queried by ID from the node, the pool snapshot is only used when that query fails.
 func (c *VeChainClient) GetMempoolTransaction(txID *thor.Bytes32) (*transactions.Transaction, error) {
-	// Get all expanded transactions from mempool (no origin filter)
-	txPool, err := c.getTransactions(nil, true)
+	tx, err := c.client.Transaction(txID, thorclient.Pending())
+	if err == nil {
+		// The node answers null for unknown transactions and sets the meta of included ones
+		if tx == nil || tx.ID.IsZero() || tx.Meta != nil {
+			return nil, fmt.Errorf("%w in mempool", ErrTransactionNotFound)
+		}
+		return tx, nil
+	}
+
+	txs, err := c.pool.get(c.fetchMempoolTransactions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get mempool transactions: %w", err)
 	}
-
-	var txs []*transactions.Transaction
-	if txList, ok := txPool.([]transactions.Transaction); ok {
-		for i := range txList {
-			txs = append(txs, &txList[i])
-		}
-	} else {
-		return nil, fmt.Errorf("unexpected response type from TxPool: %T", txPool)
+	if tx, ok := txs[*txID]; ok {
+		return tx, nil
 	}
+	return nil, fmt.Errorf("%w in mempool", ErrTransactionNotFound)
+}
 
-	// Find the transaction with the matching ID
-	for _, tx := range txs {
-		if tx.ID == *txID {
-			return tx, nil
-		}
+// fetchMempoolTransactions returns all the expanded transactions of the mempool by ID
+func (c *VeChainClient) fetchMempoolTransactions() (map[thor.Bytes32]*transactions.Transaction, error) {
+	txPool, err := c.getTransactions(nil, true)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("transaction not found in mempool")
+	txList, ok := txPool.([]transactions.Transaction)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type from TxPool: %T", txPool)
+	}
+	txs := make(map[thor.Bytes32]*transactions.Transaction, len(txList))
+	for i := range txList {
+		txs[txList[i].ID] = &txList[i]
+	}
+	return txs, nil
 }
 
 // GetMempoolStatus returns the current status of the transaction pool
@@ -293,6 +298,9 @@ func (c *VeChainClient) GetTransactionReceipt(txID string) (*api.Receipt, error)
 	}
 
 	receipt, err := c.client.TransactionReceipt(&txHash)
+	if errors.Is(err, httpclient.ErrNotFound) {
+		return nil, fmt.Errorf("%w: no receipt for %s", ErrTransactionNotFound, txID)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction receipt: %w", err)
 	}
@@ -307,3 +315,21 @@ func (c *VeChainClient) InspectClauses(batchCallData *api.BatchCallData, options
 	}
 	return results, nil
 }
+
+// FilterTransfers returns the VET transfer logs matching the filter
+func (c *VeChainClient) FilterTransfers(filter *api.TransferFilter) ([]*api.FilteredTransfer, error) {
+	transfers, err := c.client.FilterTransfers(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+// FilterEvents returns the event logs matching the filter
+func (c *VeChainClient) FilterEvents(filter *api.EventFilter) ([]api.FilteredEvent, error) {
+	events, err := c.client.FilterEvents(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter events: %w", err)
+	}
+	return events, nil
+}
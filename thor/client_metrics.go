@@ -0,0 +1,188 @@
+package thor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	meshcommon "github.com/vechain/mesh/common"
+	meshmetrics "github.com/vechain/mesh/common/metrics"
+	"github.com/vechain/thor/v2/api"
+	"github.com/vechain/thor/v2/api/transactions"
+	"github.com/vechain/thor/v2/thor"
+	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/tx"
+)
+
+// InstrumentedVeChainClient records the latency of every call made to the wrapped client
+type InstrumentedVeChainClient struct {
+	client VeChainClientInterface
+}
+
+// NewInstrumentedVeChainClient wraps a client to record the latency of its calls
+func NewInstrumentedVeChainClient(client VeChainClientInterface) *InstrumentedVeChainClient {
+	return &InstrumentedVeChainClient{client: client}
+}
+
+func (c *InstrumentedVeChainClient) GetBlock(revision string) (block *api.JSONExpandedBlock, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetBlock", start, err) }(time.Now())
+	return c.client.GetBlock(revision)
+}
+
+func (c *InstrumentedVeChainClient) GetBlockByNumber(blockNumber int64) (block *api.JSONExpandedBlock, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetBlockByNumber", start, err) }(time.Now())
+	return c.client.GetBlockByNumber(blockNumber)
+}
+
+func (c *InstrumentedVeChainClient) GetBlockHeader(revision string) (header *api.JSONBlockSummary, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetBlockHeader", start, err) }(time.Now())
+	return c.client.GetBlockHeader(revision)
+}
+
+func (c *InstrumentedVeChainClient) GetAccount(address string) (account *api.Account, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetAccount", start, err) }(time.Now())
+	return c.client.GetAccount(address)
+}
+
+func (c *InstrumentedVeChainClient) GetAccountAtRevision(address string, revision string) (account *api.Account, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetAccountAtRevision", start, err) }(time.Now())
+	return c.client.GetAccountAtRevision(address, revision)
+}
+
+func (c *InstrumentedVeChainClient) GetChainID() (chainID int, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetChainID", start, err) }(time.Now())
+	return c.client.GetChainID()
+}
+
+func (c *InstrumentedVeChainClient) SubmitTransaction(vechainTx *tx.Transaction) (txID string, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("SubmitTransaction", start, err) }(time.Now())
+	return c.client.SubmitTransaction(vechainTx)
+}
+
+func (c *InstrumentedVeChainClient) GetDynamicGasPrice() (gasPrice *DynamicGasPrice, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetDynamicGasPrice", start, err) }(time.Now())
+	return c.client.GetDynamicGasPrice()
+}
+
+func (c *InstrumentedVeChainClient) GetFeesHistory(blockCount uint32, rewardPercentile float64) (feesHistory *api.FeesHistory, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetFeesHistory", start, err) }(time.Now())
+	return c.client.GetFeesHistory(blockCount, rewardPercentile)
+}
+
+func (c *InstrumentedVeChainClient) GetPeers() (peers []Peer, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetPeers", start, err) }(time.Now())
+	return c.client.GetPeers()
+}
+
+func (c *InstrumentedVeChainClient) GetMempoolTransactions(origin *thor.Address) (txIDs []*thor.Bytes32, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetMempoolTransactions", start, err) }(time.Now())
+	return c.client.GetMempoolTransactions(origin)
+}
+
+func (c *InstrumentedVeChainClient) GetMempoolTransaction(txID *thor.Bytes32) (trx *transactions.Transaction, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetMempoolTransaction", start, err) }(time.Now())
+	return c.client.GetMempoolTransaction(txID)
+}
+
+func (c *InstrumentedVeChainClient) GetMempoolStatus() (status *api.Status, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetMempoolStatus", start, err) }(time.Now())
+	return c.client.GetMempoolStatus()
+}
+
+func (c *InstrumentedVeChainClient) CallContract(contractAddress, callData string) (result string, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("CallContract", start, err) }(time.Now())
+	return c.client.CallContract(contractAddress, callData)
+}
+
+func (c *InstrumentedVeChainClient) GetTransaction(txID string) (trx *transactions.Transaction, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetTransaction", start, err) }(time.Now())
+	return c.client.GetTransaction(txID)
+}
+
+func (c *InstrumentedVeChainClient) GetTransactionReceipt(txID string) (receipt *api.Receipt, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("GetTransactionReceipt", start, err) }(time.Now())
+	return c.client.GetTransactionReceipt(txID)
+}
+
+func (c *InstrumentedVeChainClient) InspectClauses(batchCallData *api.BatchCallData, options ...thorclient.Option) (results []*api.CallResult, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("InspectClauses", start, err) }(time.Now())
+	return c.client.InspectClauses(batchCallData, options...)
+}
+
+func (c *InstrumentedVeChainClient) FilterTransfers(filter *api.TransferFilter) (transfers []*api.FilteredTransfer, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("FilterTransfers", start, err) }(time.Now())
+	return c.client.FilterTransfers(filter)
+}
+
+func (c *InstrumentedVeChainClient) FilterEvents(filter *api.EventFilter) (events []api.FilteredEvent, err error) {
+	defer func(start time.Time) { meshmetrics.ObserveThorCall("FilterEvents", start, err) }(time.Now())
+	return c.client.FilterEvents(filter)
+}
+
+const (
+	// BlockHeightsInterval is the time between two updates of the block heights metrics
+	BlockHeightsInterval = 15 * time.Second
+	// blockHeightsErrorLogInterval is the minimum time between two logs of the same failure to update the heights
+	blockHeightsErrorLogInterval = 5 * time.Minute
+)
+
+// WatchBlockHeights updates the best and finalized block heights every interval until the context is done.
+// While the node is unreachable the failure is logged once every blockHeightsErrorLogInterval.
+func WatchBlockHeights(ctx context.Context, client VeChainClientInterface, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	limiter := &failureLogLimiter{interval: blockHeightsErrorLogInterval}
+	for {
+		err := updateBlockHeights(client)
+		if limiter.shouldLog(err, time.Now()) {
+			if err != nil {
+				log.Printf("Failed to update block heights metrics: %v", err)
+			} else {
+				log.Println("Block heights metrics updated again")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// updateBlockHeights sets the best and finalized block heights, returning the first failure
+func updateBlockHeights(client VeChainClientInterface) error {
+	for _, revision := range []string{"best", meshcommon.RevisionFinalized} {
+		header, err := client.GetBlockHeader(revision)
+		if err != nil {
+			return fmt.Errorf("failed to get %s block: %w", revision, err)
+		}
+		meshmetrics.BlockHeight.WithLabelValues(revision).Set(float64(header.Number))
+	}
+	return nil
+}
+
+// failureLogLimiter decides when a repeated failure is logged: when it starts, then once per interval,
+// and once more when it recovers
+type failureLogLimiter struct {
+	interval time.Duration
+	failing  bool
+	lastLog  time.Time
+}
+
+// shouldLog reports whether the result of an attempt made at now is logged
+func (l *failureLogLimiter) shouldLog(err error, now time.Time) bool {
+	if err == nil {
+		recovered := l.failing
+		l.failing = false
+		return recovered
+	}
+	if l.failing && now.Sub(l.lastLog) < l.interval {
+		return false
+	}
+	l.failing = true
+	l.lastLog = now
+	return true
+}
@@ -0,0 +1,93 @@
+package thor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	meshcommon "github.com/vechain/mesh/common"
+	meshmetrics "github.com/vechain/mesh/common/metrics"
+	"github.com/vechain/thor/v2/api"
+)
+
+// thorCallCount returns the number of Thor node calls recorded for a client method and result
+func thorCallCount(t *testing.T, method, result string) uint64 {
+	t.Helper()
+	var metric dto.Metric
+	if err := meshmetrics.ThorCallDuration.WithLabelValues(method, result).(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestInstrumentedVeChainClient(t *testing.T) {
+	mock := NewMockVeChainClient()
+	client := NewInstrumentedVeChainClient(mock)
+	okBefore, errorBefore := thorCallCount(t, "GetChainID", "ok"), thorCallCount(t, "GetChainID", "error")
+
+	chainID, err := client.GetChainID()
+	if err != nil {
+		t.Fatalf("GetChainID() error = %v", err)
+	}
+	if want, _ := mock.GetChainID(); chainID != want {
+		t.Errorf("GetChainID() = %d, want %d", chainID, want)
+	}
+	mock.SetMockError(errors.New("node unavailable"))
+	if _, err := client.GetChainID(); err == nil {
+		t.Error("GetChainID() expected the error of the wrapped client")
+	}
+
+	if count := thorCallCount(t, "GetChainID", "ok") - okBefore; count != 1 {
+		t.Errorf("GetChainID() recorded %d successful calls, want 1", count)
+	}
+	if count := thorCallCount(t, "GetChainID", "error") - errorBefore; count != 1 {
+		t.Errorf("GetChainID() recorded %d failed calls, want 1", count)
+	}
+}
+
+func TestWatchBlockHeights(t *testing.T) {
+	mock := NewMockVeChainClient()
+	mock.SetMockBlock(&api.JSONExpandedBlock{JSONBlockSummary: &api.JSONBlockSummary{Number: 42}})
+
+	// The heights are updated once before the context is checked
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	WatchBlockHeights(ctx, mock, time.Hour)
+
+	for _, revision := range []string{"best", meshcommon.RevisionFinalized} {
+		if height := testutil.ToFloat64(meshmetrics.BlockHeight.WithLabelValues(revision)); height != 42 {
+			t.Errorf("WatchBlockHeights() %s height = %v, want 42", revision, height)
+		}
+	}
+}
+
+func TestFailureLogLimiter(t *testing.T) {
+	limiter := &failureLogLimiter{interval: time.Minute}
+	failure := errors.New("connection refused")
+	start := time.Unix(1700000000, 0)
+
+	steps := []struct {
+		err  error
+		at   time.Duration
+		want bool
+	}{
+		{nil, 0, false},
+		{failure, 0, true},
+		{failure, 10 * time.Second, false},
+		{failure, 50 * time.Second, false},
+		{failure, time.Minute, true},
+		{failure, 90 * time.Second, false},
+		{nil, 100 * time.Second, true},
+		{nil, 110 * time.Second, false},
+		{failure, 120 * time.Second, true},
+	}
+	for i, step := range steps {
+		if got := limiter.shouldLog(step.err, start.Add(step.at)); got != step.want {
+			t.Errorf("step %d: shouldLog(%v) = %v, want %v", i, step.err, got, step.want)
+		}
+	}
+}
@@ -3,12 +3,15 @@ package thor
 import (
 	"fmt"
 	"math/big"
+	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	meshtests "github.com/vechain/mesh/tests"
 	"github.com/vechain/thor/v2/api"
 	"github.com/vechain/thor/v2/api/transactions"
+	"github.com/vechain/thor/v2/logdb"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/thorclient"
 	"github.com/vechain/thor/v2/tx"
@@ -22,7 +25,7 @@ type MockVeChainClient struct {
 	MockAccount        *api.Account
 	MockChainID        int
 	MockGasPrice       *DynamicGasPrice
-	MockSyncProgress   float64
+	MockFeesHistory    *api.FeesHistory
 	MockPeers          []Peer
 	MockMempoolTxs     []*thor.Bytes32
 	MockMempoolTx      *transactions.Transaction
@@ -31,9 +34,15 @@ type MockVeChainClient struct {
 	MockCallResults    []string
 	MockCallIndex      int
 	MockBlockByNumber  *api.JSONExpandedBlock
+	MockBlocksByNumber map[int64]*api.JSONExpandedBlock
 	MockTransaction    *transactions.Transaction
 	MockReceipt        *api.Receipt
 	MockInspectClauses []*api.CallResult
+	LastBatchCallData  *api.BatchCallData
+	MockTransactions   map[thor.Bytes32]*transactions.Transaction
+	MockReceipts       map[thor.Bytes32]*api.Receipt
+	MockTransfers      []*api.FilteredTransfer
+	MockEvents         []api.FilteredEvent
 
 	// Simulated errors
 	MockError        error
@@ -192,11 +201,11 @@ func NewMockVeChainClient() *MockVeChainClient {
 			BaseFee: big.NewInt(1000000000000000000), // 1 VTHO
 			Reward:  big.NewInt(500000000000000000),  // 0.5 VTHO
 		},
-		MockSyncProgress: 1.0,
 		MockPeers: []Peer{
 			{
-				PeerID:      "peer1",
-				BestBlockID: "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef",
+				PeerID: "peer1",
+				// Block 100, like the best block
+				BestBlockID: "0x000000647890abcdef1234567890abcdef1234567890abcdef1234567890abcd",
 			},
 		},
 		MockMempoolTxs: []*thor.Bytes32{
@@ -278,11 +287,37 @@ func (m *MockVeChainClient) GetBlock(revision string) (*api.JSONExpandedBlock, e
 }
 
 func (m *MockVeChainClient) GetBlockByNumber(blockNumber int64) (*api.JSONExpandedBlock, error) {
+	// Serve the simulated chain if configured
+	if m.MockBlocksByNumber != nil && m.MockBlockError == nil && m.MockError == nil {
+		block, ok := m.MockBlocksByNumber[blockNumber]
+		if !ok {
+			return nil, fmt.Errorf("block %d not found", blockNumber)
+		}
+		return block, nil
+	}
+
 	// Convert to hex format and use GetBlock
 	revision := fmt.Sprintf("0x%x", blockNumber)
 	return m.GetBlock(revision)
 }
 
+func (m *MockVeChainClient) GetBlockHeader(revision string) (*api.JSONBlockSummary, error) {
+	var block *api.JSONExpandedBlock
+	var err error
+	if number, parseErr := strconv.ParseInt(revision, 0, 64); parseErr == nil {
+		block, err = m.GetBlockByNumber(number)
+	} else {
+		block, err = m.GetBlock(revision)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", revision)
+	}
+	return block.JSONBlockSummary, nil
+}
+
 func (m *MockVeChainClient) GetAccount(address string) (*api.Account, error) {
 	if m.MockAccountError != nil {
 		return nil, m.MockAccountError
@@ -325,11 +360,27 @@ func (m *MockVeChainClient) GetDynamicGasPrice() (*DynamicGasPrice, error) {
 	return m.MockGasPrice, nil
 }
 
-func (m *MockVeChainClient) GetSyncProgress() (float64, error) {
+// GetFeesHistory simulates the fees history, repeating the mock gas price for every block unless set
+func (m *MockVeChainClient) GetFeesHistory(blockCount uint32, rewardPercentile float64) (*api.FeesHistory, error) {
 	if m.MockError != nil {
-		return 0, m.MockError
+		return nil, m.MockError
+	}
+	if m.MockFeesHistory != nil {
+		return m.MockFeesHistory, nil
+	}
+
+	feesHistory := &api.FeesHistory{}
+	for range blockCount {
+		feesHistory.BaseFeePerGas = append(feesHistory.BaseFeePerGas, (*hexutil.Big)(m.MockGasPrice.BaseFee))
+		feesHistory.GasUsedRatio = append(feesHistory.GasUsedRatio, 0.5)
+		feesHistory.Reward = append(feesHistory.Reward, []*hexutil.Big{(*hexutil.Big)(m.MockGasPrice.Reward)})
 	}
-	return m.MockSyncProgress, nil
+	return feesHistory, nil
+}
+
+// SetFeesHistory configures the simulated fees history
+func (m *MockVeChainClient) SetFeesHistory(feesHistory *api.FeesHistory) {
+	m.MockFeesHistory = feesHistory
 }
 
 func (m *MockVeChainClient) GetPeers() ([]Peer, error) {
@@ -421,6 +472,14 @@ func (m *MockVeChainClient) SetMockCallResults(results []string) {
 	m.MockCallIndex = 0
 }
 
+// SetBlocksByNumber configures a simulated chain, each block is served by its number
+func (m *MockVeChainClient) SetBlocksByNumber(blocks []*api.JSONExpandedBlock) {
+	m.MockBlocksByNumber = make(map[int64]*api.JSONExpandedBlock, len(blocks))
+	for _, block := range blocks {
+		m.MockBlocksByNumber[int64(block.Number)] = block
+	}
+}
+
 // SetBlockByNumber configures the simulated block by number
 func (m *MockVeChainClient) SetBlockByNumber(block *api.JSONExpandedBlock) {
 	m.MockBlockByNumber = block
@@ -431,6 +490,13 @@ func (m *MockVeChainClient) GetTransaction(txID string) (*transactions.Transacti
 	if m.MockError != nil {
 		return nil, m.MockError
 	}
+	if m.MockTransactions != nil {
+		id, _ := thor.ParseBytes32(txID)
+		if tx, ok := m.MockTransactions[id]; ok {
+			return tx, nil
+		}
+		return nil, fmt.Errorf("transaction %s not found", txID)
+	}
 	return m.MockTransaction, nil
 }
 
@@ -439,6 +505,13 @@ func (m *MockVeChainClient) GetTransactionReceipt(txID string) (*api.Receipt, er
 	if m.MockError != nil {
 		return nil, m.MockError
 	}
+	if m.MockReceipts != nil {
+		id, _ := thor.ParseBytes32(txID)
+		if receipt, ok := m.MockReceipts[id]; ok {
+			return receipt, nil
+		}
+		return nil, fmt.Errorf("%w: no receipt for %s", ErrTransactionNotFound, txID)
+	}
 	return m.MockReceipt, nil
 }
 
@@ -454,6 +527,7 @@ func (m *MockVeChainClient) SetReceipt(receipt *api.Receipt) {
 
 // InspectClauses simulates inspecting clauses
 func (m *MockVeChainClient) InspectClauses(batchCallData *api.BatchCallData, options ...thorclient.Option) ([]*api.CallResult, error) {
+	m.LastBatchCallData = batchCallData
 	if m.MockError != nil {
 		return nil, m.MockError
 	}
@@ -486,3 +560,120 @@ func (m *MockVeChainClient) InspectClausesWithRevision(batchCallData *api.BatchC
 	// For mock purposes, we ignore the revision and return the same results
 	return m.InspectClauses(batchCallData)
 }
+
+// FilterTransfers simulates filtering transfer logs, applying the criteria, range, order and options of the filter
+func (m *MockVeChainClient) FilterTransfers(filter *api.TransferFilter) ([]*api.FilteredTransfer, error) {
+	if m.MockError != nil {
+		return nil, m.MockError
+	}
+
+	var matched []*api.FilteredTransfer
+	for _, transfer := range m.MockTransfers {
+		if !mockLogInRange(transfer.Meta, filter.Range) {
+			continue
+		}
+		matches := len(filter.CriteriaSet) == 0
+		for _, criteria := range filter.CriteriaSet {
+			if (criteria.TxOrigin == nil || *criteria.TxOrigin == transfer.Meta.TxOrigin) &&
+				(criteria.Sender == nil || *criteria.Sender == transfer.Sender) &&
+				(criteria.Recipient == nil || *criteria.Recipient == transfer.Recipient) {
+				matches = true
+				break
+			}
+		}
+		if matches {
+			matched = append(matched, transfer)
+		}
+	}
+	return mockPaginateLogs(matched, filter.Order == logdb.DESC, filter.Options), nil
+}
+
+// FilterEvents simulates filtering event logs, applying the criteria, range, order and options of the filter
+func (m *MockVeChainClient) FilterEvents(filter *api.EventFilter) ([]api.FilteredEvent, error) {
+	if m.MockError != nil {
+		return nil, m.MockError
+	}
+
+	var matched []api.FilteredEvent
+	for _, event := range m.MockEvents {
+		if !mockLogInRange(event.Meta, filter.Range) {
+			continue
+		}
+		matches := len(filter.CriteriaSet) == 0
+		for _, criteria := range filter.CriteriaSet {
+			if (criteria.Address == nil || *criteria.Address == event.Address) &&
+				mockTopicMatches(criteria.Topic0, event.Topics, 0) &&
+				mockTopicMatches(criteria.Topic1, event.Topics, 1) &&
+				mockTopicMatches(criteria.Topic2, event.Topics, 2) &&
+				mockTopicMatches(criteria.Topic3, event.Topics, 3) {
+				matches = true
+				break
+			}
+		}
+		if matches {
+			matched = append(matched, event)
+		}
+	}
+	return mockPaginateLogs(matched, filter.Order == logdb.DESC, filter.Options), nil
+}
+
+// mockLogInRange checks whether a log belongs to a block range
+func mockLogInRange(meta api.LogMeta, rng *api.Range) bool {
+	if rng == nil {
+		return true
+	}
+	if rng.From != nil && uint64(meta.BlockNumber) < *rng.From {
+		return false
+	}
+	return rng.To == nil || uint64(meta.BlockNumber) <= *rng.To
+}
+
+// mockTopicMatches checks a topic criteria against the topics of an event
+func mockTopicMatches(topic *thor.Bytes32, topics []*thor.Bytes32, index int) bool {
+	if topic == nil {
+		return true
+	}
+	return index < len(topics) && topics[index] != nil && *topics[index] == *topic
+}
+
+// mockPaginateLogs orders logs, which are configured in ascending order, and applies the pagination options
+func mockPaginateLogs[T any](logs []T, desc bool, options *api.Options) []T {
+	if desc {
+		reversed := make([]T, len(logs))
+		for i, log := range logs {
+			reversed[len(logs)-1-i] = log
+		}
+		logs = reversed
+	}
+	if options == nil {
+		return logs
+	}
+	if options.Offset >= uint64(len(logs)) {
+		return nil
+	}
+	logs = logs[options.Offset:]
+	if options.Limit != nil && *options.Limit < uint64(len(logs)) {
+		logs = logs[:*options.Limit]
+	}
+	return logs
+}
+
+// AddTransaction configures a simulated transaction and its receipt, served by ID
+func (m *MockVeChainClient) AddTransaction(tx *transactions.Transaction, receipt *api.Receipt) {
+	if m.MockTransactions == nil {
+		m.MockTransactions = map[thor.Bytes32]*transactions.Transaction{}
+		m.MockReceipts = map[thor.Bytes32]*api.Receipt{}
+	}
+	m.MockTransactions[tx.ID] = tx
+	m.MockReceipts[tx.ID] = receipt
+}
+
+// SetTransfers configures the simulated transfer logs, in ascending order
+func (m *MockVeChainClient) SetTransfers(transfers []*api.FilteredTransfer) {
+	m.MockTransfers = transfers
+}
+
+// SetEvents configures the simulated event logs, in ascending order
+func (m *MockVeChainClient) SetEvents(events []api.FilteredEvent) {
+	m.MockEvents = events
+}
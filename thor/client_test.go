@@ -1,9 +1,12 @@
 package thor
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -13,6 +16,7 @@ import (
 	"github.com/vechain/thor/v2/api/transactions"
 	"github.com/vechain/thor/v2/thor"
 	"github.com/vechain/thor/v2/thorclient"
+	"github.com/vechain/thor/v2/thorclient/httpclient"
 	"github.com/vechain/thor/v2/tx"
 )
 
@@ -76,25 +80,6 @@ func TestVeChainClient_GetDynamicGasPrice(t *testing.T) {
 	}
 }
 
-func TestVeChainClient_GetSyncProgress(t *testing.T) {
-	// Test with mock client to cover success path
-	mockClient := NewMockVeChainClient()
-	progress, err := mockClient.GetSyncProgress()
-	if err != nil {
-		t.Errorf("GetSyncProgress() with mock client should not return error, got: %v", err)
-	}
-	if progress < 0 || progress > 1 {
-		t.Errorf("GetSyncProgress() should return progress between 0 and 1, got: %v", progress)
-	}
-
-	// Test with real client (will fail)
-	client := NewVeChainClient("http://localhost:8669")
-	_, err = client.GetSyncProgress()
-	if err == nil {
-		t.Errorf("GetSyncProgress() should return error when no Thor node is available")
-	}
-}
-
 func TestVeChainClient_GetMempoolTransactions(t *testing.T) {
 	// Test with mock client to cover success path
 	mockClient := NewMockVeChainClient()
@@ -135,6 +120,73 @@ func TestVeChainClient_GetMempoolTransaction(t *testing.T) {
 	}
 }
 
+func TestVeChainClient_GetMempoolTransaction_ByID(t *testing.T) {
+	pendingID := thor.BytesToBytes32([]byte{0x01})
+	includedID := thor.BytesToBytes32([]byte{0x02})
+	mockThorClient := NewMockThorClient()
+	mockThorClient.SetTransactionFunc(func(txHash *thor.Bytes32, opts ...thorclient.Option) (*transactions.Transaction, error) {
+		switch *txHash {
+		case pendingID:
+			return &transactions.Transaction{ID: pendingID}, nil
+		case includedID:
+			return &transactions.Transaction{ID: includedID, Meta: &api.TxMeta{BlockNumber: 1}}, nil
+		default:
+			// The node answers null for unknown transactions
+			return &transactions.Transaction{}, nil
+		}
+	})
+	mockThorClient.SetTxPoolFunc(func(expanded bool, origin *thor.Address) (any, error) {
+		t.Error("TxPool() must not be called when the transaction can be queried by ID")
+		return nil, fmt.Errorf("unexpected call")
+	})
+	client := NewVeChainClientWithMock(mockThorClient)
+
+	if tx, err := client.GetMempoolTransaction(&pendingID); err != nil || tx.ID != pendingID {
+		t.Errorf("GetMempoolTransaction() = %v, %v, want the pending transaction", tx, err)
+	}
+	unknownID := thor.BytesToBytes32([]byte{0x03})
+	for _, txID := range []thor.Bytes32{includedID, unknownID} {
+		if _, err := client.GetMempoolTransaction(&txID); !errors.Is(err, ErrTransactionNotFound) {
+			t.Errorf("GetMempoolTransaction(%s) error = %v, want ErrTransactionNotFound", txID, err)
+		}
+	}
+}
+
+func TestVeChainClient_GetMempoolTransaction_SnapshotFallback(t *testing.T) {
+	pendingID := thor.BytesToBytes32([]byte{0x01})
+	var poolCalls atomic.Int32
+	mockThorClient := NewMockThorClient()
+	mockThorClient.SetTransactionFunc(func(txHash *thor.Bytes32, opts ...thorclient.Option) (*transactions.Transaction, error) {
+		return nil, fmt.Errorf("pending lookup unavailable")
+	})
+	mockThorClient.SetTxPoolFunc(func(expanded bool, origin *thor.Address) (any, error) {
+		poolCalls.Add(1)
+		return []transactions.Transaction{{ID: pendingID}}, nil
+	})
+	client := NewVeChainClientWithMock(mockThorClient)
+
+	// Concurrent lookups share a single download of the pool
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if tx, err := client.GetMempoolTransaction(&pendingID); err != nil || tx.ID != pendingID {
+				t.Errorf("GetMempoolTransaction() = %v, %v, want the pending transaction", tx, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	unknownID := thor.BytesToBytes32([]byte{0x02})
+	if _, err := client.GetMempoolTransaction(&unknownID); !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("GetMempoolTransaction() error = %v, want ErrTransactionNotFound", err)
+	}
+	if calls := poolCalls.Load(); calls != 1 {
+		t.Errorf("TxPool() called %d times, want 1", calls)
+	}
+}
+
 func TestVeChainClient_GetMempoolStatus(t *testing.T) {
 	client := NewVeChainClient("http://localhost:8669")
 
@@ -479,6 +531,32 @@ func TestVeChainClient_GetDynamicGasPrice_Error(t *testing.T) {
 	}
 }
 
+func TestVeChainClient_GetFeesHistory(t *testing.T) {
+	mockThorClient := NewMockThorClient()
+	mockThorClient.SetFeesHistoryFunc(func(blockCount uint32, newestBlock string, rewardPercentiles []float64) (*api.FeesHistory, error) {
+		if blockCount != 10 || newestBlock != "best" || len(rewardPercentiles) != 1 || rewardPercentiles[0] != 75 {
+			return nil, fmt.Errorf("unexpected fees history request")
+		}
+		return &api.FeesHistory{BaseFeePerGas: []*hexutil.Big{(*hexutil.Big)(big.NewInt(100))}}, nil
+	})
+	client := NewVeChainClientWithMock(mockThorClient)
+
+	feesHistory, err := client.GetFeesHistory(10, 75)
+	if err != nil {
+		t.Fatalf("GetFeesHistory() error = %v", err)
+	}
+	if len(feesHistory.BaseFeePerGas) != 1 {
+		t.Errorf("GetFeesHistory() = %v", feesHistory)
+	}
+
+	mockThorClient.SetFeesHistoryFunc(func(blockCount uint32, newestBlock string, rewardPercentiles []float64) (*api.FeesHistory, error) {
+		return nil, fmt.Errorf("fees history error")
+	})
+	if _, err := client.GetFeesHistory(10, 75); err == nil {
+		t.Error("GetFeesHistory() should return error when FeesHistory fails")
+	}
+}
+
 func TestVeChainClient_GetDynamicGasPrice_NoBaseFee(t *testing.T) {
 	mockThorClient := NewMockThorClient()
 	mockThorClient.SetFeesHistoryFunc(func(blockCount uint32, newestBlock string, rewardPercentiles []float64) (*api.FeesHistory, error) {
@@ -524,72 +602,6 @@ func TestVeChainClient_GetDynamicGasPrice_NoReward(t *testing.T) {
 	}
 }
 
-func TestVeChainClient_GetSyncProgress_GetBlockError(t *testing.T) {
-	mockThorClient := NewMockThorClient()
-	mockThorClient.SetExpandedBlockFunc(func(revision string) (*api.JSONExpandedBlock, error) {
-		return nil, fmt.Errorf("get block error")
-	})
-	client := NewVeChainClientWithMock(mockThorClient)
-
-	_, err := client.GetSyncProgress()
-	if err == nil {
-		t.Error("GetSyncProgress() should return error when GetBlock fails")
-	}
-}
-
-func TestVeChainClient_GetSyncProgress_GetGenesisError(t *testing.T) {
-	mockThorClient := NewMockThorClient()
-	callCount := 0
-	mockThorClient.SetExpandedBlockFunc(func(revision string) (*api.JSONExpandedBlock, error) {
-		callCount++
-		if callCount == 1 {
-			// Return best block successfully
-			return &api.JSONExpandedBlock{
-				JSONBlockSummary: &api.JSONBlockSummary{
-					Timestamp: 1000000,
-				},
-			}, nil
-		}
-		// Fail on genesis block
-		return nil, fmt.Errorf("genesis error")
-	})
-	client := NewVeChainClientWithMock(mockThorClient)
-
-	_, err := client.GetSyncProgress()
-	if err == nil {
-		t.Error("GetSyncProgress() should return error when getting genesis block fails")
-	}
-}
-
-func TestVeChainClient_GetSyncProgress_NegativeProgress(t *testing.T) {
-	mockThorClient := NewMockThorClient()
-	mockThorClient.SetExpandedBlockFunc(func(revision string) (*api.JSONExpandedBlock, error) {
-		if revision == "best" {
-			// Return a block with timestamp before genesis (shouldn't happen in reality)
-			return &api.JSONExpandedBlock{
-				JSONBlockSummary: &api.JSONBlockSummary{
-					Timestamp: 500000,
-				},
-			}, nil
-		}
-		// Genesis block
-		return &api.JSONExpandedBlock{
-			JSONBlockSummary: &api.JSONBlockSummary{
-				Timestamp: 1000000,
-			},
-		}, nil
-	})
-	client := NewVeChainClientWithMock(mockThorClient)
-
-	progress, err := client.GetSyncProgress()
-	if err != nil {
-		t.Errorf("GetSyncProgress() error = %v, want nil", err)
-	}
-	if progress == progress { // Check if NaN (NaN != NaN)
-		t.Error("GetSyncProgress() should return NaN for negative progress")
-	}
-}
-
 func TestVeChainClient_GetPeers_Error(t *testing.T) {
 	mockThorClient := NewMockThorClient()
 	mockThorClient.SetPeersFunc(func() ([]*api.PeerStats, error) {
@@ -753,8 +765,17 @@ func TestVeChainClient_GetTransactionReceipt_ClientError(t *testing.T) {
 	client := NewVeChainClientWithMock(mockThorClient)
 
 	_, err := client.GetTransactionReceipt("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
-	if err == nil {
-		t.Error("GetTransactionReceipt() should return error when client fails")
+	if err == nil || errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("GetTransactionReceipt() error = %v, want the client error", err)
+	}
+
+	// The node answers null for unknown transactions
+	mockThorClient.SetTransactionReceiptFunc(func(txHash *thor.Bytes32, opts ...thorclient.Option) (*api.Receipt, error) {
+		return nil, httpclient.ErrNotFound
+	})
+	_, err = client.GetTransactionReceipt("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+	if !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("GetTransactionReceipt() error = %v, want ErrTransactionNotFound", err)
 	}
 }
 
@@ -950,3 +971,71 @@ func TestVeChainClient_GetAccountAtRevision_ClientError(t *testing.T) {
 		t.Errorf("GetAccountAtRevision() error = %v, want error containing 'failed to get account'", err)
 	}
 }
+
+func TestVeChainClient_FilterTransfers(t *testing.T) {
+	mockThorClient := NewMockThorClient()
+	mockThorClient.SetFilterTransfersFunc(func(req *api.TransferFilter) ([]*api.FilteredTransfer, error) {
+		return []*api.FilteredTransfer{{Meta: api.LogMeta{BlockNumber: 10}}}, nil
+	})
+	client := NewVeChainClientWithMock(mockThorClient)
+
+	transfers, err := client.FilterTransfers(&api.TransferFilter{})
+	if err != nil {
+		t.Fatalf("FilterTransfers() error = %v", err)
+	}
+	if len(transfers) != 1 || transfers[0].Meta.BlockNumber != 10 {
+		t.Errorf("FilterTransfers() = %v", transfers)
+	}
+
+	mockThorClient.SetFilterTransfersFunc(func(req *api.TransferFilter) ([]*api.FilteredTransfer, error) {
+		return nil, fmt.Errorf("filter error")
+	})
+	if _, err := client.FilterTransfers(&api.TransferFilter{}); err == nil {
+		t.Error("FilterTransfers() should return error when the node fails")
+	}
+}
+
+func TestVeChainClient_FilterEvents(t *testing.T) {
+	mockThorClient := NewMockThorClient()
+	mockThorClient.SetFilterEventsFunc(func(req *api.EventFilter) ([]api.FilteredEvent, error) {
+		return []api.FilteredEvent{{Meta: api.LogMeta{BlockNumber: 10}}}, nil
+	})
+	client := NewVeChainClientWithMock(mockThorClient)
+
+	events, err := client.FilterEvents(&api.EventFilter{})
+	if err != nil {
+		t.Fatalf("FilterEvents() error = %v", err)
+	}
+	if len(events) != 1 || events[0].Meta.BlockNumber != 10 {
+		t.Errorf("FilterEvents() = %v", events)
+	}
+
+	mockThorClient.SetFilterEventsFunc(func(req *api.EventFilter) ([]api.FilteredEvent, error) {
+		return nil, fmt.Errorf("filter error")
+	})
+	if _, err := client.FilterEvents(&api.EventFilter{}); err == nil {
+		t.Error("FilterEvents() should return error when the node fails")
+	}
+}
+
+func TestVeChainClient_GetBlockHeader(t *testing.T) {
+	mockThorClient := NewMockThorClient()
+	client := NewVeChainClientWithMock(mockThorClient)
+
+	var requested string
+	mockThorClient.SetBlockFunc(func(revision string) (*api.JSONCollapsedBlock, error) {
+		requested = revision
+		if revision != "12" {
+			return nil, nil
+		}
+		return &api.JSONCollapsedBlock{JSONBlockSummary: &api.JSONBlockSummary{Number: 12}}, nil
+	})
+
+	header, err := client.GetBlockHeader("12")
+	if err != nil || header.Number != 12 || requested != "12" {
+		t.Errorf("GetBlockHeader() = %v, %v for revision %s", header, err, requested)
+	}
+	if _, err := client.GetBlockHeader("13"); err == nil {
+		t.Error("GetBlockHeader() expected error for a missing block")
+	}
+}
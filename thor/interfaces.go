@@ -12,12 +12,13 @@ import (
 type VeChainClientInterface interface {
 	GetBlock(revision string) (*api.JSONExpandedBlock, error)
 	GetBlockByNumber(blockNumber int64) (*api.JSONExpandedBlock, error)
+	GetBlockHeader(revision string) (*api.JSONBlockSummary, error)
 	GetAccount(address string) (*api.Account, error)
 	GetAccountAtRevision(address string, revision string) (*api.Account, error)
 	GetChainID() (int, error)
 	SubmitTransaction(vechainTx *tx.Transaction) (string, error)
 	GetDynamicGasPrice() (*DynamicGasPrice, error)
-	GetSyncProgress() (float64, error)
+	GetFeesHistory(blockCount uint32, rewardPercentile float64) (*api.FeesHistory, error)
 	GetPeers() ([]Peer, error)
 	GetMempoolTransactions(origin *thor.Address) ([]*thor.Bytes32, error)
 	GetMempoolTransaction(txID *thor.Bytes32) (*transactions.Transaction, error)
@@ -26,10 +27,13 @@ type VeChainClientInterface interface {
 	GetTransaction(txID string) (*transactions.Transaction, error)
 	GetTransactionReceipt(txID string) (*api.Receipt, error)
 	InspectClauses(batchCallData *api.BatchCallData, options ...thorclient.Option) ([]*api.CallResult, error)
+	FilterTransfers(filter *api.TransferFilter) ([]*api.FilteredTransfer, error)
+	FilterEvents(filter *api.EventFilter) ([]api.FilteredEvent, error)
 }
 
 // ThorClientInterface defines the interface for thorclient.Client methods we use
 type ThorClientInterface interface {
+	Block(revision string) (*api.JSONCollapsedBlock, error)
 	ExpandedBlock(revision string) (*api.JSONExpandedBlock, error)
 	Account(address *thor.Address, opts ...thorclient.Option) (*api.Account, error)
 	ChainTag() (byte, error)
@@ -41,4 +45,6 @@ type ThorClientInterface interface {
 	InspectClauses(batchCallData *api.BatchCallData, options ...thorclient.Option) ([]*api.CallResult, error)
 	Transaction(txHash *thor.Bytes32, opts ...thorclient.Option) (*transactions.Transaction, error)
 	TransactionReceipt(txHash *thor.Bytes32, opts ...thorclient.Option) (*api.Receipt, error)
+	FilterTransfers(req *api.TransferFilter) ([]*api.FilteredTransfer, error)
+	FilterEvents(req *api.EventFilter) ([]api.FilteredEvent, error)
 }
@@ -8,10 +8,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
 	meshcommon "github.com/vechain/mesh/common"
+	meshmetrics "github.com/vechain/mesh/common/metrics"
 )
 
 // Config represents the configuration for a Thor node
@@ -33,6 +35,20 @@ type Server struct {
 	ctx      context.Context
 	cancel   context.CancelFunc
 	thorPath string
+	// mu guards the state of the process
+	mu      sync.Mutex
+	running bool
+	exits   int
+	// exited is closed once the process was waited for, nil when it is not watched
+	exited chan struct{}
+}
+
+// ProcessStatus is the state of the Thor child process
+type ProcessStatus struct {
+	Running bool `json:"running"`
+	PID     int  `json:"pid,omitempty"`
+	// Exits counts the exits of the process that were not requested by Stop
+	Exits int `json:"exits"`
 }
 
 // NewServer creates a new Thor server instance
@@ -74,19 +90,8 @@ func (ts *Server) AttachToPublicNetworkAndStart() error {
 		"--data-dir", meshcommon.DataDirectory,
 	}
 
-	// Create the command
-	// #nosec G204 - args are constructed from controlled configuration and constants
-	ts.process = exec.CommandContext(ts.ctx, ts.thorPath, args...)
-
-	// Set up process attributes
-	ts.process.Stdout = os.Stdout
-	ts.process.Stderr = os.Stderr
-	ts.process.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
-	}
-
 	// Start the process
-	if err := ts.process.Start(); err != nil {
+	if err := ts.launch(args); err != nil {
 		return fmt.Errorf("failed to start Thor process: %v", err)
 	}
 
@@ -100,6 +105,7 @@ func (ts *Server) AttachToPublicNetworkAndStart() error {
 		return fmt.Errorf("thor process exited unexpectedly")
 	}
 
+	ts.watch()
 	return nil
 }
 
@@ -130,19 +136,8 @@ func (ts *Server) StartSoloNode() error {
 		args = append(args, "--api-cors", "*") // Default to allow all CORS
 	}
 
-	// Create the command
-	// #nosec G204 - args are constructed from controlled configuration and constants
-	ts.process = exec.CommandContext(ts.ctx, ts.thorPath, args...)
-
-	// Set up process attributes
-	ts.process.Stdout = os.Stdout
-	ts.process.Stderr = os.Stderr
-	ts.process.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
-	}
-
 	// Start the process
-	if err := ts.process.Start(); err != nil {
+	if err := ts.launch(args); err != nil {
 		return fmt.Errorf("failed to start Thor solo process: %v", err)
 	}
 
@@ -156,12 +151,73 @@ func (ts *Server) StartSoloNode() error {
 		return fmt.Errorf("thor solo process exited unexpectedly")
 	}
 
+	ts.watch()
+	return nil
+}
+
+// launch starts a new Thor process with the given arguments
+func (ts *Server) launch(args []string) error {
+	// #nosec G204 - args are constructed from controlled configuration and constants
+	process := exec.CommandContext(ts.ctx, ts.thorPath, args...)
+
+	// Set up process attributes
+	process.Stdout = os.Stdout
+	process.Stderr = os.Stderr
+	process.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid: true,
+	}
+
+	if err := process.Start(); err != nil {
+		return err
+	}
+
+	ts.mu.Lock()
+	ts.process = process
+	ts.running = true
+	ts.mu.Unlock()
 	return nil
 }
 
+// Status reports whether the Thor process is running and how many times it exited unexpectedly
+func (ts *Server) Status() ProcessStatus {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	status := ProcessStatus{Running: ts.running, Exits: ts.exits}
+	if ts.running && ts.process != nil && ts.process.Process != nil {
+		status.PID = ts.process.Process.Pid
+	}
+	return status
+}
+
+// watch waits for the Thor process in the background and counts its exit when it was not stopped
+func (ts *Server) watch() {
+	exited := make(chan struct{})
+	ts.mu.Lock()
+	ts.exited = exited
+	ts.mu.Unlock()
+
+	go func() {
+		defer close(exited)
+		err := ts.process.Wait()
+		ts.mu.Lock()
+		ts.running = false
+		unexpected := ts.ctx.Err() == nil
+		if unexpected {
+			ts.exits++
+		}
+		ts.mu.Unlock()
+		if unexpected {
+			meshmetrics.ThorProcessExits.Inc()
+			log.Printf("Thor process exited unexpectedly: %v", err)
+		}
+	}()
+}
+
 // Stop stops the Thor node
 func (ts *Server) Stop() error {
-	if ts.process == nil {
+	process := ts.process
+	if process == nil {
 		log.Println("No Thor process to stop")
 		return nil
 	}
@@ -171,10 +227,18 @@ func (ts *Server) Stop() error {
 	// Cancel the context to signal the process to stop
 	ts.cancel()
 
-	// Wait for the process to finish with a timeout
+	// Wait for the process to finish with a timeout, a watched process is already waited for
 	done := make(chan error, 1)
+	ts.mu.Lock()
+	exited := ts.exited
+	ts.mu.Unlock()
 	go func() {
-		done <- ts.process.Wait()
+		if exited != nil {
+			<-exited
+			done <- nil
+			return
+		}
+		done <- process.Wait()
 	}()
 
 	select {
@@ -189,8 +253,8 @@ func (ts *Server) Stop() error {
 		log.Println("Thor process did not stop gracefully, forcing termination...")
 
 		// Force kill the process group
-		if ts.process.Process != nil {
-			if err := syscall.Kill(-ts.process.Process.Pid, syscall.SIGKILL); err != nil {
+		if process.Process != nil {
+			if err := syscall.Kill(-process.Process.Pid, syscall.SIGKILL); err != nil {
 				log.Printf("Failed to kill Thor process: %v", err)
 				return err
 			}
@@ -8,7 +8,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	meshcommon "github.com/vechain/mesh/common"
+	meshmetrics "github.com/vechain/mesh/common/metrics"
 )
 
 func TestConfig(t *testing.T) {
@@ -223,3 +225,56 @@ func TestServer_StartSoloNode_WithMockThorBinary(t *testing.T) {
 		t.Errorf("StartSoloNode() should return error when mock Thor binary exits immediately")
 	}
 }
+
+func TestServer_Watch_CountsUnexpectedExits(t *testing.T) {
+	dir := t.TempDir()
+	exiting := filepath.Join(dir, "exiting")
+	if err := os.WriteFile(exiting, []byte("#!/bin/sh\nexit 1\n"), 0700); err != nil {
+		t.Fatalf("Failed to create mock Thor binary: %v", err)
+	}
+	sleeping := filepath.Join(dir, "sleeping")
+	if err := os.WriteFile(sleeping, []byte("#!/bin/sh\nexec sleep 60\n"), 0700); err != nil {
+		t.Fatalf("Failed to create mock Thor binary: %v", err)
+	}
+
+	start := func(thorPath string) *Server {
+		ctx, cancel := context.WithCancel(context.Background())
+		server := &Server{ctx: ctx, cancel: cancel, thorPath: thorPath}
+		if err := server.launch(nil); err != nil {
+			t.Fatalf("launch() error = %v", err)
+		}
+		server.watch()
+		return server
+	}
+
+	exits := testutil.ToFloat64(meshmetrics.ThorProcessExits)
+	server := start(exiting)
+	<-server.exited
+	if status := server.Status(); status.Running || status.Exits != 1 {
+		t.Errorf("Status() = %+v, want the exit counted", status)
+	}
+	if got := testutil.ToFloat64(meshmetrics.ThorProcessExits); got != exits+1 {
+		t.Errorf("ThorProcessExits = %v, want %v", got, exits+1)
+	}
+	_ = server.Stop()
+
+	// Stopping the process is not an unexpected exit
+	server = start(sleeping)
+	if status := server.Status(); !status.Running || status.PID == 0 {
+		t.Errorf("Status() = %+v, want the process running", status)
+	}
+	if err := server.Stop(); err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+	select {
+	case <-server.exited:
+	default:
+		t.Error("Stop() returned before the process was waited for")
+	}
+	if status := server.Status(); status.Running || status.Exits != 0 {
+		t.Errorf("Status() = %+v, want the process stopped without exits", status)
+	}
+	if got := testutil.ToFloat64(meshmetrics.ThorProcessExits); got != exits+1 {
+		t.Errorf("ThorProcessExits = %v, want %v", got, exits+1)
+	}
+}
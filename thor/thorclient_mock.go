@@ -12,6 +12,7 @@ import (
 
 // MockThorClient is a mock implementation of ThorClientInterface
 type MockThorClient struct {
+	blockFunc              func(revision string) (*api.JSONCollapsedBlock, error)
 	expandedBlockFunc      func(revision string) (*api.JSONExpandedBlock, error)
 	accountFunc            func(address *thor.Address, opts ...thorclient.Option) (*api.Account, error)
 	chainTagFunc           func() (byte, error)
@@ -23,6 +24,8 @@ type MockThorClient struct {
 	inspectClausesFunc     func(batchCallData *api.BatchCallData, options ...thorclient.Option) ([]*api.CallResult, error)
 	transactionFunc        func(txHash *thor.Bytes32, opts ...thorclient.Option) (*transactions.Transaction, error)
 	transactionReceiptFunc func(txHash *thor.Bytes32, opts ...thorclient.Option) (*api.Receipt, error)
+	filterTransfersFunc    func(req *api.TransferFilter) ([]*api.FilteredTransfer, error)
+	filterEventsFunc       func(req *api.EventFilter) ([]api.FilteredEvent, error)
 }
 
 // NewMockThorClient creates a new mock thor client
@@ -30,6 +33,13 @@ func NewMockThorClient() *MockThorClient {
 	return &MockThorClient{}
 }
 
+func (m *MockThorClient) Block(revision string) (*api.JSONCollapsedBlock, error) {
+	if m.blockFunc != nil {
+		return m.blockFunc(revision)
+	}
+	return nil, fmt.Errorf("mock not configured")
+}
+
 func (m *MockThorClient) ExpandedBlock(revision string) (*api.JSONExpandedBlock, error) {
 	if m.expandedBlockFunc != nil {
 		return m.expandedBlockFunc(revision)
@@ -107,7 +117,25 @@ func (m *MockThorClient) TransactionReceipt(txHash *thor.Bytes32, opts ...thorcl
 	return nil, fmt.Errorf("mock not configured")
 }
 
+func (m *MockThorClient) FilterTransfers(req *api.TransferFilter) ([]*api.FilteredTransfer, error) {
+	if m.filterTransfersFunc != nil {
+		return m.filterTransfersFunc(req)
+	}
+	return nil, fmt.Errorf("mock not configured")
+}
+
+func (m *MockThorClient) FilterEvents(req *api.EventFilter) ([]api.FilteredEvent, error) {
+	if m.filterEventsFunc != nil {
+		return m.filterEventsFunc(req)
+	}
+	return nil, fmt.Errorf("mock not configured")
+}
+
 // Setter methods for configuring mock behavior
+func (m *MockThorClient) SetBlockFunc(f func(revision string) (*api.JSONCollapsedBlock, error)) {
+	m.blockFunc = f
+}
+
 func (m *MockThorClient) SetExpandedBlockFunc(f func(revision string) (*api.JSONExpandedBlock, error)) {
 	m.expandedBlockFunc = f
 }
@@ -151,3 +179,11 @@ func (m *MockThorClient) SetTransactionFunc(f func(txHash *thor.Bytes32, opts ..
 func (m *MockThorClient) SetTransactionReceiptFunc(f func(txHash *thor.Bytes32, opts ...thorclient.Option) (*api.Receipt, error)) {
 	m.transactionReceiptFunc = f
 }
+
+func (m *MockThorClient) SetFilterTransfersFunc(f func(req *api.TransferFilter) ([]*api.FilteredTransfer, error)) {
+	m.filterTransfersFunc = f
+}
+
+func (m *MockThorClient) SetFilterEventsFunc(f func(req *api.EventFilter) ([]api.FilteredEvent, error)) {
+	m.filterEventsFunc = f
+}
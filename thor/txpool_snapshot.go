@@ -0,0 +1,37 @@
+package thor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vechain/thor/v2/api/transactions"
+	"github.com/vechain/thor/v2/thor"
+)
+
+// txPoolSnapshotTTL is how long a copy of the mempool is reused, about a fifth of a block
+const txPoolSnapshotTTL = 2 * time.Second
+
+// txPoolSnapshot is a short-lived copy of the expanded mempool shared by concurrent lookups.
+// Lookups arriving while the pool is downloaded wait for that download instead of starting their own.
+type txPoolSnapshot struct {
+	mu        sync.Mutex
+	fetchedAt time.Time
+	txs       map[thor.Bytes32]*transactions.Transaction
+}
+
+// get returns the snapshot, refreshed with fetch when it is older than its TTL
+func (s *txPoolSnapshot) get(fetch func() (map[thor.Bytes32]*transactions.Transaction, error)) (map[thor.Bytes32]*transactions.Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.txs != nil && time.Since(s.fetchedAt) < txPoolSnapshotTTL {
+		return s.txs, nil
+	}
+	txs, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	s.txs = txs
+	s.fetchedAt = time.Now()
+	return txs, nil
+}